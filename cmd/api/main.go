@@ -1,16 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	httphandler "github.com/adty404/kredit-plus/internal/handler/http"
+	"github.com/adty404/kredit-plus/internal/handler/job"
 	"github.com/adty404/kredit-plus/internal/platform/database"
 	"github.com/adty404/kredit-plus/internal/platform/migration"
+	"github.com/adty404/kredit-plus/internal/platform/pii"
+	"github.com/adty404/kredit-plus/internal/platform/scheduler"
 	"github.com/adty404/kredit-plus/internal/platform/seeder"
+	"github.com/adty404/kredit-plus/internal/repository/postgres"
+	"github.com/adty404/kredit-plus/internal/usecase"
 
 	"github.com/joho/godotenv"
 )
@@ -18,6 +25,12 @@ import (
 func main() {
 	// 1. Tambahkan flag untuk menjalankan seeder
 	runSeeder := flag.Bool("seed", false, "Run the database seeder to populate initial data")
+	runDelinquency := flag.Bool("assess-delinquency", false, "Run the daily delinquency assessment once and exit")
+	runLimitReview := flag.Bool("review-limits", false, "Run the credit limit review once and exit")
+	rotatePIIKey := flag.Bool("rotate-pii-key", false, "Create a new data key for consumer PII encryption and exit")
+	reencryptPII := flag.Bool(
+		"reencrypt-pii", false, "Re-encrypt all consumer PII with the active keys after key rotation and exit",
+	)
 	flag.Parse()
 
 	// 2. Coba memuat file .env
@@ -36,17 +49,87 @@ func main() {
 		log.Fatalf("Could not run database migrations: %v", err)
 	}
 
-	// 5. Cek apakah seeder harus dijalankan
+	// 5. Siapkan enkripsi data pribadi konsumen (envelope encryption dan blind index NIK)
+	piiConfig, err := pii.LoadConfig(os.Getenv)
+	if err != nil {
+		log.Fatalf("Invalid PII encryption configuration: %v", err)
+	}
+	piiCipher := pii.NewCipher(piiConfig.Keys, postgres.NewPIIDataKeyRepository(db), piiConfig.BlindIndexKey)
+	pii.Use(piiCipher)
+	if err := pii.RegisterCallbacks(db); err != nil {
+		log.Fatalf("Could not register PII callbacks: %v", err)
+	}
+
+	// 6. Jalankan rotasi DEK dan/atau enkripsi ulang data pribadi jika diminta
+	if *rotatePIIKey || *reencryptPII {
+		piiUsecase := usecase.NewPIIUsecase(piiCipher, postgres.NewConsumerRepository(db))
+		if *rotatePIIKey {
+			dataKey, err := piiUsecase.RotateDataKey()
+			if err != nil {
+				log.Fatalf("PII data key rotation failed: %v", err)
+			}
+			log.Printf("PII data key %d is now active", dataKey.ID)
+		}
+		if *reencryptPII {
+			output, err := piiUsecase.ReencryptConsumers()
+			if err != nil {
+				log.Fatalf("PII re-encryption failed: %v", err)
+			}
+			log.Printf(
+				"PII re-encryption: %d data keys rewrapped, %d consumers re-encrypted",
+				output.DataKeyDibungkusUlang,
+				output.KonsumenDienkripsi,
+			)
+			for _, message := range output.Errors {
+				log.Printf("PII re-encryption error: %s", message)
+			}
+		}
+		return
+	}
+
+	// 7. Cek apakah seeder harus dijalankan
 	if *runSeeder {
 		seeder.Run(db)
 		log.Println("Seeder has been run. Exiting.")
 		return
 	}
 
-	// 6. Setup Router HTTP
+	// 8. Siapkan job penilaian keterlambatan harian (DPD, denda, dan kolektibilitas)
+	delinquencyJob := job.NewDelinquencyJob(db)
+	if *runDelinquency {
+		if err := delinquencyJob.Run(time.Now()); err != nil {
+			log.Fatalf("Delinquency assessment failed: %v", err)
+		}
+		return
+	}
+	delinquencyJobTime, err := scheduler.ParseTimeOfDay(os.Getenv("DELINQUENCY_JOB_TIME"), 30*time.Minute)
+	if err != nil {
+		log.Fatalf("Invalid DELINQUENCY_JOB_TIME: %v", err)
+	}
+	go scheduler.RunDaily(context.Background(), "delinquency assessment", delinquencyJobTime, delinquencyJob.Run)
+
+	// 9. Jalankan job yang mengakhiri penahanan limit checkout yang kedaluwarsa
+	limitHoldJob := job.NewLimitHoldJob(db)
+	go scheduler.RunEvery(context.Background(), "limit hold sweep", job.LimitHoldSweepInterval, limitHoldJob.Run)
+
+	// 10. Siapkan job peninjauan limit berkala yang mengusulkan perubahan limit yang akan berakhir
+	limitReviewJob := job.NewCreditLimitReviewJob(db)
+	if *runLimitReview {
+		if err := limitReviewJob.Run(time.Now()); err != nil {
+			log.Fatalf("Credit limit review failed: %v", err)
+		}
+		return
+	}
+	limitReviewJobTime, err := scheduler.ParseTimeOfDay(os.Getenv("LIMIT_REVIEW_JOB_TIME"), time.Hour)
+	if err != nil {
+		log.Fatalf("Invalid LIMIT_REVIEW_JOB_TIME: %v", err)
+	}
+	go scheduler.RunDaily(context.Background(), "credit limit review", limitReviewJobTime, limitReviewJob.Run)
+
+	// 11. Setup Router HTTP
 	router := httphandler.SetupRouter(db)
 
-	// 8. Tambahkan route untuk mendapatkan informasi tentang aplikasi
+	// 12. Tambahkan route untuk mendapatkan informasi tentang aplikasi
 	port := os.Getenv("SERVE_PORT")
 	if port == "" {
 		port = "8080"
@@ -55,7 +138,7 @@ func main() {
 	fmt.Println("Application startup completed successfully!")
 	log.Printf("Starting the HTTP server on http://localhost:%s\n", port)
 
-	// 9. Mulai HTTP server
+	// 13. Mulai HTTP server
 	if err := http.ListenAndServe(":"+port, router); err != nil {
 		log.Fatalf("Failed to start HTTP server: %v", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -78,8 +79,8 @@ func validateToken(tokenString string) (*jwt.Token, error) {
 	)
 }
 
-// AuthorizeRole membuat middleware untuk memeriksa peran (role) pengguna.
-func AuthorizeRole(requiredRole string) gin.HandlerFunc {
+// AuthorizeRole membuat middleware untuk memeriksa peran (role) pengguna terhadap daftar peran yang diizinkan.
+func AuthorizeRole(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("userRole")
 		if !exists {
@@ -87,7 +88,7 @@ func AuthorizeRole(requiredRole string) gin.HandlerFunc {
 			return
 		}
 
-		if userRole.(string) != requiredRole {
+		if !slices.Contains(allowedRoles, userRole.(string)) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You are not authorized to perform this action"})
 			return
 		}
@@ -1,24 +1,58 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
+// Consumer adalah data konsumen. Nik, FullName, LegalName, TanggalLahir, dan Gaji adalah data pribadi yang
+// disimpan terenkripsi (serializer pii); pencarian berdasarkan NIK memakai blind index NikHash dan pencarian
+// nama memakai blind index per kata FullNameTokens.
 type Consumer struct {
 	ID                 uint      `gorm:"primarykey"`
 	UserID             uint      `gorm:"unique;not null"`
-	Nik                string    `gorm:"type:varchar(16);unique;not null"`
-	FullName           string    `gorm:"type:varchar(255);not null"`
-	LegalName          string    `gorm:"type:varchar(255)"`
+	Nik                string    `gorm:"type:text;not null;serializer:pii"`
+	NikHash            string    `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	FullName           string    `gorm:"type:text;not null;serializer:pii"`
+	FullNameTokens     string    `gorm:"type:text" json:"-"`
+	LegalName          string    `gorm:"type:text;serializer:pii"`
 	TempatLahir        string    `gorm:"type:varchar(100)"`
-	TanggalLahir       *JSONDate `gorm:"type:date"`
-	Gaji               float64   `gorm:"type:decimal(15,2)"`
-	OverallCreditLimit float64   `gorm:"type:decimal(19,2);not null;default:0"`
-	FotoKtp            string    `gorm:"type:varchar(255)"`
-	FotoSelfie         string    `gorm:"type:varchar(255)"`
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
+	TanggalLahir       *JSONDate `gorm:"type:text;serializer:pii"`
+	JenisKelamin       string    `gorm:"type:varchar(20)"`
+	Gaji               Money     `gorm:"type:text;serializer:pii"`
+	OverallCreditLimit Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	// PlafonBerlakuSampai adalah akhir masa berlaku plafon; nil berarti plafon belum pernah ditinjau.
+	PlafonBerlakuSampai *time.Time `gorm:"index"`
+	HariKeterlambatan   int        `gorm:"not null;default:0"`
+	Kolektibilitas      string     `gorm:"type:varchar(20);not null;default:'LANCAR'"`
+	FotoKtpID           *uint
+	FotoSelfieID        *uint
+	CreditFreeze        `gorm:"embedded"`
+	KYCVerification     `gorm:"embedded"`
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 
 	// Relasi
 	User         User                  `gorm:"foreignKey:UserID"`
+	FotoKtp      *ConsumerDocument     `gorm:"foreignKey:FotoKtpID"`
+	FotoSelfie   *ConsumerDocument     `gorm:"foreignKey:FotoSelfieID"`
 	CreditLimits []ConsumerCreditLimit `gorm:"foreignKey:ConsumerID"`
 	Transactions []Transaction         `gorm:"foreignKey:ConsumerID"`
 }
+
+// NameTokens memecah nama menjadi kata-kata berhuruf kecil tanpa duplikat, sebagai dasar blind index nama.
+func NameTokens(name string) []string {
+	var tokens []string
+	for _, token := range strings.Fields(strings.ToLower(name)) {
+		if !slices.Contains(tokens, token) {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// IsPlafonExpired menandakan masa berlaku plafon kredit konsumen sudah habis pada waktu now.
+func (c *Consumer) IsPlafonExpired(now time.Time) bool {
+	return c.PlafonBerlakuSampai != nil && !now.Before(*c.PlafonBerlakuSampai)
+}
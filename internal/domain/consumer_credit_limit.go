@@ -1,12 +1,28 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type ConsumerCreditLimit struct {
-	ID          uint    `gorm:"primarykey"`
-	ConsumerID  uint    `gorm:"not null"`
-	TenorMonths int     `gorm:"not null"`
-	CreditLimit float64 `gorm:"type:decimal(15,2);not null"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID          uint  `gorm:"primarykey"`
+	ConsumerID  uint  `gorm:"not null"`
+	TenorMonths int   `gorm:"not null"`
+	CreditLimit Money `gorm:"type:decimal(15,2);not null"`
+	// BerlakuSampai adalah akhir masa berlaku limit; nil berarti limit belum pernah ditinjau.
+	BerlakuSampai *time.Time `gorm:"index"`
+	// CreditFreeze membekukan penarikan pinjaman baru pada tenor ini saja.
+	CreditFreeze `gorm:"embedded"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// DeletedAt membuat penghapusan limit bersifat soft delete: kontrak lama tetap merujuk ke baris ini,
+	// sementara limit baru untuk tenor yang sama dapat dibuat kembali.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// IsExpired menandakan masa berlaku limit tenor sudah habis pada waktu now.
+func (l *ConsumerCreditLimit) IsExpired(now time.Time) bool {
+	return l.BerlakuSampai != nil && !now.Before(*l.BerlakuSampai)
 }
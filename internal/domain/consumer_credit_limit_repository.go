@@ -5,5 +5,9 @@ import "gorm.io/gorm"
 type ConsumerCreditLimitRepository interface {
 	WithTx(tx *gorm.DB) ConsumerCreditLimitRepository
 	Save(creditLimit *ConsumerCreditLimit) error
+	Update(id uint, updates map[string]interface{}) error
+	Delete(id uint) error
+	FindByID(id uint) (*ConsumerCreditLimit, error)
 	FindByConsumerAndTenor(consumerID uint, tenorMonths int) (*ConsumerCreditLimit, error)
+	FindByConsumerID(consumerID uint) ([]*ConsumerCreditLimit, error)
 }
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// Jenis dokumen konsumen.
+const (
+	JenisDokumenKTP    = "KTP"
+	JenisDokumenSelfie = "SELFIE"
+)
+
+// ErrDocumentNotFound dikembalikan DocumentStorage jika isi dokumen tidak ditemukan pada backend.
+var ErrDocumentNotFound = errors.New("document not found")
+
+// ConsumerDocument adalah metadata dokumen konsumen (foto KTP dan selfie) yang isinya disimpan di DocumentStorage.
+// SHA256 adalah hash isi dokumen untuk memeriksa keutuhannya. Dokumen lama tetap disimpan ketika konsumen
+// mengunggah dokumen pengganti; Consumer menunjuk dokumen yang berlaku.
+type ConsumerDocument struct {
+	ID             uint   `gorm:"primarykey"`
+	ConsumerID     uint   `gorm:"not null;index"`
+	Jenis          string `gorm:"type:varchar(20);not null"`
+	StorageBackend string `gorm:"type:varchar(20);not null"`
+	StorageKey     string `gorm:"type:varchar(255);not null"`
+	NamaFile       string `gorm:"type:varchar(255)"`
+	ContentType    string `gorm:"type:varchar(100);not null"`
+	Ukuran         int64  `gorm:"not null;default:0"`
+	SHA256         string `gorm:"column:sha256;type:varchar(64)"`
+	DiunggahOleh   *uint
+	CreatedAt      time.Time
+}
+
+// DocumentStorage menyimpan isi dokumen konsumen, misalnya di filesystem lokal atau object storage
+// yang kompatibel dengan S3.
+type DocumentStorage interface {
+	// Backend adalah nama backend yang dicatat pada ConsumerDocument.StorageBackend.
+	Backend() string
+	Put(key string, content []byte, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
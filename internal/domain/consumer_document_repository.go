@@ -0,0 +1,10 @@
+package domain
+
+import "gorm.io/gorm"
+
+type ConsumerDocumentRepository interface {
+	WithTx(tx *gorm.DB) ConsumerDocumentRepository
+	Save(document *ConsumerDocument) error
+	FindByID(id uint) (*ConsumerDocument, error)
+	FindByConsumerID(consumerID uint) ([]*ConsumerDocument, error)
+}
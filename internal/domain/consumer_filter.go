@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Relasi konsumen yang dapat dimuat pada daftar konsumen melalui parameter include.
+const (
+	RelasiKonsumenUser         = "user"
+	RelasiKonsumenCreditLimits = "credit_limits"
+	RelasiKonsumenTransactions = "transactions"
+	RelasiKonsumenDokumen      = "documents"
+)
+
+// Kolom yang dapat dipakai untuk mengurutkan daftar konsumen. Kolom data pribadi tidak dapat diurutkan karena
+// disimpan terenkripsi.
+const (
+	UrutKonsumenID                 = "id"
+	UrutKonsumenCreatedAt          = "created_at"
+	UrutKonsumenOverallCreditLimit = "overall_credit_limit"
+	UrutKonsumenHariKeterlambatan  = "hari_keterlambatan"
+)
+
+// ConsumerListFilter adalah kriteria penyaringan, pengurutan, dan halaman daftar konsumen.
+type ConsumerListFilter struct {
+	// Nik mencari NIK yang persis sama melalui blind index.
+	Nik string
+	// Nama mencari konsumen yang nama lengkapnya memuat setiap kata pada Nama (kata utuh, tanpa memperhatikan
+	// huruf besar-kecil) melalui blind index per kata, karena nama konsumen disimpan terenkripsi.
+	Nama              string
+	StatusKYC         []string
+	PunyaKontrakAktif *bool
+	// DibuatDari inklusif, DibuatSebelum eksklusif.
+	DibuatDari    *time.Time
+	DibuatSebelum *time.Time
+
+	// UrutBerdasarkan adalah salah satu konstanta UrutKonsumen; baris dengan nilai sama diurutkan menurut ID.
+	UrutBerdasarkan string
+	UrutMenurun     bool
+	// Setelah adalah posisi cursor: hanya baris sesudah posisi ini (menurut urutan) yang diambil.
+	Setelah *ConsumerCursor
+	Offset  int
+	Limit   int
+
+	// Include adalah relasi yang ikut dimuat, berisi konstanta RelasiKonsumen.
+	Include []string
+}
+
+// ConsumerCursor adalah posisi baris terakhir sebuah halaman: nilai kolom urut dan ID-nya.
+type ConsumerCursor struct {
+	Nilai interface{}
+	ID    uint
+}
@@ -11,7 +11,12 @@ type (
 		FindByUserID(userID uint) (*Consumer, error)
 		FindByID(id uint) (*Consumer, error)
 		FindByNIK(nik string) (*Consumer, error)
-		FindAll() ([]*Consumer, error)
+		FindPage(filter ConsumerListFilter) ([]*Consumer, error)
+		Count(filter ConsumerListFilter) (int64, error)
+		FindByStatusKYC(statuses []string) ([]*Consumer, error)
+		FindAfterID(afterID uint, limit int) ([]*Consumer, error)
+		FindDelinquentIDs() ([]uint, error)
+		UpdatePII(consumer *Consumer) error
 		Delete(id uint) error
 	}
 )
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// ContractSequence menyimpan nomor urut terakhir nomor kontrak untuk satu kunci (cabang dan periode bulan).
+// Kunci baru otomatis dimulai dari 1 sehingga nomor urut ter-reset setiap bulan.
+type ContractSequence struct {
+	Kunci         string `gorm:"primaryKey;type:varchar(50)"`
+	NilaiTerakhir int64  `gorm:"not null"`
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "gorm.io/gorm"
+
+type ContractSequenceRepository interface {
+	WithTx(tx *gorm.DB) ContractSequenceRepository
+	// Next menaikkan dan mengembalikan nomor urut berikutnya untuk kunci. Baris kunci tetap terkunci
+	// sampai transaksi database selesai, sehingga pemanggil paralel mendapatkan nomor yang berbeda.
+	Next(kunci string) (int64, error)
+}
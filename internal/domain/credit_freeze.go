@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// Kode alasan pembekuan kredit.
+const (
+	KodePembekuanFraud   = "FRAUD_SUSPICION"
+	KodePembekuanDPD     = "DPD_BREACH"
+	KodePembekuanKYC     = "KYC_EXPIRED"
+	KodePembekuanLainnya = "OTHER"
+)
+
+// Aksi pada riwayat pembekuan kredit.
+const (
+	PembekuanAksiFreeze   = "FREEZE"
+	PembekuanAksiUnfreeze = "UNFREEZE"
+)
+
+// KodePembekuanValid adalah daftar kode alasan pembekuan yang dapat dipakai.
+var KodePembekuanValid = []string{KodePembekuanFraud, KodePembekuanDPD, KodePembekuanKYC, KodePembekuanLainnya}
+
+// CreditFreeze adalah status pembekuan yang disematkan pada Consumer dan ConsumerCreditLimit. Selama Dibekukan
+// bernilai true, konsumen (atau limit tenor tersebut) tidak dapat menarik pinjaman baru, namun limit dan kontrak
+// berjalan tidak berubah. DibekukanOleh bernilai nil untuk pembekuan otomatis oleh sistem.
+type CreditFreeze struct {
+	Dibekukan        bool   `gorm:"not null;default:false"`
+	KodePembekuan    string `gorm:"type:varchar(30)"`
+	CatatanPembekuan string `gorm:"type:text"`
+	DibekukanOleh    *uint
+	DibekukanPada    *time.Time
+}
+
+// CreditFreezeHistory mencatat setiap pembekuan dan pencairan kredit. ConsumerCreditLimitID bernilai nil untuk
+// pembekuan di tingkat konsumen, dan ChangedBy bernilai nil untuk pembekuan otomatis oleh sistem.
+type CreditFreezeHistory struct {
+	ID                    uint `gorm:"primarykey"`
+	ConsumerID            uint `gorm:"not null;index"`
+	ConsumerCreditLimitID *uint
+	TenorMonths           int    `gorm:"not null;default:0"`
+	Aksi                  string `gorm:"type:varchar(20);not null"`
+	KodePembekuan         string `gorm:"type:varchar(30)"`
+	Alasan                string `gorm:"type:text"`
+	ChangedBy             *uint
+	CreatedAt             time.Time
+}
+
+// IsValidKodePembekuan memeriksa apakah kode termasuk KodePembekuanValid.
+func IsValidKodePembekuan(kode string) bool {
+	for _, valid := range KodePembekuanValid {
+		if kode == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// FreezeUpdates mengembalikan kolom yang diubah saat membekukan kredit, untuk dipakai dengan Update repository.
+func FreezeUpdates(kode, catatan string, dibekukanOleh *uint, dibekukanPada time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"dibekukan":         true,
+		"kode_pembekuan":    kode,
+		"catatan_pembekuan": catatan,
+		"dibekukan_oleh":    dibekukanOleh,
+		"dibekukan_pada":    dibekukanPada,
+	}
+}
+
+// UnfreezeUpdates mengembalikan kolom yang diubah saat mencairkan pembekuan kredit.
+func UnfreezeUpdates() map[string]interface{} {
+	return map[string]interface{}{
+		"dibekukan":         false,
+		"kode_pembekuan":    "",
+		"catatan_pembekuan": "",
+		"dibekukan_oleh":    nil,
+		"dibekukan_pada":    nil,
+	}
+}
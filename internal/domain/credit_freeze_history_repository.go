@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type CreditFreezeHistoryRepository interface {
+	WithTx(tx *gorm.DB) CreditFreezeHistoryRepository
+	Save(history *CreditFreezeHistory) error
+	FindByConsumerID(consumerID uint) ([]*CreditFreezeHistory, error)
+}
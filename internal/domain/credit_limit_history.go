@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// Aksi perubahan limit kredit per tenor.
+const (
+	CreditLimitAksiCreate = "CREATE"
+	CreditLimitAksiUpdate = "UPDATE"
+	CreditLimitAksiDelete = "DELETE"
+)
+
+// CreditLimitHistory mencatat setiap perubahan limit kredit per tenor: nilai sebelum dan sesudah,
+// admin yang mengubah, dan alasannya.
+type CreditLimitHistory struct {
+	ID                    uint   `gorm:"primarykey"`
+	ConsumerID            uint   `gorm:"not null;index"`
+	ConsumerCreditLimitID uint   `gorm:"not null"`
+	TenorMonths           int    `gorm:"not null"`
+	Aksi                  string `gorm:"type:varchar(20);not null"`
+	LimitSebelum          Money  `gorm:"type:decimal(15,2);not null;default:0"`
+	LimitSesudah          Money  `gorm:"type:decimal(15,2);not null;default:0"`
+	Alasan                string `gorm:"type:text"`
+	ChangedBy             uint   `gorm:"not null"`
+	CreatedAt             time.Time
+}
+
+func (CreditLimitHistory) TableName() string {
+	return "credit_limit_history"
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type CreditLimitHistoryRepository interface {
+	WithTx(tx *gorm.DB) CreditLimitHistoryRepository
+	Save(history *CreditLimitHistory) error
+	FindByConsumerID(consumerID uint) ([]*CreditLimitHistory, error)
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Status usulan peninjauan limit kredit.
+const (
+	StatusReviewMenunggu  = "MENUNGGU"
+	StatusReviewDisetujui = "DISETUJUI"
+	StatusReviewDitolak   = "DITOLAK"
+)
+
+// CreditLimitReview adalah usulan plafon dan limit per tenor hasil peninjauan berkala. Usulan dibuat oleh job
+// peninjauan dari scoring ulang (gaji dan perilaku pembayaran) ketika masa berlaku limit hampir atau sudah habis,
+// lalu menunggu keputusan admin. Limit konsumen baru berubah setelah usulan disetujui.
+type CreditLimitReview struct {
+	ID          uint   `gorm:"primarykey"`
+	ConsumerID  uint   `gorm:"not null;index"`
+	Status      string `gorm:"type:varchar(20);not null;index"`
+	VersiAturan string `gorm:"type:varchar(50)"`
+	Eligible    bool   `gorm:"not null"`
+	// BerlakuSampai adalah masa berlaku paling awal di antara plafon dan limit tenor saat usulan dibuat;
+	// nil berarti ada limit yang belum pernah ditinjau.
+	BerlakuSampai *time.Time
+	PlafonSebelum Money  `gorm:"type:decimal(19,2);not null;default:0"`
+	PlafonUsulan  Money  `gorm:"type:decimal(19,2);not null;default:0"`
+	Alasan        string `gorm:"type:text"`
+	// Keputusan admin atas usulan.
+	DiputuskanOleh   *uint
+	DiputuskanPada   *time.Time
+	CatatanKeputusan string `gorm:"type:text"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+
+	// Relasi
+	Items []CreditLimitReviewItem `gorm:"foreignKey:CreditLimitReviewID"`
+}
+
+// CreditLimitReviewItem adalah usulan limit untuk satu tenor. LimitSebelum nol berarti tenor tersebut belum
+// memiliki limit dan akan dibuat saat usulan disetujui.
+type CreditLimitReviewItem struct {
+	ID                  uint  `gorm:"primarykey"`
+	CreditLimitReviewID uint  `gorm:"not null;index"`
+	TenorMonths         int   `gorm:"not null"`
+	LimitSebelum        Money `gorm:"type:decimal(15,2);not null;default:0"`
+	LimitUsulan         Money `gorm:"type:decimal(15,2);not null;default:0"`
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CreditLimitReviewRepository interface {
+	WithTx(tx *gorm.DB) CreditLimitReviewRepository
+	Save(review *CreditLimitReview) error
+	Update(review *CreditLimitReview) error
+	FindByID(id uint) (*CreditLimitReview, error)
+	FindByIDForUpdate(id uint) (*CreditLimitReview, error)
+	FindByStatus(status string) ([]*CreditLimitReview, error)
+	FindConsumerIDsDueForReview(dueBefore, reviewedAfter time.Time) ([]uint, error)
+}
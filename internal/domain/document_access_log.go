@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Aksi akses dokumen konsumen yang dicatat pada audit log.
+const (
+	AksiDokumenUnduh            = "DOWNLOAD"
+	AksiDokumenSignedURLDibuat  = "SIGNED_URL_ISSUED"
+	AksiDokumenUnduhLewatSigned = "SIGNED_URL_DOWNLOAD"
+)
+
+// DocumentAccessLog mencatat setiap akses ke dokumen identitas konsumen: unduhan langsung, pembuatan signed URL,
+// dan unduhan melalui signed URL. DiaksesOleh adalah pengguna yang mengakses atau, untuk unduhan melalui
+// signed URL, pengguna yang menerima URL tersebut.
+type DocumentAccessLog struct {
+	ID                 uint   `gorm:"primarykey"`
+	ConsumerDocumentID uint   `gorm:"not null;index"`
+	ConsumerID         uint   `gorm:"not null;index"`
+	Aksi               string `gorm:"type:varchar(30);not null"`
+	DiaksesOleh        *uint
+	IPAddress          string `gorm:"type:varchar(45)"`
+	UserAgent          string `gorm:"type:varchar(255)"`
+	CreatedAt          time.Time
+}
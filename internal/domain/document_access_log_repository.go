@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type DocumentAccessLogRepository interface {
+	WithTx(tx *gorm.DB) DocumentAccessLogRepository
+	Save(log *DocumentAccessLog) error
+	FindByConsumerID(consumerID uint) ([]*DocumentAccessLog, error)
+}
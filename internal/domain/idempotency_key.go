@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Cakupan kunci idempotensi. Kunci yang sama boleh dipakai di cakupan berbeda.
+const (
+	IdempotencyScopeCreateTransaction = "CREATE_TRANSACTION"
+	IdempotencyScopeCreatePayment     = "CREATE_PAYMENT"
+	IdempotencyScopePayoff            = "PAYOFF"
+)
+
+// IdempotencyKey menyimpan hasil permintaan yang dikirim dengan header Idempotency-Key, sehingga
+// permintaan ulang dengan kunci dan isi yang sama mendapatkan respons yang sama tanpa diproses lagi.
+type IdempotencyKey struct {
+	ID           uint   `gorm:"primarykey"`
+	Scope        string `gorm:"type:varchar(50);not null;uniqueIndex:idx_idempotency_keys_scope_kunci"`
+	Kunci        string `gorm:"type:varchar(100);not null;uniqueIndex:idx_idempotency_keys_scope_kunci"`
+	RequestHash  string `gorm:"type:varchar(64);not null"`
+	ResponseBody string `gorm:"type:text;not null"`
+	CreatedAt    time.Time
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type IdempotencyKeyRepository interface {
+	WithTx(tx *gorm.DB) IdempotencyKeyRepository
+	Save(key *IdempotencyKey) error
+	FindByScopeAndKey(scope, kunci string) (*IdempotencyKey, error)
+}
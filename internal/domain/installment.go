@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// Status angsuran.
+const (
+	InstallmentStatusBelumBayar = "BELUM_BAYAR"
+	InstallmentStatusSebagian   = "SEBAGIAN"
+	InstallmentStatusLunas      = "LUNAS"
+	// InstallmentStatusDitutup menandai angsuran yang ditutup oleh pelunasan dipercepat;
+	// porsi bunga yang belum berjalan tidak ditagihkan.
+	InstallmentStatusDitutup = "DITUTUP"
+)
+
+// Installment merepresentasikan satu baris jadwal angsuran (amortisasi) dari sebuah transaksi.
+type Installment struct {
+	ID                uint      `gorm:"primarykey"`
+	TransactionID     uint      `gorm:"not null;uniqueIndex:idx_installment_transaction_periode"`
+	AngsuranKe        int       `gorm:"not null;uniqueIndex:idx_installment_transaction_periode"`
+	TanggalJatuhTempo time.Time `gorm:"type:date;not null"`
+	Pokok             Money     `gorm:"type:decimal(19,2);not null"`
+	Bunga             Money     `gorm:"type:decimal(19,2);not null"`
+	JumlahTagihan     Money     `gorm:"type:decimal(19,2);not null"`
+	JumlahDibayar     Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	Denda             Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	DendaDibayar      Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	BungaDibayar      Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	PokokDibayar      Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	Status            string    `gorm:"type:varchar(20);not null"`
+	TanggalLunas      *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// SisaDenda mengembalikan denda yang belum dibayar.
+func (i *Installment) SisaDenda() Money {
+	return i.Denda.Sub(i.DendaDibayar)
+}
+
+// SisaBunga mengembalikan porsi bunga yang belum dibayar.
+func (i *Installment) SisaBunga() Money {
+	return i.Bunga.Sub(i.BungaDibayar)
+}
+
+// SisaPokok mengembalikan porsi pokok yang belum dibayar.
+func (i *Installment) SisaPokok() Money {
+	return i.Pokok.Sub(i.PokokDibayar)
+}
+
+// SisaTagihan mengembalikan total kewajiban angsuran (denda, bunga, dan pokok) yang belum dibayar.
+func (i *Installment) SisaTagihan() Money {
+	return i.SisaDenda().Add(i.SisaBunga()).Add(i.SisaPokok())
+}
+
+// IsClosed menandakan angsuran sudah tidak menerima alokasi pembayaran lagi.
+func (i *Installment) IsClosed() bool {
+	return i.Status == InstallmentStatusLunas || i.Status == InstallmentStatusDitutup
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "gorm.io/gorm"
+
+type InstallmentRepository interface {
+	WithTx(tx *gorm.DB) InstallmentRepository
+	SaveAll(installments []*Installment) error
+	FindByTransactionID(transactionID uint) ([]*Installment, error)
+	FindOutstandingByTransactionID(transactionID uint) ([]*Installment, error)
+	Update(installment *Installment) error
+}
@@ -0,0 +1,27 @@
+package domain
+
+// Kualitas kredit (kolektibilitas) berdasarkan jumlah hari keterlambatan, mengikuti penggolongan OJK.
+const (
+	KolektibilitasLancar       = "LANCAR"
+	KolektibilitasDPK          = "DPK"
+	KolektibilitasKurangLancar = "KURANG_LANCAR"
+	KolektibilitasDiragukan    = "DIRAGUKAN"
+	KolektibilitasMacet        = "MACET"
+)
+
+// KolektibilitasFromDPD menggolongkan kualitas kredit dari jumlah hari keterlambatan (days past due):
+// 0 lancar, 1-90 dalam perhatian khusus, 91-120 kurang lancar, 121-180 diragukan, dan di atas 180 macet.
+func KolektibilitasFromDPD(dpd int) string {
+	switch {
+	case dpd <= 0:
+		return KolektibilitasLancar
+	case dpd <= 90:
+		return KolektibilitasDPK
+	case dpd <= 120:
+		return KolektibilitasKurangLancar
+	case dpd <= 180:
+		return KolektibilitasDiragukan
+	default:
+		return KolektibilitasMacet
+	}
+}
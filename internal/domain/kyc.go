@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// Status verifikasi identitas (KYC) konsumen.
+const (
+	StatusKYCDraft     = "DRAFT"
+	StatusKYCSubmitted = "SUBMITTED"
+	StatusKYCInReview  = "IN_REVIEW"
+	StatusKYCApproved  = "APPROVED"
+	StatusKYCRejected  = "REJECTED"
+)
+
+// kycTransitions mendefinisikan alur verifikasi KYC: status asal -> status tujuan yang diizinkan.
+// Konsumen yang ditolak dapat mengajukan ulang dokumennya.
+var kycTransitions = map[string][]string{
+	StatusKYCDraft:     {StatusKYCSubmitted},
+	StatusKYCSubmitted: {StatusKYCInReview},
+	StatusKYCInReview:  {StatusKYCApproved, StatusKYCRejected},
+	StatusKYCRejected:  {StatusKYCSubmitted},
+}
+
+// StatusKYCAntrean adalah status KYC yang menunggu tindakan reviewer.
+var StatusKYCAntrean = []string{StatusKYCSubmitted, StatusKYCInReview}
+
+// KYCVerification adalah status verifikasi identitas yang disematkan pada Consumer. Selama StatusKYC belum
+// APPROVED, konsumen tidak dapat diberi limit tenor maupun menarik pinjaman baru.
+type KYCVerification struct {
+	StatusKYC       string `gorm:"type:varchar(20);not null;default:'DRAFT';index"`
+	KYCDiajukanPada *time.Time
+	// KYCReviewer adalah admin yang terakhir meninjau atau memutuskan verifikasi.
+	KYCReviewer       *uint
+	KYCDiputuskanPada *time.Time
+	// KYCCatatan berisi alasan penolakan atau catatan persetujuan reviewer.
+	KYCCatatan string `gorm:"type:text"`
+}
+
+// KYCHistory mencatat setiap perubahan status KYC konsumen beserta alasannya. ChangedBy adalah pengguna yang
+// mengajukan dokumen atau reviewer yang memutuskan.
+type KYCHistory struct {
+	ID         uint   `gorm:"primarykey"`
+	ConsumerID uint   `gorm:"not null;index"`
+	FromStatus string `gorm:"type:varchar(20);not null"`
+	ToStatus   string `gorm:"type:varchar(20);not null"`
+	Alasan     string `gorm:"type:text"`
+	ChangedBy  *uint
+	CreatedAt  time.Time
+}
+
+// IsKYCApproved menandakan identitas konsumen sudah diverifikasi.
+func (k *KYCVerification) IsKYCApproved() bool {
+	return k.StatusKYC == StatusKYCApproved
+}
+
+// CanTransitionKYCTo memeriksa apakah status KYC boleh berpindah ke status tujuan.
+func (k *KYCVerification) CanTransitionKYCTo(status string) bool {
+	for _, allowed := range kycTransitions[k.StatusKYC] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsKYCDocuments menandakan dokumen KYC masih boleh diganti, yaitu sebelum diajukan atau setelah ditolak.
+func (k *KYCVerification) AcceptsKYCDocuments() bool {
+	return k.StatusKYC == StatusKYCDraft || k.StatusKYC == StatusKYCRejected
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type KYCHistoryRepository interface {
+	WithTx(tx *gorm.DB) KYCHistoryRepository
+	Save(history *KYCHistory) error
+	FindByConsumerID(consumerID uint) ([]*KYCHistory, error)
+}
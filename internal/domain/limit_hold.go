@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// Status penahanan limit.
+const (
+	StatusHoldAktif        = "AKTIF"
+	StatusHoldDikonfirmasi = "DIKONFIRMASI"
+	StatusHoldDilepas      = "DILEPAS"
+	StatusHoldKedaluwarsa  = "KEDALUWARSA"
+)
+
+// LimitHold adalah penahanan sementara atas plafon dan limit tenor konsumen selama proses checkout merchant.
+// Selama berstatus AKTIF dan belum melewati KedaluwarsaPada, Jumlah mengurangi sisa limit seperti kontrak berjalan.
+// Data pengajuan disimpan agar konfirmasi menghasilkan kontrak yang sama dengan yang ditahan.
+type LimitHold struct {
+	ID                    uint      `gorm:"primarykey"`
+	ConsumerID            uint      `gorm:"not null;index"`
+	ConsumerCreditLimitID uint      `gorm:"not null"`
+	TenorBulan            int       `gorm:"not null"`
+	Jumlah                Money     `gorm:"type:decimal(19,2);not null"`
+	Otr                   Money     `gorm:"type:decimal(19,2);not null"`
+	UangMuka              Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	AdminFee              Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	NamaAsset             string    `gorm:"type:varchar(255)"`
+	JenisAsset            string    `gorm:"type:varchar(50)"`
+	SumberTransaksi       string    `gorm:"type:varchar(100)"`
+	Status                string    `gorm:"type:varchar(20);not null;index"`
+	KedaluwarsaPada       time.Time `gorm:"not null"`
+	TransactionID         *uint
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// IsActive menandakan penahanan masih mengikat limit pada waktu now.
+func (h *LimitHold) IsActive(now time.Time) bool {
+	return h.Status == StatusHoldAktif && now.Before(h.KedaluwarsaPada)
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LimitHoldRepository interface {
+	WithTx(tx *gorm.DB) LimitHoldRepository
+	Save(hold *LimitHold) error
+	Update(hold *LimitHold) error
+	FindByIDForUpdate(id uint) (*LimitHold, error)
+	FindByConsumerID(consumerID uint) ([]*LimitHold, error)
+	FindActiveByConsumerID(consumerID uint, now time.Time) ([]*LimitHold, error)
+	ExpireBefore(now time.Time) (int64, error)
+}
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const senPerRupiah = 100
+
+// Money merepresentasikan nominal rupiah secara eksak dalam satuan sen (dua angka desimal),
+// sesuai dengan kolom decimal(19,2) di database. Semua pembulatan dilakukan secara eksplisit
+// dengan aturan round-half-up (menjauhi nol), tidak ada pembulatan implisit seperti pada float64.
+type Money struct {
+	sen int64
+}
+
+// NewMoney membuat Money dari nominal rupiah utuh.
+func NewMoney(rupiah int64) Money {
+	return Money{sen: rupiah * senPerRupiah}
+}
+
+// NewMoneyFromSen membuat Money dari nominal dalam satuan sen.
+func NewMoneyFromSen(sen int64) Money {
+	return Money{sen: sen}
+}
+
+// ParseMoney mengurai nominal desimal seperti "1500000", "1500000.5" atau "-12.34".
+// Nominal dengan lebih dari dua angka desimal ditolak agar tidak ada pembulatan tersembunyi.
+func ParseMoney(s string) (Money, error) {
+	raw := strings.TrimSpace(s)
+	if !isDecimalString(raw) {
+		return Money{}, fmt.Errorf("invalid money value %q", s)
+	}
+	r, ok := new(big.Rat).SetString(raw)
+	if !ok {
+		return Money{}, fmt.Errorf("invalid money value %q", s)
+	}
+
+	r.Mul(r, big.NewRat(senPerRupiah, 1))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("money value %q has more than 2 decimal places", s)
+	}
+	if !r.Num().IsInt64() {
+		return Money{}, fmt.Errorf("money value %q is out of range", s)
+	}
+	return Money{sen: r.Num().Int64()}, nil
+}
+
+// Sen mengembalikan nominal dalam satuan sen.
+func (m Money) Sen() int64 {
+	return m.sen
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{sen: m.sen + other.sen}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{sen: m.sen - other.sen}
+}
+
+// Satuan pembulatan untuk MulRound dan DivRound.
+var (
+	// SatuSen membulatkan hasil ke sen terdekat.
+	SatuSen = NewMoneyFromSen(1)
+	// SatuRupiah membulatkan hasil ke rupiah utuh terdekat.
+	SatuRupiah = NewMoney(1)
+)
+
+// MulInt mengalikan nominal dengan bilangan bulat. Hasilnya eksak sehingga tidak ada pembulatan.
+func (m Money) MulInt(n int64) Money {
+	return Money{sen: m.sen * n}
+}
+
+// MulRound mengalikan nominal dengan sebuah faktor (misalnya suku bunga) lalu membulatkan hasilnya satu kali
+// ke kelipatan unit terdekat (round-half-up). Faktor dikonversi melalui representasi desimal terpendeknya,
+// sehingga 0.02 diperlakukan tepat 2/100. Faktor NaN atau tak hingga ditolak.
+func (m Money) MulRound(factor float64, unit Money) (Money, error) {
+	return m.MulAddRound(factor, Money{}, unit)
+}
+
+// MulAddRound menghitung m x factor + addend secara eksak lalu membulatkannya satu kali ke kelipatan unit
+// terdekat (round-half-up), misalnya biaya admin = biaya tetap + persentase x OTR.
+func (m Money) MulAddRound(factor float64, addend Money, unit Money) (Money, error) {
+	f, err := exactFactor(factor)
+	if err != nil {
+		return Money{}, err
+	}
+	sen := f.Mul(f, new(big.Rat).SetInt64(m.sen))
+	return roundToUnit(sen.Add(sen, new(big.Rat).SetInt64(addend.sen)), unit)
+}
+
+// DivRound membagi nominal dengan bilangan bulat lalu membulatkan hasilnya satu kali ke kelipatan unit
+// terdekat (round-half-up). Pembagi nol ditolak.
+func (m Money) DivRound(divisor int64, unit Money) (Money, error) {
+	if divisor == 0 {
+		return Money{}, fmt.Errorf("cannot divide money value %s by zero", m)
+	}
+	return roundToUnit(big.NewRat(m.sen, divisor), unit)
+}
+
+// RoundRupiah membulatkan nominal ke rupiah utuh terdekat (round-half-up). Dipakai untuk nominal angsuran.
+func (m Money) RoundRupiah() Money {
+	rounded, _ := roundToUnit(new(big.Rat).SetInt64(m.sen), SatuRupiah)
+	return rounded
+}
+
+// Cmp mengembalikan -1, 0, atau 1 jika m lebih kecil, sama dengan, atau lebih besar dari other.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.sen < other.sen:
+		return -1
+	case m.sen > other.sen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) GreaterThan(other Money) bool {
+	return m.sen > other.sen
+}
+
+func (m Money) LessThan(other Money) bool {
+	return m.sen < other.sen
+}
+
+func (m Money) IsZero() bool {
+	return m.sen == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.sen > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.sen < 0
+}
+
+// MinMoney mengembalikan nominal terkecil dari a dan b.
+func MinMoney(a, b Money) Money {
+	if a.LessThan(b) {
+		return a
+	}
+	return b
+}
+
+// String mengembalikan nominal dengan tepat dua angka desimal, misalnya "1500000.00".
+func (m Money) String() string {
+	sign := ""
+	sen := m.sen
+	if sen < 0 {
+		sign = "-"
+		sen = -sen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, sen/senPerRupiah, sen%senPerRupiah)
+}
+
+// MarshalJSON mengimplementasikan interface json.Marshaler.
+// Nominal ditulis sebagai angka JSON dengan dua angka desimal.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON mengimplementasikan interface json.Unmarshaler.
+// Menerima angka JSON maupun string berisi angka, misalnya 1500000.50 atau "1500000.50".
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		return nil
+	}
+	parsed, err := ParseMoney(strings.Trim(raw, `"`))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Value mengimplementasikan interface driver.Valuer.
+// Nominal disimpan sebagai string desimal agar tidak melewati float64.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan mengimplementasikan interface sql.Scanner.
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Money{}
+		return nil
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		scanned, err := NewMoneyFromSen(senPerRupiah).MulRound(v, SatuSen)
+		if err != nil {
+			return fmt.Errorf("could not scan %v into Money: %w", v, err)
+		}
+		*m = scanned
+		return nil
+	}
+	return fmt.Errorf("could not scan type %T into Money", value)
+}
+
+func (m *Money) scanString(s string) error {
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// isDecimalString memastikan s hanya berisi tanda opsional, digit, dan paling banyak satu titik desimal.
+func isDecimalString(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	digits, dots := 0, 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// exactFactor mengonversi faktor float64 menjadi bilangan rasional melalui representasi desimal terpendeknya.
+func exactFactor(factor float64) (*big.Rat, error) {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return nil, fmt.Errorf("invalid money factor %v", factor)
+	}
+	f, ok := new(big.Rat).SetString(strconv.FormatFloat(factor, 'g', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("invalid money factor %v", factor)
+	}
+	return f, nil
+}
+
+// roundToUnit membulatkan nominal eksak (dalam sen) ke kelipatan unit terdekat dengan round-half-up.
+func roundToUnit(sen *big.Rat, unit Money) (Money, error) {
+	if !unit.IsPositive() {
+		return Money{}, fmt.Errorf("rounding unit must be positive, got %s", unit)
+	}
+	units := roundHalfUp(new(big.Rat).Quo(sen, new(big.Rat).SetInt64(unit.sen)))
+	result := units.Mul(units, big.NewInt(unit.sen))
+	if !result.IsInt64() {
+		return Money{}, fmt.Errorf("money value is out of range")
+	}
+	return Money{sen: result.Int64()}, nil
+}
+
+// roundHalfUp membulatkan bilangan rasional ke bilangan bulat terdekat; nilai tepat di tengah
+// dibulatkan menjauhi nol (2.5 -> 3, -2.5 -> -3).
+func roundHalfUp(r *big.Rat) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(r.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(r.Num().Sign())))
+	}
+	return quotient
+}
@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "rupiah utuh", input: "1500000", want: NewMoney(1500000)},
+		{name: "satu angka desimal", input: "1500000.5", want: NewMoneyFromSen(150000050)},
+		{name: "dua angka desimal", input: " 12.34 ", want: NewMoneyFromSen(1234)},
+		{name: "nol di belakang desimal", input: "12.340", want: NewMoneyFromSen(1234)},
+		{name: "tanda minus", input: "-12.34", want: NewMoneyFromSen(-1234)},
+		{name: "tanda plus", input: "+12.34", want: NewMoneyFromSen(1234)},
+		{name: "tanpa angka di depan titik", input: ".5", want: NewMoneyFromSen(50)},
+		{name: "kosong", input: "", wantErr: true},
+		{name: "bukan angka", input: "abc", wantErr: true},
+		{name: "notasi eksponen", input: "1e3", wantErr: true},
+		{name: "pemisah ribuan", input: "1,500,000", wantErr: true},
+		{name: "dua titik desimal", input: "1.2.3", wantErr: true},
+		{name: "tanda ganda", input: "-+12", wantErr: true},
+		{name: "tanda tanpa angka", input: "-", wantErr: true},
+		{name: "lebih dari dua angka desimal", input: "12.345", wantErr: true},
+		{name: "di luar jangkauan", input: "100000000000000000000", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got, err := ParseMoney(tc.input)
+
+				if tc.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			},
+		)
+	}
+}
+
+func TestMoney_RoundRupiah_HalfUpAwayFromZero(t *testing.T) {
+	testCases := []struct {
+		sen  int64
+		want int64
+	}{
+		{sen: 149, want: 1},
+		{sen: 150, want: 2},
+		{sen: 250, want: 3},
+		{sen: 49, want: 0},
+		{sen: -49, want: 0},
+		{sen: -149, want: -1},
+		{sen: -150, want: -2},
+		{sen: -250, want: -3},
+		{sen: -251, want: -3},
+	}
+
+	for _, tc := range testCases {
+		got := NewMoneyFromSen(tc.sen).RoundRupiah()
+
+		assert.Equal(t, NewMoney(tc.want), got, "sen %d", tc.sen)
+	}
+}
+
+func TestMoney_MulRound_RoundsOnce(t *testing.T) {
+	testCases := []struct {
+		name   string
+		amount Money
+		factor float64
+		unit   Money
+		want   Money
+	}{
+		// 1 x 0.49996 = 0,49996 rupiah: dibulatkan sekali menjadi 0, bukan 50 sen lalu 1 rupiah.
+		{
+			name: "tanpa pembulatan ganda", amount: NewMoney(1), factor: 0.49996,
+			unit: SatuRupiah, want: Money{},
+		},
+		{
+			name: "tepat di tengah dibulatkan ke atas", amount: NewMoney(1), factor: 0.5,
+			unit: SatuRupiah, want: NewMoney(1),
+		},
+		{
+			name: "negatif tepat di tengah menjauhi nol", amount: NewMoney(-1), factor: 0.5,
+			unit: SatuRupiah, want: NewMoney(-1),
+		},
+		{
+			name: "negatif di bawah tengah", amount: NewMoney(-1), factor: 0.49996,
+			unit: SatuRupiah, want: Money{},
+		},
+		{
+			name: "faktor desimal eksak", amount: NewMoneyFromSen(100000050), factor: 0.02,
+			unit: SatuSen, want: NewMoneyFromSen(2000001),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got, err := tc.amount.MulRound(tc.factor, tc.unit)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			},
+		)
+	}
+}
+
+func TestMoney_MulAddRound(t *testing.T) {
+	// 2.500.000 x 1,5% + 12.345,50 = 49.845,50 dibulatkan sekali ke 49.846.
+	got, err := NewMoney(2500000).MulAddRound(0.015, NewMoneyFromSen(1234550), SatuRupiah)
+
+	assert.NoError(t, err)
+	assert.Equal(t, NewMoney(49846), got)
+}
+
+func TestMoney_MulRound_RejectsInvalidInput(t *testing.T) {
+	amount := NewMoney(1000)
+
+	for _, factor := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := amount.MulRound(factor, SatuRupiah)
+		assert.Error(t, err, "factor %v", factor)
+	}
+	_, err := amount.MulRound(0.5, Money{})
+	assert.Error(t, err, "unit pembulatan harus positif")
+	_, err = NewMoneyFromSen(math.MaxInt64).MulRound(2, SatuSen)
+	assert.Error(t, err, "hasil di luar jangkauan int64")
+}
+
+func TestMoney_DivRound(t *testing.T) {
+	testCases := []struct {
+		name    string
+		amount  Money
+		divisor int64
+		unit    Money
+		want    Money
+	}{
+		{name: "habis dibagi", amount: NewMoney(1200000), divisor: 12, want: NewMoney(100000)},
+		{name: "sisa di bawah setengah", amount: NewMoney(100), divisor: 3, want: NewMoney(33)},
+		{name: "sisa di atas setengah", amount: NewMoney(200), divisor: 3, want: NewMoney(67)},
+		{name: "sisa tepat setengah", amount: NewMoney(5), divisor: 2, want: NewMoney(3)},
+		{
+			name: "sisa ke sen", amount: NewMoney(100), divisor: 3, unit: SatuSen,
+			want: NewMoneyFromSen(3333),
+		},
+		{name: "negatif tepat setengah", amount: NewMoney(-5), divisor: 2, want: NewMoney(-3)},
+		{name: "pembagi negatif", amount: NewMoney(5), divisor: -2, want: NewMoney(-3)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				unit := tc.unit
+				if unit.IsZero() {
+					unit = SatuRupiah
+				}
+
+				got, err := tc.amount.DivRound(tc.divisor, unit)
+
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			},
+		)
+	}
+
+	_, err := NewMoney(5).DivRound(0, SatuRupiah)
+	assert.Error(t, err, "pembagi nol harus ditolak")
+}
+
+func TestMoney_Scan(t *testing.T) {
+	testCases := []struct {
+		name    string
+		value   interface{}
+		want    Money
+		wantErr bool
+	}{
+		{name: "bytes", value: []byte("1500000.50"), want: NewMoneyFromSen(150000050)},
+		{name: "string", value: "-12.34", want: NewMoneyFromSen(-1234)},
+		{name: "int64", value: int64(1500000), want: NewMoney(1500000)},
+		{name: "float64", value: 12.34, want: NewMoneyFromSen(1234)},
+		{name: "float64 dibulatkan ke sen", value: 0.125, want: NewMoneyFromSen(13)},
+		{name: "nil", value: nil, want: Money{}},
+		{name: "string tidak valid", value: "abc", wantErr: true},
+		{name: "float64 NaN", value: math.NaN(), wantErr: true},
+		{name: "tipe tidak didukung", value: true, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				money := NewMoney(99)
+
+				err := money.Scan(tc.value)
+
+				if tc.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, money)
+			},
+		)
+	}
+}
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	// Arrange
+	type payload struct {
+		Nominal Money  `json:"nominal"`
+		Opsi    *Money `json:"opsi"`
+	}
+	original := payload{Nominal: NewMoneyFromSen(-150000005)}
+
+	// Act
+	raw, err := json.Marshal(original)
+	assert.NoError(t, err)
+	var decoded payload
+	decodeErr := json.Unmarshal(raw, &decoded)
+	var fromString payload
+	stringErr := json.Unmarshal([]byte(`{"nominal":"1500000.50"}`), &fromString)
+	var invalid payload
+	invalidErr := json.Unmarshal([]byte(`{"nominal":1.234}`), &invalid)
+
+	// Assert
+	assert.JSONEq(t, `{"nominal":-1500000.05,"opsi":null}`, string(raw))
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, original, decoded)
+	assert.NoError(t, stringErr)
+	assert.Equal(t, NewMoneyFromSen(150000050), fromString.Nominal)
+	assert.Error(t, invalidErr)
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jenis kelamin konsumen yang diturunkan dari NIK.
+const (
+	JenisKelaminLakiLaki  = "LAKI_LAKI"
+	JenisKelaminPerempuan = "PEREMPUAN"
+)
+
+// ErrInvalidNIK dibungkus oleh setiap kesalahan struktur NIK.
+var ErrInvalidNIK = errors.New("invalid NIK")
+
+//go:embed nik_wilayah.csv
+var nikWilayahCSV string
+
+// nikWilayah dimuat sekali dari tabel referensi yang di-embed; kuncinya adalah kode provinsi dua digit atau
+// kode kabupaten/kota empat digit, dan nilainya nama wilayah.
+var nikWilayah = mustLoadNIKWilayah(nikWilayahCSV)
+
+// NIK adalah Nomor Induk Kependudukan yang sudah divalidasi beserta atribut yang terkandung di dalamnya:
+// kode provinsi, kabupaten/kota, dan kecamatan (PPKKCC), tanggal lahir (DDMMYY, tanggal ditambah 40 untuk
+// perempuan), dan nomor urut.
+type NIK struct {
+	Nomor             string
+	KodeProvinsi      string
+	NamaProvinsi      string
+	KodeKabupatenKota string
+	NamaKabupatenKota string
+	KodeKecamatan     string
+	TanggalLahir      time.Time
+	JenisKelamin      string
+	NomorUrut         string
+}
+
+// ParseNIK memvalidasi struktur NIK serta kode provinsi dan kabupaten/kotanya terhadap tabel referensi, lalu
+// menurunkan tanggal lahir dan jenis kelamin. Abad tahun lahir dipilih sehingga tanggal lahir tidak melewati now.
+func ParseNIK(nomor string, now time.Time) (*NIK, error) {
+	nomor = strings.TrimSpace(nomor)
+	if len(nomor) != 16 || strings.Trim(nomor, "0123456789") != "" {
+		return nil, fmt.Errorf("%w: must be 16 digits", ErrInvalidNIK)
+	}
+
+	kodeProvinsi := nomor[0:2]
+	namaProvinsi, ok := nikWilayah[kodeProvinsi]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown province code %s", ErrInvalidNIK, kodeProvinsi)
+	}
+	namaKabupatenKota, ok := nikWilayah[nomor[0:4]]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown regency/city code %s in %s", ErrInvalidNIK, nomor[0:4], namaProvinsi)
+	}
+	if nomor[4:6] == "00" {
+		return nil, fmt.Errorf("%w: invalid district code %s", ErrInvalidNIK, nomor[0:6])
+	}
+
+	hari, _ := strconv.Atoi(nomor[6:8])
+	bulan, _ := strconv.Atoi(nomor[8:10])
+	tahun, _ := strconv.Atoi(nomor[10:12])
+	jenisKelamin := JenisKelaminLakiLaki
+	if hari > 40 {
+		hari -= 40
+		jenisKelamin = JenisKelaminPerempuan
+	}
+	tahun += 2000
+	if tahun > now.Year() {
+		tahun -= 100
+	}
+	tanggalLahir := time.Date(tahun, time.Month(bulan), hari, 0, 0, 0, 0, time.UTC)
+	if bulan < 1 || bulan > 12 || hari < 1 || tanggalLahir.Day() != hari {
+		return nil, fmt.Errorf("%w: invalid birth date %s", ErrInvalidNIK, nomor[6:12])
+	}
+	if tanggalLahir.After(now) {
+		return nil, fmt.Errorf("%w: birth date %s is in the future", ErrInvalidNIK, tanggalLahir.Format("2006-01-02"))
+	}
+
+	if nomor[12:16] == "0000" {
+		return nil, fmt.Errorf("%w: serial number must not be 0000", ErrInvalidNIK)
+	}
+
+	return &NIK{
+		Nomor:             nomor,
+		KodeProvinsi:      kodeProvinsi,
+		NamaProvinsi:      namaProvinsi,
+		KodeKabupatenKota: nomor[0:4],
+		NamaKabupatenKota: namaKabupatenKota,
+		KodeKecamatan:     nomor[0:6],
+		TanggalLahir:      tanggalLahir,
+		JenisKelamin:      jenisKelamin,
+		NomorUrut:         nomor[12:16],
+	}, nil
+}
+
+// CocokTanggalLahir memeriksa apakah tanggal lahir sama dengan yang terkandung di NIK. NIK hanya memuat dua
+// digit tahun, sehingga tahun dibandingkan tanpa abadnya.
+func (n *NIK) CocokTanggalLahir(tanggal time.Time) bool {
+	return tanggal.Day() == n.TanggalLahir.Day() &&
+		tanggal.Month() == n.TanggalLahir.Month() &&
+		tanggal.Year()%100 == n.TanggalLahir.Year()%100
+}
+
+func mustLoadNIKWilayah(raw string) map[string]string {
+	reader := csv.NewReader(strings.NewReader(raw))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	records, err := reader.ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("invalid NIK region table: %v", err))
+	}
+
+	wilayah := make(map[string]string, len(records))
+	for _, record := range records {
+		kode := record[0]
+		if (len(kode) != 2 && len(kode) != 4) || strings.Trim(kode, "0123456789") != "" {
+			panic(fmt.Sprintf("invalid NIK region code %q", kode))
+		}
+		if _, ok := wilayah[kode[0:2]]; len(kode) == 4 && !ok {
+			panic(fmt.Sprintf("NIK region %s is listed before its province", kode))
+		}
+		wilayah[kode] = record[1]
+	}
+	return wilayah
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNIK(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name             string
+		nomor            string
+		wantTanggalLahir string
+		wantJenisKelamin string
+		wantKabupaten    string
+		wantErr          string
+	}{
+		{
+			name:             "laki-laki",
+			nomor:            "3273011505900001",
+			wantTanggalLahir: "1990-05-15",
+			wantJenisKelamin: JenisKelaminLakiLaki,
+			wantKabupaten:    "Kota Bandung",
+		},
+		{
+			name:             "perempuan, tanggal ditambah 40",
+			nomor:            "3174066008920001",
+			wantTanggalLahir: "1992-08-20",
+			wantJenisKelamin: JenisKelaminPerempuan,
+			wantKabupaten:    "Kota Jakarta Barat",
+		},
+		{
+			name:             "perempuan lahir tanggal 31",
+			nomor:            "3201017101000001",
+			wantTanggalLahir: "2000-01-31",
+			wantJenisKelamin: JenisKelaminPerempuan,
+			wantKabupaten:    "Kabupaten Bogor",
+		},
+		{
+			name:             "perempuan lahir 29 Februari tahun kabisat",
+			nomor:            "3201016902000001",
+			wantTanggalLahir: "2000-02-29",
+			wantJenisKelamin: JenisKelaminPerempuan,
+			wantKabupaten:    "Kabupaten Bogor",
+		},
+		{
+			name:             "tahun dua digit setelah tahun berjalan berarti abad sebelumnya",
+			nomor:            "3273011505300001",
+			wantTanggalLahir: "1930-05-15",
+			wantJenisKelamin: JenisKelaminLakiLaki,
+			wantKabupaten:    "Kota Bandung",
+		},
+		{
+			name:             "kode lama wilayah yang dipindahkan ke provinsi baru",
+			nomor:            "6473011505900001",
+			wantTanggalLahir: "1990-05-15",
+			wantJenisKelamin: JenisKelaminLakiLaki,
+			wantKabupaten:    "Kota Tarakan (kode lama)",
+		},
+		{name: "kurang dari 16 digit", nomor: "327301150590001", wantErr: "must be 16 digits"},
+		{name: "mengandung huruf", nomor: "32730115059000A1", wantErr: "must be 16 digits"},
+		{name: "provinsi tidak dikenal", nomor: "2073011505900001", wantErr: "unknown province code 20"},
+		{name: "kabupaten di luar daftar", nomor: "3219011505900001", wantErr: "unknown regency/city code 3219"},
+		{name: "kota di luar daftar", nomor: "3280011505900001", wantErr: "unknown regency/city code 3280"},
+		{name: "kode kabupaten 00", nomor: "3200011505900001", wantErr: "unknown regency/city code 3200"},
+		{name: "celah kode kabupaten", nomor: "7323011505900001", wantErr: "unknown regency/city code 7323"},
+		{name: "provinsi tanpa kota", nomor: "7671011505900001", wantErr: "unknown regency/city code 7671"},
+		{name: "kode kecamatan 00", nomor: "3273001505900001", wantErr: "invalid district code 327300"},
+		{name: "tanggal 00", nomor: "3273010005900001", wantErr: "invalid birth date 000590"},
+		{name: "tanggal 32", nomor: "3273013205900001", wantErr: "invalid birth date 320590"},
+		{name: "tanggal 40 bukan tanggal perempuan", nomor: "3273014005900001", wantErr: "invalid birth date"},
+		{name: "perempuan tanggal 72", nomor: "3273017205900001", wantErr: "invalid birth date 720590"},
+		{name: "bulan 00", nomor: "3273011500900001", wantErr: "invalid birth date 150090"},
+		{name: "bulan 13", nomor: "3273011513900001", wantErr: "invalid birth date 151390"},
+		{name: "30 Februari", nomor: "3273013002900001", wantErr: "invalid birth date 300290"},
+		{name: "29 Februari bukan tahun kabisat", nomor: "3273012902990001", wantErr: "invalid birth date 290299"},
+		{name: "tanggal lahir di masa depan", nomor: "3273011811260001", wantErr: "birth date 2026-11-18 is in the future"},
+		{name: "nomor urut 0000", nomor: "3273011505900000", wantErr: "serial number must not be 0000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Act
+				nik, err := ParseNIK(tc.nomor, now)
+
+				// Assert
+				if tc.wantErr != "" {
+					assert.ErrorIs(t, err, ErrInvalidNIK)
+					assert.ErrorContains(t, err, tc.wantErr)
+					assert.Nil(t, nik)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantTanggalLahir, nik.TanggalLahir.Format("2006-01-02"))
+				assert.Equal(t, tc.wantJenisKelamin, nik.JenisKelamin)
+				assert.Equal(t, tc.nomor[0:4], nik.KodeKabupatenKota)
+				assert.Equal(t, tc.wantKabupaten, nik.NamaKabupatenKota)
+				assert.Equal(t, tc.nomor[0:6], nik.KodeKecamatan)
+				assert.Equal(t, tc.nomor[12:16], nik.NomorUrut)
+			},
+		)
+	}
+}
+
+func TestNIK_CocokTanggalLahir(t *testing.T) {
+	nik, err := ParseNIK("3174066008920001", time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	assert.True(t, nik.CocokTanggalLahir(time.Date(1992, 8, 20, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, nik.CocokTanggalLahir(time.Date(1992, 8, 21, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, nik.CocokTanggalLahir(time.Date(1993, 8, 20, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Jenis pembayaran.
+const (
+	JenisPembayaranAngsuran  = "ANGSURAN"
+	JenisPembayaranPelunasan = "PELUNASAN"
+)
+
+// Payment mencatat satu kali penerimaan pembayaran dari konsumen untuk sebuah transaksi.
+type Payment struct {
+	ID                uint      `gorm:"primarykey"`
+	TransactionID     uint      `gorm:"not null;index"`
+	TanggalBayar      time.Time `gorm:"not null"`
+	Jumlah            Money     `gorm:"type:decimal(19,2);not null"`
+	DialokasikanDenda Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	DialokasikanBunga Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	DialokasikanPokok Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	BiayaPelunasan    Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	KelebihanBayar    Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	JenisPembayaran   string    `gorm:"type:varchar(20);not null;default:'ANGSURAN'"`
+	MetodePembayaran  string    `gorm:"type:varchar(50)"`
+	Referensi         string    `gorm:"type:varchar(100)"`
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	// Relasi
+	Allocations []PaymentAllocation `gorm:"foreignKey:PaymentID"`
+}
+
+// PaymentAllocation mencatat porsi sebuah pembayaran yang dialokasikan ke satu angsuran.
+type PaymentAllocation struct {
+	ID            uint  `gorm:"primarykey"`
+	PaymentID     uint  `gorm:"not null;index"`
+	InstallmentID uint  `gorm:"not null;index"`
+	AngsuranKe    int   `gorm:"not null"`
+	Denda         Money `gorm:"type:decimal(19,2);not null;default:0"`
+	Bunga         Money `gorm:"type:decimal(19,2);not null;default:0"`
+	Pokok         Money `gorm:"type:decimal(19,2);not null;default:0"`
+	CreatedAt     time.Time
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type PaymentRepository interface {
+	WithTx(tx *gorm.DB) PaymentRepository
+	Save(payment *Payment) error
+	FindByTransactionID(transactionID uint) ([]*Payment, error)
+}
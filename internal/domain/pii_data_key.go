@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// PIIDataKey adalah data encryption key (DEK) untuk mengenkripsi data pribadi konsumen. DEK disimpan dalam
+// keadaan terbungkus (WrappedKey) oleh key encryption key (KEK) dengan ID KEKID yang dikelola di luar database.
+// Hanya satu DEK yang aktif untuk enkripsi baru; DEK lama tetap disimpan agar data lama dapat didekripsi.
+type PIIDataKey struct {
+	ID         uint   `gorm:"primarykey"`
+	KEKID      string `gorm:"column:kek_id;type:varchar(64);not null"`
+	WrappedKey []byte `gorm:"type:bytea;not null"`
+	Aktif      bool   `gorm:"not null;default:false;index"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// PIIKeyManager merotasi kunci enkripsi data pribadi konsumen.
+type PIIKeyManager interface {
+	// RotateDataKey membuat DEK baru yang langsung dipakai untuk enkripsi berikutnya.
+	RotateDataKey() (*PIIDataKey, error)
+	// RewrapDataKeys membungkus ulang DEK yang masih terbungkus KEK lama dengan KEK aktif, lalu mengembalikan
+	// jumlah DEK yang dibungkus ulang.
+	RewrapDataKeys() (int, error)
+}
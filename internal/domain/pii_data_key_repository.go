@@ -0,0 +1,13 @@
+package domain
+
+import "gorm.io/gorm"
+
+type PIIDataKeyRepository interface {
+	WithTx(tx *gorm.DB) PIIDataKeyRepository
+	FindActive() (*PIIDataKey, error)
+	FindByID(id uint) (*PIIDataKey, error)
+	FindAll() ([]*PIIDataKey, error)
+	// ReplaceActive menyimpan DEK baru sebagai satu-satunya DEK aktif.
+	ReplaceActive(key *PIIDataKey) error
+	Update(id uint, updates map[string]interface{}) error
+}
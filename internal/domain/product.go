@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Product adalah produk pembiayaan (misalnya MOTOR, ELEKTRONIK, WHITE_GOODS) yang menentukan tenor yang
+// ditawarkan beserta bunganya, aturan biaya admin, uang muka minimal, dan pembiayaan maksimal.
+// Kode produk dicocokkan dengan JenisAsset pada transaksi.
+type Product struct {
+	ID   uint   `gorm:"primarykey"`
+	Kode string `gorm:"type:varchar(50);uniqueIndex;not null"`
+	Nama string `gorm:"type:varchar(100);not null"`
+	// MinimalUangMukaPersen adalah uang muka minimal sebagai persentase dari OTR (0.2 = 20%).
+	MinimalUangMukaPersen float64 `gorm:"type:decimal(5,4);not null;default:0"`
+	// MaksimalPembiayaan adalah batas pokok pembiayaan per kontrak; nol berarti tanpa batas.
+	MaksimalPembiayaan Money `gorm:"type:decimal(19,2);not null;default:0"`
+	// MaksimalLTV adalah batas rasio pokok pembiayaan terhadap OTR (loan-to-value); nol berarti tanpa batas.
+	MaksimalLTV float64 `gorm:"type:decimal(5,4);not null;default:0"`
+	// Rentang OTR yang dapat dibiayai; nol berarti tanpa batas.
+	MinimalOtr  Money `gorm:"type:decimal(19,2);not null;default:0"`
+	MaksimalOtr Money `gorm:"type:decimal(19,2);not null;default:0"`
+	// Biaya admin produk = BiayaAdminTetap + BiayaAdminPersen x OTR. Jika rentang biaya admin diisi, klien boleh
+	// mengirim biaya admin lain selama berada di dalam rentang tersebut; nol berarti tanpa batas.
+	BiayaAdminTetap    Money   `gorm:"type:decimal(19,2);not null;default:0"`
+	BiayaAdminPersen   float64 `gorm:"type:decimal(5,4);not null;default:0"`
+	MinimalBiayaAdmin  Money   `gorm:"type:decimal(19,2);not null;default:0"`
+	MaksimalBiayaAdmin Money   `gorm:"type:decimal(19,2);not null;default:0"`
+	Aktif              bool    `gorm:"not null"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+
+	// Relasi
+	Tenors []ProductTenor `gorm:"foreignKey:ProductID"`
+}
+
+// ProductTenor adalah tenor yang ditawarkan sebuah produk beserta kebijakan bunga, biaya pelunasan, dan dendanya.
+type ProductTenor struct {
+	ID                       uint    `gorm:"primarykey"`
+	ProductID                uint    `gorm:"not null;uniqueIndex:idx_product_tenors_product_tenor"`
+	TenorBulan               int     `gorm:"not null;uniqueIndex:idx_product_tenors_product_tenor"`
+	MetodeBunga              string  `gorm:"type:varchar(20);not null"`
+	SukuBungaTahunan         float64 `gorm:"type:decimal(7,4);not null;default:0"`
+	BiayaPelunasanDipercepat float64 `gorm:"type:decimal(7,4);not null;default:0"`
+	DendaHarian              float64 `gorm:"type:decimal(7,4);not null;default:0"`
+	MaksimalDenda            float64 `gorm:"type:decimal(7,4);not null;default:0"`
+}
+
+// NormalizeProductCode menyeragamkan kode produk/jenis aset, misalnya "white goods" menjadi "WHITE_GOODS".
+func NormalizeProductCode(kode string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(kode), "_"))
+}
+
+// Tenor mengembalikan konfigurasi tenor produk, atau false jika tenor tidak ditawarkan.
+func (p *Product) Tenor(tenorBulan int) (*ProductTenor, bool) {
+	for i := range p.Tenors {
+		if p.Tenors[i].TenorBulan == tenorBulan {
+			return &p.Tenors[i], true
+		}
+	}
+	return nil, false
+}
+
+// BiayaAdmin menghitung biaya admin produk untuk sebuah OTR, dibulatkan ke rupiah utuh.
+func (p *Product) BiayaAdmin(otr Money) (Money, error) {
+	return otr.MulAddRound(p.BiayaAdminPersen, p.BiayaAdminTetap, SatuRupiah)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "gorm.io/gorm"
+
+type ProductRepository interface {
+	WithTx(tx *gorm.DB) ProductRepository
+	Save(product *Product) error
+	Update(product *Product) error
+	ReplaceTenors(productID uint, tenors []ProductTenor) error
+	Delete(id uint) error
+	FindByID(id uint) (*Product, error)
+	FindByKode(kode string) (*Product, error)
+	FindAll() ([]*Product, error)
+	FindAllActive() ([]*Product, error)
+}
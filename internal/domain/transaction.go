@@ -2,25 +2,82 @@ package domain
 
 import "time"
 
+// Status kontrak transaksi.
+const (
+	StatusKontrakPending         = "PENDING"
+	StatusKontrakAktif           = "AKTIF"
+	StatusKontrakLunas           = "LUNAS"
+	StatusKontrakDibatalkan      = "DIBATALKAN"
+	StatusKontrakWriteOff        = "WRITE_OFF"
+	StatusKontrakRestrukturisasi = "RESTRUKTURISASI"
+)
+
+// contractTransitions mendefinisikan siklus hidup kontrak: status asal -> status tujuan yang diizinkan.
+// LUNAS, DIBATALKAN, dan WRITE_OFF adalah status akhir.
+var contractTransitions = map[string][]string{
+	StatusKontrakPending: {StatusKontrakAktif, StatusKontrakDibatalkan},
+	StatusKontrakAktif: {
+		StatusKontrakLunas,
+		StatusKontrakDibatalkan,
+		StatusKontrakWriteOff,
+		StatusKontrakRestrukturisasi,
+	},
+	StatusKontrakRestrukturisasi: {StatusKontrakLunas, StatusKontrakWriteOff},
+}
+
+// StatusKontrakMengikatPlafon adalah status kontrak yang sisa pokoknya masih mengurangi plafon konsumen.
+// Kontrak WRITE_OFF tetap mengikat plafon karena piutangnya belum dibayar kembali.
+var StatusKontrakMengikatPlafon = []string{
+	StatusKontrakPending,
+	StatusKontrakAktif,
+	StatusKontrakRestrukturisasi,
+	StatusKontrakWriteOff,
+}
+
 type Transaction struct {
 	ID                       uint      `gorm:"primarykey"`
 	ConsumerID               uint      `gorm:"not null"`
 	ConsumerCreditLimitID    uint      `gorm:"not null"`
 	NomorKontrak             string    `gorm:"type:varchar(50);unique;not null"`
 	TanggalKontrak           time.Time `gorm:"not null"`
-	Otr                      float64   `gorm:"type:decimal(19,2);not null"`
-	UangMuka                 float64   `gorm:"type:decimal(19,2);default:0"`
-	AdminFee                 float64   `gorm:"type:decimal(19,2);default:0"`
-	PokokPembiayaanAwal      float64   `gorm:"type:decimal(19,2);not null"`
-	NilaiCicilanPerPeriode   float64   `gorm:"type:decimal(19,2);not null"`
+	Otr                      Money     `gorm:"type:decimal(19,2);not null"`
+	UangMuka                 Money     `gorm:"type:decimal(19,2);default:0"`
+	AdminFee                 Money     `gorm:"type:decimal(19,2);default:0"`
+	PokokPembiayaanAwal      Money     `gorm:"type:decimal(19,2);not null"`
+	PokokTerbayar            Money     `gorm:"type:decimal(19,2);not null;default:0"`
+	NilaiCicilanPerPeriode   Money     `gorm:"type:decimal(19,2);not null"`
 	TenorBulan               int       `gorm:"not null"`
-	TotalBunga               float64   `gorm:"type:decimal(19,2);not null"`
-	TotalKewajibanPembayaran float64   `gorm:"type:decimal(19,2);not null"`
+	MetodeBunga              string    `gorm:"type:varchar(20);not null;default:'FLAT'"`
+	SukuBungaTahunan         float64   `gorm:"type:decimal(7,4);not null;default:0"`
+	TotalBunga               Money     `gorm:"type:decimal(19,2);not null"`
+	TotalKewajibanPembayaran Money     `gorm:"type:decimal(19,2);not null"`
 	NamaAsset                string    `gorm:"type:varchar(255)"`
 	JenisAsset               string    `gorm:"type:varchar(50)"`
 	StatusKontrak            string    `gorm:"type:varchar(30);not null"`
+	HariKeterlambatan        int       `gorm:"not null;default:0"`
+	Kolektibilitas           string    `gorm:"type:varchar(20);not null;default:'LANCAR'"`
 	SumberTransaksi          string    `gorm:"type:varchar(100)"`
 	Catatan                  string    `gorm:"type:text"`
 	CreatedAt                time.Time
 	UpdatedAt                time.Time
 }
+
+// SisaPokok mengembalikan pokok pembiayaan yang masih terutang (belum dibayar kembali oleh konsumen).
+func (t *Transaction) SisaPokok() Money {
+	return t.PokokPembiayaanAwal.Sub(t.PokokTerbayar)
+}
+
+// CanTransitionTo memeriksa apakah kontrak boleh berpindah ke status tujuan.
+func (t *Transaction) CanTransitionTo(status string) bool {
+	for _, allowed := range contractTransitions[t.StatusKontrak] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// AcceptsPayment menandakan kontrak sedang berjalan dan dapat menerima pembayaran angsuran.
+func (t *Transaction) AcceptsPayment() bool {
+	return t.StatusKontrak == StatusKontrakAktif || t.StatusKontrak == StatusKontrakRestrukturisasi
+}
@@ -6,7 +6,9 @@ type TransactionRepository interface {
 	WithTx(tx *gorm.DB) TransactionRepository
 	Save(transaction *Transaction) error
 	FindByID(id uint) (*Transaction, error)
+	FindByIDForUpdate(id uint) (*Transaction, error)
 	FindByConsumerID(consumerID uint) ([]*Transaction, error)
 	FindActiveByConsumerID(consumerID uint) ([]*Transaction, error)
+	FindByStatuses(statuses []string) ([]*Transaction, error)
 	Update(transaction *Transaction) error
 }
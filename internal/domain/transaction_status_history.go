@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// TransactionStatusHistory mencatat setiap perubahan status kontrak: dari status apa, ke status apa,
+// oleh siapa, dan alasannya. ChangedBy bernilai nil untuk perubahan otomatis oleh sistem.
+type TransactionStatusHistory struct {
+	ID            uint   `gorm:"primarykey"`
+	TransactionID uint   `gorm:"not null;index"`
+	FromStatus    string `gorm:"type:varchar(30);not null"`
+	ToStatus      string `gorm:"type:varchar(30);not null"`
+	Alasan        string `gorm:"type:text"`
+	ChangedBy     *uint
+	CreatedAt     time.Time
+}
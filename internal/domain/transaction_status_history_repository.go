@@ -0,0 +1,9 @@
+package domain
+
+import "gorm.io/gorm"
+
+type TransactionStatusHistoryRepository interface {
+	WithTx(tx *gorm.DB) TransactionStatusHistoryRepository
+	Save(history *TransactionStatusHistory) error
+	FindByTransactionID(transactionID uint) ([]*TransactionStatusHistory, error)
+}
@@ -6,6 +6,16 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Peran pengguna. Admin adalah operator yang mengelola konsumen dan transaksi; compliance hanya membaca data
+// konsumen, termasuk data pribadinya secara lengkap.
+const (
+	RoleAdmin      = "admin"
+	RoleCompliance = "compliance"
+	RoleConsumer   = "consumer"
+)
+
+// User adalah akun untuk login. FullName kosong untuk akun konsumen karena nama konsumen adalah data pribadi yang
+// disimpan terenkripsi di Consumer.
 type User struct {
 	ID        uint   `gorm:"primarykey"`
 	FullName  string `gorm:"type:varchar(255);not null"`
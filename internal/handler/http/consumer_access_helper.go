@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// authorizeConsumerAccess memuat konsumen dari parameter :id dan memastikan pengguna non-admin hanya mengakses
+// data miliknya sendiri.
+func authorizeConsumerAccess(
+	c *gin.Context,
+	consumerUsecase usecase.ConsumerUsecase,
+	forbiddenMessage string,
+) (*domain.Consumer, bool) {
+	consumerID, ok := parseConsumerIDParam(c)
+	if !ok {
+		return nil, false
+	}
+
+	if c.GetString("userRole") != "admin" {
+		owner, err := consumerUsecase.GetConsumerByUserID(c.GetUint("userID"))
+		if err != nil || owner.ID != consumerID {
+			c.JSON(http.StatusForbidden, gin.H{"error": forbiddenMessage})
+			return nil, false
+		}
+	}
+
+	consumer, err := consumerUsecase.GetConsumerByID(consumerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Consumer not found"})
+		return nil, false
+	}
+	return consumer, true
+}
+
+func parseConsumerIDParam(c *gin.Context) (uint, bool) {
+	consumerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return 0, false
+	}
+	return uint(consumerID), true
+}
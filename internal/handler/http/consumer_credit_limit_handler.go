@@ -1,8 +1,11 @@
 package http
 
 import (
+	"errors"
 	"github.com/adty404/kredit-plus/internal/usecase"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
 	"net/http"
 	"strconv"
 )
@@ -36,7 +39,7 @@ func (h *ConsumerCreditLimitHandler) CreateLimitForConsumer(c *gin.Context) {
 		return
 	}
 
-	limit, err := h.usecase.CreateConsumerCreditLimit(uint(consumerID), input)
+	limit, err := h.usecase.CreateConsumerCreditLimit(uint(consumerID), c.GetUint("userID"), input)
 	if err != nil {
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
@@ -44,3 +47,191 @@ func (h *ConsumerCreditLimitHandler) CreateLimitForConsumer(c *gin.Context) {
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Credit limit created successfully", "data": limit})
 }
+
+// GetLimitsForConsumer menampilkan limit kredit konsumen untuk setiap tenor.
+func (h *ConsumerCreditLimitHandler) GetLimitsForConsumer(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	loggedInUserID := c.GetUint("userID")
+	loggedInUserRole := c.GetString("userRole")
+
+	if loggedInUserRole != "admin" {
+		consumer, err := h.consumerUsecase.GetConsumerByUserID(loggedInUserID)
+		if err != nil || consumer.ID != uint(consumerID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this consumer's limits"})
+			return
+		}
+	}
+
+	limits, err := h.usecase.GetConsumerCreditLimits(uint(consumerID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": limits})
+}
+
+// UpdateLimitForConsumer mengubah limit kredit konsumen untuk sebuah tenor.
+func (h *ConsumerCreditLimitHandler) UpdateLimitForConsumer(c *gin.Context) {
+	consumerID, tenorMonths, ok := parseConsumerTenorParams(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.UpdateConsumerCreditLimitInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	limit, err := h.usecase.UpdateConsumerCreditLimit(consumerID, tenorMonths, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credit limit updated successfully", "data": limit})
+}
+
+// DeleteLimitForConsumer menghapus limit kredit konsumen untuk sebuah tenor.
+func (h *ConsumerCreditLimitHandler) DeleteLimitForConsumer(c *gin.Context) {
+	consumerID, tenorMonths, ok := parseConsumerTenorParams(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.DeleteConsumerCreditLimitInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	err := h.usecase.DeleteConsumerCreditLimit(consumerID, tenorMonths, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credit limit deleted successfully"})
+}
+
+// GetLimitHistory menampilkan riwayat perubahan limit kredit konsumen.
+func (h *ConsumerCreditLimitHandler) GetLimitHistory(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	histories, err := h.usecase.GetCreditLimitHistory(uint(consumerID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": histories})
+}
+
+// GetLimitAvailability menampilkan pemakaian dan sisa plafon konsumen per tenor dan keseluruhan.
+func (h *ConsumerCreditLimitHandler) GetLimitAvailability(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	loggedInUserID := c.GetUint("userID")
+	loggedInUserRole := c.GetString("userRole")
+
+	if loggedInUserRole != "admin" {
+		consumer, err := h.consumerUsecase.GetConsumerByUserID(loggedInUserID)
+		if err != nil || consumer.ID != uint(consumerID) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this consumer's limits"})
+			return
+		}
+	}
+
+	availability, err := h.usecase.GetLimitAvailability(uint(consumerID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": availability})
+}
+
+// GetLimitRecommendation menampilkan rekomendasi plafon dan limit per tenor hasil scoring beserta alasannya.
+func (h *ConsumerCreditLimitHandler) GetLimitRecommendation(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	recommendation, err := h.usecase.RecommendCreditLimit(uint(consumerID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Consumer not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to compute credit limit recommendation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": presentCreditLimitRecommendation(c, recommendation)})
+}
+
+// ApplyLimitRecommendation menerapkan rekomendasi scoring, dengan override opsional dari admin.
+func (h *ConsumerCreditLimitHandler) ApplyLimitRecommendation(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	// Body boleh kosong untuk menerima rekomendasi apa adanya.
+	var input usecase.ApplyCreditLimitRecommendationInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	output, err := h.usecase.ApplyCreditLimitRecommendation(uint(consumerID), c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(
+		http.StatusOK, gin.H{
+			"message": "Credit limit recommendation applied successfully",
+			"data":    presentApplyCreditLimitRecommendation(c, output),
+		},
+	)
+}
+
+func parseConsumerTenorParams(c *gin.Context) (uint, int, bool) {
+	consumerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return 0, 0, false
+	}
+
+	tenorMonths, err := strconv.Atoi(c.Param("tenor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenor format"})
+		return 0, 0, false
+	}
+
+	return uint(consumerID), tenorMonths, true
+}
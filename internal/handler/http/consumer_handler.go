@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/adty404/kredit-plus/internal/domain"
 	"github.com/adty404/kredit-plus/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -26,24 +27,36 @@ func (h *ConsumerHandler) CreateConsumer(c *gin.Context) {
 		return
 	}
 
-	// Simpan file yang di-upload.
-	fotoKtpPath, err := SaveUploadedFile(c, input.FotoKtp, input.Nik, "ktp")
+	// Buka file yang di-upload; validasi dan penyimpanannya dilakukan oleh usecase.
+	fotoKtp, closeFotoKtp, err := openDocumentUpload(input.FotoKtp)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not save foto_ktp file", "details": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read foto_ktp file", "details": err.Error()})
 		return
 	}
-	fotoSelfiePath, err := SaveUploadedFile(c, input.FotoSelfie, input.Nik, "selfie")
+	defer closeFotoKtp()
+	fotoSelfie, closeFotoSelfie, err := openDocumentUpload(input.FotoSelfie)
 	if err != nil {
-		c.JSON(
-			http.StatusInternalServerError,
-			gin.H{"error": "Could not save foto_selfie file", "details": err.Error()},
-		)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read foto_selfie file", "details": err.Error()})
 		return
 	}
+	defer closeFotoSelfie()
 
-	// Konversi nilai string dari form ke float64.
-	gaji, _ := strconv.ParseFloat(input.Gaji, 64)
-	overallCreditLimit, _ := strconv.ParseFloat(input.OverallCreditLimit, 64)
+	// Konversi nilai string dari form ke Money.
+	gaji, err := domain.ParseMoney(input.Gaji)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid gaji value", "details": err.Error()})
+		return
+	}
+	// overall_credit_limit boleh dikosongkan; plafon kemudian ditetapkan dari rekomendasi scoring
+	// melalui POST /consumers/:id/limits/recommendation/apply.
+	var overallCreditLimit domain.Money
+	if input.OverallCreditLimit != "" {
+		overallCreditLimit, err = domain.ParseMoney(input.OverallCreditLimit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid overall_credit_limit value", "details": err.Error()})
+			return
+		}
+	}
 
 	// Siapkan input untuk usecase.
 	usecaseInput := usecase.CreateConsumerInput{
@@ -56,8 +69,8 @@ func (h *ConsumerHandler) CreateConsumer(c *gin.Context) {
 		TanggalLahir:       input.TanggalLahir,
 		Gaji:               gaji,
 		OverallCreditLimit: overallCreditLimit,
-		FotoKtpPath:        fotoKtpPath,
-		FotoSelfiePath:     fotoSelfiePath,
+		FotoKtp:            fotoKtp,
+		FotoSelfie:         fotoSelfie,
 	}
 
 	// Panggil usecase.
@@ -67,18 +80,34 @@ func (h *ConsumerHandler) CreateConsumer(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Consumer and user created successfully", "data": consumer})
+	c.JSON(
+		http.StatusCreated,
+		gin.H{"message": "Consumer and user created successfully", "data": presentConsumer(c, consumer)},
+	)
 }
 
-// GetAllConsumers menangani permintaan untuk mendapatkan semua konsumen.
+// GetAllConsumers menangani permintaan daftar konsumen dengan filter, urutan, pagination, dan include relasi.
 func (h *ConsumerHandler) GetAllConsumers(c *gin.Context) {
-	consumers, err := h.consumerUsecase.GetAllConsumers()
+	var query usecase.ConsumerListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters", "details": err.Error()})
+		return
+	}
+
+	output, err := h.consumerUsecase.ListConsumers(query)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidConsumerListQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve consumers"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": consumers})
+	c.JSON(
+		http.StatusOK,
+		gin.H{"data": presentConsumers(c, output.Consumers), "pagination": output.Pagination},
+	)
 }
 
 func (h *ConsumerHandler) GetConsumerByID(c *gin.Context) {
@@ -91,7 +120,8 @@ func (h *ConsumerHandler) GetConsumerByID(c *gin.Context) {
 	loggedInUserRole := c.GetString("userRole")
 
 	// --- LOGIKA KONTROL AKSES ---
-	if loggedInUserRole != "admin" {
+	// Admin dan compliance dapat melihat semua konsumen; data pribadinya disamarkan oleh presenter sesuai peran.
+	if loggedInUserRole != "admin" && loggedInUserRole != "compliance" {
 		consumer, err := h.consumerUsecase.GetConsumerByUserID(loggedInUserID)
 		if err != nil || consumer.ID != uint(id) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this consumer"})
@@ -109,7 +139,7 @@ func (h *ConsumerHandler) GetConsumerByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"data": consumer})
+	c.JSON(http.StatusOK, gin.H{"data": presentConsumer(c, consumer)})
 }
 
 func (h *ConsumerHandler) UpdateConsumer(c *gin.Context) {
@@ -153,7 +183,7 @@ func (h *ConsumerHandler) UpdateConsumer(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Consumer updated successfully", "data": consumer})
+	c.JSON(http.StatusOK, gin.H{"message": "Consumer updated successfully", "data": presentConsumer(c, consumer)})
 }
 
 // DeleteConsumer menangani penghapusan konsumen.
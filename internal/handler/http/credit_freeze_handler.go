@@ -0,0 +1,125 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// CreditFreezeHandler menangani pembekuan kredit konsumen dan limit tenor oleh admin.
+type CreditFreezeHandler struct {
+	uc usecase.CreditFreezeUsecase
+}
+
+func NewCreditFreezeHandler(uc usecase.CreditFreezeUsecase) *CreditFreezeHandler {
+	return &CreditFreezeHandler{uc: uc}
+}
+
+// FreezeConsumer membekukan seluruh kredit konsumen.
+func (h *CreditFreezeHandler) FreezeConsumer(c *gin.Context) {
+	consumerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	var input usecase.FreezeCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	consumer, err := h.uc.FreezeConsumer(uint(consumerID), c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Consumer credit frozen successfully", "data": presentConsumer(c, consumer)})
+}
+
+// UnfreezeConsumer mencairkan pembekuan kredit konsumen.
+func (h *CreditFreezeHandler) UnfreezeConsumer(c *gin.Context) {
+	consumerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	var input usecase.UnfreezeCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	consumer, err := h.uc.UnfreezeConsumer(uint(consumerID), c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Consumer credit unfrozen successfully", "data": presentConsumer(c, consumer)})
+}
+
+// FreezeCreditLimit membekukan limit kredit konsumen untuk sebuah tenor.
+func (h *CreditFreezeHandler) FreezeCreditLimit(c *gin.Context) {
+	consumerID, tenorMonths, ok := parseConsumerTenorParams(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.FreezeCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	limit, err := h.uc.FreezeCreditLimit(consumerID, tenorMonths, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credit limit frozen successfully", "data": limit})
+}
+
+// UnfreezeCreditLimit mencairkan pembekuan limit kredit konsumen untuk sebuah tenor.
+func (h *CreditFreezeHandler) UnfreezeCreditLimit(c *gin.Context) {
+	consumerID, tenorMonths, ok := parseConsumerTenorParams(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.UnfreezeCreditInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	limit, err := h.uc.UnfreezeCreditLimit(consumerID, tenorMonths, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credit limit unfrozen successfully", "data": limit})
+}
+
+// GetFreezeHistory menampilkan riwayat pembekuan dan pencairan kredit konsumen.
+func (h *CreditFreezeHandler) GetFreezeHistory(c *gin.Context) {
+	consumerID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	histories, err := h.uc.GetFreezeHistory(uint(consumerID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": histories})
+}
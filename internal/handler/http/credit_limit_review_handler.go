@@ -0,0 +1,101 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// CreditLimitReviewHandler menangani antrean usulan peninjauan limit berkala untuk admin.
+type CreditLimitReviewHandler struct {
+	uc usecase.CreditLimitReviewUsecase
+}
+
+func NewCreditLimitReviewHandler(uc usecase.CreditLimitReviewUsecase) *CreditLimitReviewHandler {
+	return &CreditLimitReviewHandler{uc: uc}
+}
+
+// GetLimitReviews menampilkan usulan peninjauan, dapat difilter dengan query ?status=MENUNGGU.
+func (h *CreditLimitReviewHandler) GetLimitReviews(c *gin.Context) {
+	reviews, err := h.uc.GetLimitReviews(c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": reviews})
+}
+
+// GetLimitReviewByID menampilkan satu usulan peninjauan beserta usulan limit per tenornya.
+func (h *CreditLimitReviewHandler) GetLimitReviewByID(c *gin.Context) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	review, err := h.uc.GetLimitReviewByID(reviewID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": review})
+}
+
+// ApproveLimitReview menyetujui usulan dan menerapkan limit yang diusulkan.
+func (h *CreditLimitReviewHandler) ApproveLimitReview(c *gin.Context) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	// Body boleh kosong jika admin tidak menambahkan catatan.
+	var input usecase.ApproveLimitReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	review, err := h.uc.ApproveLimitReview(reviewID, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credit limit review approved successfully", "data": review})
+}
+
+// RejectLimitReview menolak usulan tanpa mengubah limit konsumen.
+func (h *CreditLimitReviewHandler) RejectLimitReview(c *gin.Context) {
+	reviewID, ok := parseReviewID(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.RejectLimitReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	review, err := h.uc.RejectLimitReview(reviewID, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credit limit review rejected successfully", "data": review})
+}
+
+func parseReviewID(c *gin.Context) (uint, bool) {
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID format"})
+		return 0, false
+	}
+	return uint(reviewID), true
+}
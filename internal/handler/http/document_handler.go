@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// DocumentHandler menyajikan dokumen identitas konsumen (foto KTP dan selfie) kepada admin atau pemiliknya,
+// termasuk melalui signed URL berumur pendek.
+type DocumentHandler struct {
+	uc              usecase.DocumentAccessUsecase
+	consumerUsecase usecase.ConsumerUsecase
+}
+
+func NewDocumentHandler(uc usecase.DocumentAccessUsecase, consumerUsecase usecase.ConsumerUsecase) *DocumentHandler {
+	return &DocumentHandler{uc: uc, consumerUsecase: consumerUsecase}
+}
+
+// GetDocument mengirimkan isi dokumen konsumen :type (ktp atau selfie) kepada admin atau pemiliknya.
+func (h *DocumentHandler) GetDocument(c *gin.Context) {
+	consumer, ok := authorizeConsumerAccess(c, h.consumerUsecase, "You are not authorized to view this document")
+	if !ok {
+		return
+	}
+
+	content, err := h.uc.OpenDocument(consumer.ID, c.Param("type"), documentAccess(c))
+	if err != nil {
+		writeDocumentError(c, err)
+		return
+	}
+	streamDocument(c, content)
+}
+
+// CreateSignedURL membuat URL berumur pendek untuk dokumen konsumen :type sehingga UI dapat menampilkannya
+// tanpa meneruskan token JWT.
+func (h *DocumentHandler) CreateSignedURL(c *gin.Context) {
+	consumer, ok := authorizeConsumerAccess(c, h.consumerUsecase, "You are not authorized to view this document")
+	if !ok {
+		return
+	}
+
+	signedURL, err := h.uc.CreateSignedURL(consumer.ID, c.Param("type"), documentAccess(c))
+	if err != nil {
+		writeDocumentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": signedURL})
+}
+
+// GetSignedDocument mengirimkan isi dokumen melalui signed URL. Rute ini tidak memerlukan token JWT; signature
+// pada query menjadi bukti otorisasinya.
+func (h *DocumentHandler) GetSignedDocument(c *gin.Context) {
+	documentID, err := strconv.ParseUint(c.Param("documentId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID format"})
+		return
+	}
+
+	var query usecase.SignedDocumentQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": usecase.ErrInvalidDocumentSignature.Error()})
+		return
+	}
+
+	access := usecase.DocumentAccess{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+	content, err := h.uc.OpenSignedDocument(uint(documentID), query, access)
+	if err != nil {
+		writeDocumentError(c, err)
+		return
+	}
+	streamDocument(c, content)
+}
+
+// GetAccessLogs menampilkan audit log akses dokumen konsumen.
+func (h *DocumentHandler) GetAccessLogs(c *gin.Context) {
+	consumerID, ok := parseConsumerIDParam(c)
+	if !ok {
+		return
+	}
+
+	logs, err := h.uc.GetAccessLogs(consumerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": logs})
+}
+
+func documentAccess(c *gin.Context) usecase.DocumentAccess {
+	userID := c.GetUint("userID")
+	return usecase.DocumentAccess{UserID: &userID, IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// streamDocument mengirimkan isi dokumen apa adanya. Dokumen identitas tidak boleh disimpan oleh cache bersama.
+func streamDocument(c *gin.Context, content *usecase.DocumentContent) {
+	defer content.Isi.Close()
+
+	document := content.Document
+	contentType := document.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// Ukuran dokumen lama yang dipindahkan dari path file tidak diketahui.
+	contentLength := document.Ukuran
+	if contentLength <= 0 {
+		contentLength = -1
+	}
+	headers := map[string]string{
+		"Cache-Control":          "private, no-store",
+		"Content-Disposition":    fmt.Sprintf("inline; filename=%q", fmt.Sprintf("%s-%d", document.Jenis, document.ID)),
+		"X-Content-Type-Options": "nosniff",
+	}
+	if document.SHA256 != "" {
+		headers["ETag"] = strconv.Quote(document.SHA256)
+	}
+	c.DataFromReader(http.StatusOK, contentLength, contentType, content.Isi, headers)
+}
+
+// writeDocumentError memetakan error akses dokumen ke status HTTP.
+func writeDocumentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidDocumentSignature):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrDocumentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	}
+}
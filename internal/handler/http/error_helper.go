@@ -0,0 +1,33 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// writeCreateError memetakan error dari usecase pembuatan transaksi/pembayaran ke status HTTP.
+// Pelanggaran aturan bisnis dikembalikan dengan kode dan field agar klien tidak perlu mengurai pesan error.
+func writeCreateError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrIdempotencyKeyReused) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "code": usecase.RuleIdempotencyKeyReused})
+		return
+	}
+	if violation := usecase.AsRuleViolation(err); violation != nil {
+		body := gin.H{"error": violation.Message, "code": violation.Code}
+		if violation.Field != "" {
+			body["field"] = violation.Field
+		}
+		// Kredit yang dibekukan dan KYC yang belum disetujui bukan kesalahan isi permintaan, sehingga
+		// dikembalikan sebagai 403.
+		status := http.StatusUnprocessableEntity
+		if errors.Is(err, usecase.ErrCreditFrozen) || errors.Is(err, usecase.ErrKYCNotApproved) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, body)
+		return
+	}
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+}
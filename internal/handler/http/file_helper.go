@@ -1,26 +1,27 @@
 package http
 
 import (
-	"fmt"
-	"github.com/gin-gonic/gin"
 	"mime/multipart"
-	"path/filepath"
-	"time"
+
+	"github.com/adty404/kredit-plus/internal/usecase"
 )
 
-func SaveUploadedFile(c *gin.Context, file *multipart.FileHeader, nik, fileType string) (string, error) {
+// openDocumentUpload membuka file multipart sebagai DocumentUpload. Validasi dan penyimpanannya dilakukan oleh
+// usecase; pemanggil wajib memanggil fungsi close yang dikembalikan setelah usecase selesai.
+func openDocumentUpload(file *multipart.FileHeader) (*usecase.DocumentUpload, func(), error) {
 	if file == nil {
-		return "", nil // Tidak ada file yang di-upload, ini bukan error.
+		return nil, func() {}, nil // Tidak ada file yang di-upload, ini bukan error.
 	}
 
-	ext := filepath.Ext(file.Filename)
-	filename := fmt.Sprintf("%s-%s-%d%s", nik, fileType, time.Now().Unix(), ext)
-
-	uploadPath := filepath.Join("uploads", filename)
-
-	if err := c.SaveUploadedFile(file, uploadPath); err != nil {
-		return "", err
+	content, err := file.Open()
+	if err != nil {
+		return nil, func() {}, err
 	}
 
-	return uploadPath, nil
+	upload := &usecase.DocumentUpload{
+		NamaFile:    file.Filename,
+		ContentType: file.Header.Get("Content-Type"),
+		Isi:         content,
+	}
+	return upload, func() { _ = content.Close() }, nil
 }
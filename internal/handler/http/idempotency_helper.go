@@ -0,0 +1,26 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader adalah header yang dikirim klien agar permintaan ulang (retry) tidak diproses dua kali.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// readIdempotencyKey mengambil header Idempotency-Key. Mengembalikan false (dan sudah menulis respons 400)
+// jika kunci terlalu panjang.
+func readIdempotencyKey(c *gin.Context) (string, bool) {
+	key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+	if len(key) > usecase.MaxIdempotencyKeyLength {
+		c.JSON(
+			http.StatusBadRequest,
+			gin.H{"error": "Idempotency-Key must not exceed 100 characters"},
+		)
+		return "", false
+	}
+	return key, true
+}
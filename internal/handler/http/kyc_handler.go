@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// KYCHandler menangani pengajuan dokumen KYC oleh konsumen serta antrean dan keputusan reviewer.
+type KYCHandler struct {
+	uc              usecase.KYCUsecase
+	consumerUsecase usecase.ConsumerUsecase
+}
+
+func NewKYCHandler(uc usecase.KYCUsecase, consumerUsecase usecase.ConsumerUsecase) *KYCHandler {
+	return &KYCHandler{uc: uc, consumerUsecase: consumerUsecase}
+}
+
+// SubmitKYC mengajukan (ulang) dokumen KYC. Foto yang tidak di-upload memakai dokumen yang sudah tersimpan.
+func (h *KYCHandler) SubmitKYC(c *gin.Context) {
+	consumer, ok := authorizeConsumerAccess(
+		c, h.consumerUsecase, "You are not authorized to submit KYC for this consumer",
+	)
+	if !ok {
+		return
+	}
+
+	var form usecase.SubmitKYCFormInput
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validation failed", "details": err.Error()})
+		return
+	}
+
+	fotoKtp, closeFotoKtp, err := openDocumentUpload(form.FotoKtp)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read foto_ktp file", "details": err.Error()})
+		return
+	}
+	defer closeFotoKtp()
+	fotoSelfie, closeFotoSelfie, err := openDocumentUpload(form.FotoSelfie)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Could not read foto_selfie file", "details": err.Error()})
+		return
+	}
+	defer closeFotoSelfie()
+
+	input := usecase.SubmitKYCInput{FotoKtp: fotoKtp, FotoSelfie: fotoSelfie}
+	submitted, err := h.uc.SubmitKYC(consumer.ID, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "KYC submitted successfully", "data": presentConsumer(c, submitted)})
+}
+
+// GetKYCHistory menampilkan riwayat status KYC konsumen beserta alasan penolakannya.
+func (h *KYCHandler) GetKYCHistory(c *gin.Context) {
+	consumer, ok := authorizeConsumerAccess(c, h.consumerUsecase, "You are not authorized to view this consumer's KYC")
+	if !ok {
+		return
+	}
+
+	histories, err := h.uc.GetKYCHistory(consumer.ID)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": histories})
+}
+
+// GetKYCQueue menampilkan antrean KYC untuk reviewer, dapat difilter dengan ?status=.
+func (h *KYCHandler) GetKYCQueue(c *gin.Context) {
+	consumers, err := h.uc.GetKYCQueue(c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": presentConsumers(c, consumers)})
+}
+
+// StartKYCReview mengambil pengajuan KYC dari antrean untuk ditinjau.
+func (h *KYCHandler) StartKYCReview(c *gin.Context) {
+	consumerID, ok := parseConsumerIDParam(c)
+	if !ok {
+		return
+	}
+
+	consumer, err := h.uc.StartKYCReview(consumerID, c.GetUint("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "KYC review started", "data": presentConsumer(c, consumer)})
+}
+
+// ApproveKYC menyetujui KYC yang sedang ditinjau. Body berisi catatan opsional.
+func (h *KYCHandler) ApproveKYC(c *gin.Context) {
+	consumerID, ok := parseConsumerIDParam(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.ApproveKYCInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	consumer, err := h.uc.ApproveKYC(consumerID, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "KYC approved successfully", "data": presentConsumer(c, consumer)})
+}
+
+// RejectKYC menolak KYC yang sedang ditinjau dengan alasan yang wajib diisi.
+func (h *KYCHandler) RejectKYC(c *gin.Context) {
+	consumerID, ok := parseConsumerIDParam(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.RejectKYCInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	consumer, err := h.uc.RejectKYC(consumerID, c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "KYC rejected successfully", "data": presentConsumer(c, consumer)})
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// LimitHoldHandler menangani penahanan limit untuk checkout merchant dua langkah: konsumen memilih pembiayaan
+// (limit ditahan), lalu merchant mengonfirmasi pengiriman (penahanan menjadi kontrak) atau membatalkannya.
+type LimitHoldHandler struct {
+	transactionUC usecase.TransactionUsecase
+	uc            usecase.LimitHoldUsecase
+	consumerRepo  domain.ConsumerRepository
+}
+
+func NewLimitHoldHandler(
+	transactionUC usecase.TransactionUsecase,
+	uc usecase.LimitHoldUsecase,
+	consumerRepo domain.ConsumerRepository,
+) *LimitHoldHandler {
+	return &LimitHoldHandler{
+		transactionUC: transactionUC,
+		uc:            uc,
+		consumerRepo:  consumerRepo,
+	}
+}
+
+// CreateLimitHold menahan limit konsumen dengan body yang sama seperti pembuatan transaksi.
+func (h *LimitHoldHandler) CreateLimitHold(c *gin.Context) {
+	consumerID, ok := h.authorizeConsumer(c)
+	if !ok {
+		return
+	}
+
+	var input usecase.CreateTransactionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	hold, err := h.transactionUC.CreateLimitHold(consumerID, input)
+	if err != nil {
+		writeCreateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Credit limit held successfully", "data": hold})
+}
+
+func (h *LimitHoldHandler) GetLimitHolds(c *gin.Context) {
+	consumerID, ok := h.authorizeConsumer(c)
+	if !ok {
+		return
+	}
+
+	holds, err := h.uc.GetLimitHolds(consumerID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": holds})
+}
+
+// ConfirmLimitHold membuat kontrak dari penahanan limit yang masih aktif.
+func (h *LimitHoldHandler) ConfirmLimitHold(c *gin.Context) {
+	consumerID, ok := h.authorizeConsumer(c)
+	if !ok {
+		return
+	}
+	holdID, ok := parseHoldID(c)
+	if !ok {
+		return
+	}
+
+	transaction, err := h.transactionUC.ConfirmLimitHold(consumerID, holdID)
+	if err != nil {
+		writeCreateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Limit hold confirmed successfully", "data": transaction})
+}
+
+// ReleaseLimitHold melepas penahanan limit sebelum batas waktunya.
+func (h *LimitHoldHandler) ReleaseLimitHold(c *gin.Context) {
+	consumerID, ok := h.authorizeConsumer(c)
+	if !ok {
+		return
+	}
+	holdID, ok := parseHoldID(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.uc.ReleaseLimitHold(consumerID, holdID)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Limit hold released successfully", "data": hold})
+}
+
+// authorizeConsumer membaca consumer ID dari URL dan memastikan pengguna non-admin hanya mengakses
+// penahanan limit miliknya sendiri.
+func (h *LimitHoldHandler) authorizeConsumer(c *gin.Context) (uint, bool) {
+	consumerIDFromURL, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return 0, false
+	}
+
+	if c.GetString("userRole") != "admin" {
+		consumer, err := h.consumerRepo.FindByUserID(c.GetUint("userID"))
+		if err != nil || consumer.ID != uint(consumerIDFromURL) {
+			c.JSON(
+				http.StatusForbidden,
+				gin.H{"error": "You are not authorized to manage limit holds for this consumer"},
+			)
+			return 0, false
+		}
+	}
+
+	return uint(consumerIDFromURL), true
+}
+
+func parseHoldID(c *gin.Context) (uint, bool) {
+	holdID, err := strconv.ParseUint(c.Param("holdId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit hold ID format"})
+		return 0, false
+	}
+	return uint(holdID), true
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	uc           usecase.PaymentUsecase
+	consumerRepo domain.ConsumerRepository
+}
+
+func NewPaymentHandler(uc usecase.PaymentUsecase, consumerRepo domain.ConsumerRepository) *PaymentHandler {
+	return &PaymentHandler{
+		uc:           uc,
+		consumerRepo: consumerRepo,
+	}
+}
+
+// CreatePayment menangani pencatatan pembayaran angsuran untuk sebuah transaksi.
+func (h *PaymentHandler) CreatePayment(c *gin.Context) {
+	idStr := c.Param("id")
+	transactionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
+		return
+	}
+
+	var input usecase.CreatePaymentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+	idempotencyKey, ok := readIdempotencyKey(c)
+	if !ok {
+		return
+	}
+	input.IdempotencyKey = idempotencyKey
+
+	payment, err := h.uc.CreatePayment(uint(transactionID), input)
+	if err != nil {
+		writeCreateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Payment recorded successfully", "data": payment})
+}
+
+// GetPayoffQuote menghitung nilai pelunasan dipercepat sebuah kontrak. Query opsional: tanggal=yyyy-MM-dd.
+func (h *PaymentHandler) GetPayoffQuote(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerIDFromURL, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	trxIDStr := c.Param("trxId")
+	transactionID, err := strconv.ParseUint(trxIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
+		return
+	}
+
+	loggedInUserID := c.GetUint("userID")
+	loggedInUserRole := c.GetString("userRole")
+
+	if loggedInUserRole != "admin" {
+		consumer, err := h.consumerRepo.FindByUserID(loggedInUserID)
+		if err != nil || consumer.ID != uint(consumerIDFromURL) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this transaction"})
+			return
+		}
+	}
+
+	quote, err := h.uc.GetPayoffQuote(uint(consumerIDFromURL), uint(transactionID), c.Query("tanggal"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": quote})
+}
+
+// PayoffTransaction menangani pelunasan dipercepat sebuah transaksi.
+func (h *PaymentHandler) PayoffTransaction(c *gin.Context) {
+	idStr := c.Param("id")
+	transactionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
+		return
+	}
+
+	var input usecase.CreatePaymentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+	idempotencyKey, ok := readIdempotencyKey(c)
+	if !ok {
+		return
+	}
+	input.IdempotencyKey = idempotencyKey
+
+	payment, err := h.uc.PayoffTransaction(uint(transactionID), input)
+	if err != nil {
+		writeCreateError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Transaction paid off successfully", "data": payment})
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// UserResponse adalah representasi pengguna pada respons API. Hash password tidak pernah ikut dikirim.
+type UserResponse struct {
+	ID        uint
+	FullName  string
+	Email     string
+	Role      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ConsumerResponse adalah representasi konsumen pada respons API. Nama field mengikuti respons sebelumnya
+// (domain.Consumer tanpa tag json) agar klien tidak perlu berubah. Untuk pemanggil yang tidak berhak melihat
+// data pribadi lengkap, Nik disamarkan serta TanggalLahir dan Gaji dikosongkan (null).
+type ConsumerResponse struct {
+	ID                  uint
+	UserID              uint
+	Nik                 string
+	FullName            string
+	LegalName           string
+	TempatLahir         string
+	TanggalLahir        *domain.JSONDate
+	JenisKelamin        string
+	Gaji                *domain.Money
+	OverallCreditLimit  domain.Money
+	PlafonBerlakuSampai *time.Time
+	HariKeterlambatan   int
+	Kolektibilitas      string
+	FotoKtpID           *uint
+	FotoSelfieID        *uint
+	domain.CreditFreeze
+	domain.KYCVerification
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Relasi; User bernilai null jika tidak dimuat.
+	User         *UserResponse
+	FotoKtp      *domain.ConsumerDocument
+	FotoSelfie   *domain.ConsumerDocument
+	CreditLimits []domain.ConsumerCreditLimit
+	Transactions []domain.Transaction
+}
+
+func presentUser(user *domain.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID,
+		FullName:  user.FullName,
+		Email:     user.Email,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// presentConsumer menyiapkan respons konsumen sesuai hak pemanggil atas data pribadinya: compliance dan
+// konsumen pemilik data melihat data lengkap, selain itu data pribadi disamarkan.
+func presentConsumer(c *gin.Context, consumer *domain.Consumer) *ConsumerResponse {
+	gaji := consumer.Gaji
+	response := &ConsumerResponse{
+		ID:                  consumer.ID,
+		UserID:              consumer.UserID,
+		Nik:                 consumer.Nik,
+		FullName:            consumer.FullName,
+		LegalName:           consumer.LegalName,
+		TempatLahir:         consumer.TempatLahir,
+		TanggalLahir:        consumer.TanggalLahir,
+		JenisKelamin:        consumer.JenisKelamin,
+		Gaji:                &gaji,
+		OverallCreditLimit:  consumer.OverallCreditLimit,
+		PlafonBerlakuSampai: consumer.PlafonBerlakuSampai,
+		HariKeterlambatan:   consumer.HariKeterlambatan,
+		Kolektibilitas:      consumer.Kolektibilitas,
+		FotoKtpID:           consumer.FotoKtpID,
+		FotoSelfieID:        consumer.FotoSelfieID,
+		CreditFreeze:        consumer.CreditFreeze,
+		KYCVerification:     consumer.KYCVerification,
+		CreatedAt:           consumer.CreatedAt,
+		UpdatedAt:           consumer.UpdatedAt,
+		FotoKtp:             consumer.FotoKtp,
+		FotoSelfie:          consumer.FotoSelfie,
+		CreditLimits:        consumer.CreditLimits,
+		Transactions:        consumer.Transactions,
+	}
+	if consumer.User.ID != 0 {
+		// Nama akun konsumen tidak disimpan di users; respons tetap menampilkan nama konsumen.
+		response.User = presentUser(&consumer.User)
+		response.User.FullName = consumer.FullName
+	}
+
+	isOwner := c.GetUint("userID") == consumer.UserID
+	if !usecase.CanViewFullPII(c.GetString("userRole"), isOwner) {
+		response.Nik = usecase.MaskNIK(consumer.Nik)
+		response.TanggalLahir = nil
+		response.Gaji = nil
+	}
+	return response
+}
+
+func presentConsumers(c *gin.Context, consumers []*domain.Consumer) []*ConsumerResponse {
+	responses := make([]*ConsumerResponse, 0, len(consumers))
+	for _, consumer := range consumers {
+		responses = append(responses, presentConsumer(c, consumer))
+	}
+	return responses
+}
+
+// CreditLimitRecommendationResponse adalah representasi hasil scoring limit pada respons API. Untuk pemanggil
+// yang tidak berhak melihat data pribadi lengkap, Usia dan Gaji dikosongkan (hanya KelompokUsia yang tampil),
+// begitu pula RasioDTI dan KapasitasAngsuran karena gaji dapat dihitung kembali dari keduanya.
+type CreditLimitRecommendationResponse struct {
+	ConsumerID         uint                            `json:"consumer_id"`
+	VersiAturan        string                          `json:"versi_aturan"`
+	Eligible           bool                            `json:"eligible"`
+	Usia               *int                            `json:"usia"`
+	KelompokUsia       string                          `json:"kelompok_usia"`
+	Gaji               *domain.Money                   `json:"gaji"`
+	KewajibanBulanan   domain.Money                    `json:"kewajiban_bulanan"`
+	RasioDTI           *float64                        `json:"rasio_dti"`
+	KapasitasAngsuran  *domain.Money                   `json:"kapasitas_angsuran"`
+	OverallCreditLimit domain.Money                    `json:"overall_credit_limit"`
+	Limits             []usecase.RecommendedTenorLimit `json:"limits"`
+	Reasons            []usecase.ScoringReason         `json:"reasons"`
+}
+
+// ApplyCreditLimitRecommendationResponse adalah representasi hasil penerapan rekomendasi pada respons API.
+type ApplyCreditLimitRecommendationResponse struct {
+	Recommendation     *CreditLimitRecommendationResponse `json:"recommendation"`
+	OverallCreditLimit domain.Money                       `json:"overall_credit_limit"`
+	Limits             []*domain.ConsumerCreditLimit      `json:"limits"`
+}
+
+// presentCreditLimitRecommendation menyiapkan hasil scoring sesuai hak pemanggil atas data pribadi konsumen.
+// Rekomendasi hanya dapat diakses admin dan compliance, sehingga pemanggil tidak pernah menjadi pemilik data.
+func presentCreditLimitRecommendation(
+	c *gin.Context,
+	recommendation *usecase.CreditLimitRecommendation,
+) *CreditLimitRecommendationResponse {
+	usia := recommendation.Usia
+	gaji := recommendation.Gaji
+	rasioDTI := recommendation.RasioDTI
+	kapasitasAngsuran := recommendation.KapasitasAngsuran
+	response := &CreditLimitRecommendationResponse{
+		ConsumerID:         recommendation.ConsumerID,
+		VersiAturan:        recommendation.VersiAturan,
+		Eligible:           recommendation.Eligible,
+		Usia:               &usia,
+		KelompokUsia:       recommendation.KelompokUsia,
+		Gaji:               &gaji,
+		KewajibanBulanan:   recommendation.KewajibanBulanan,
+		RasioDTI:           &rasioDTI,
+		KapasitasAngsuran:  &kapasitasAngsuran,
+		OverallCreditLimit: recommendation.OverallCreditLimit,
+		Limits:             recommendation.Limits,
+		Reasons:            recommendation.Reasons,
+	}
+
+	if !usecase.CanViewFullPII(c.GetString("userRole"), false) {
+		response.Usia = nil
+		response.Gaji = nil
+		response.RasioDTI = nil
+		response.KapasitasAngsuran = nil
+	}
+	return response
+}
+
+func presentApplyCreditLimitRecommendation(
+	c *gin.Context,
+	output *usecase.ApplyCreditLimitRecommendationOutput,
+) *ApplyCreditLimitRecommendationResponse {
+	return &ApplyCreditLimitRecommendationResponse{
+		Recommendation:     presentCreditLimitRecommendation(c, output.Recommendation),
+		OverallCreditLimit: output.OverallCreditLimit,
+		Limits:             output.Limits,
+	}
+}
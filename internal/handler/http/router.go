@@ -2,13 +2,17 @@ package http
 
 import (
 	"github.com/adty404/kredit-plus/internal/auth"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/storage"
 	"github.com/adty404/kredit-plus/internal/repository/postgres"
 	"github.com/adty404/kredit-plus/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
+	"log"
 	"net/http"
+	"os"
 	"reflect"
 	"strings"
 )
@@ -27,6 +31,15 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 				return name
 			},
 		)
+		// Money divalidasi berdasarkan nilai sen-nya agar tag seperti gt=0 dan gte=0 tetap berlaku.
+		v.RegisterCustomTypeFunc(
+			func(field reflect.Value) interface{} {
+				if money, ok := field.Interface().(domain.Money); ok {
+					return money.Sen()
+				}
+				return nil
+			}, domain.Money{},
+		)
 	}
 
 	router.GET(
@@ -45,20 +58,149 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	consumerCreditLimitRepo := postgres.NewConsumerCreditLimitRepository(db)
 	transactionRepo := postgres.NewTransactionRepository(db)
 	userRepo := postgres.NewUserRepository(db)
+	installmentRepo := postgres.NewInstallmentRepository(db)
+	paymentRepo := postgres.NewPaymentRepository(db)
+	statusHistoryRepo := postgres.NewTransactionStatusHistoryRepository(db)
+	contractSequenceRepo := postgres.NewContractSequenceRepository(db)
+	idempotencyKeyRepo := postgres.NewIdempotencyKeyRepository(db)
+	creditLimitHistoryRepo := postgres.NewCreditLimitHistoryRepository(db)
+	productRepo := postgres.NewProductRepository(db)
+	limitHoldRepo := postgres.NewLimitHoldRepository(db)
+	creditFreezeHistoryRepo := postgres.NewCreditFreezeHistoryRepository(db)
+	creditLimitReviewRepo := postgres.NewCreditLimitReviewRepository(db)
+	kycHistoryRepo := postgres.NewKYCHistoryRepository(db)
+	consumerDocumentRepo := postgres.NewConsumerDocumentRepository(db)
+	documentAccessLogRepo := postgres.NewDocumentAccessLogRepository(db)
+
+	// Konfigurasi
+	allocationOrder, err := usecase.ParseAllocationOrder(os.Getenv("PAYMENT_ALLOCATION_ORDER"))
+	if err != nil {
+		log.Fatalf("Invalid PAYMENT_ALLOCATION_ORDER: %v", err)
+	}
+	tenorPricing, err := usecase.ParseTenorPricingTable(os.Getenv("INTEREST_PRICING"))
+	if err != nil {
+		log.Fatalf("Invalid INTEREST_PRICING: %v", err)
+	}
+	coolingOff, err := usecase.ParseCoolingOffPeriod(os.Getenv("CONTRACT_COOLING_OFF_DAYS"))
+	if err != nil {
+		log.Fatalf("Invalid CONTRACT_COOLING_OFF_DAYS: %v", err)
+	}
+	contractNumberFormat, err := usecase.ParseContractNumberFormat(os.Getenv("CONTRACT_NUMBER_FORMAT"))
+	if err != nil {
+		log.Fatalf("Invalid CONTRACT_NUMBER_FORMAT: %v", err)
+	}
+	branchCode, err := usecase.ParseBranchCode(os.Getenv("CONTRACT_BRANCH_CODE"))
+	if err != nil {
+		log.Fatalf("Invalid CONTRACT_BRANCH_CODE: %v", err)
+	}
+	scoringRules, err := usecase.LoadScoringRules(os.Getenv("CREDIT_SCORING_RULES"))
+	if err != nil {
+		log.Fatalf("Invalid CREDIT_SCORING_RULES: %v", err)
+	}
+	limitHoldTTL, err := usecase.ParseLimitHoldTTL(os.Getenv("LIMIT_HOLD_TTL_MINUTES"))
+	if err != nil {
+		log.Fatalf("Invalid LIMIT_HOLD_TTL_MINUTES: %v", err)
+	}
+	limitReviewPolicy, err := usecase.ParseLimitReviewPolicy(
+		os.Getenv("CREDIT_LIMIT_VALIDITY_MONTHS"),
+		os.Getenv("CREDIT_LIMIT_REVIEW_DAYS"),
+	)
+	if err != nil {
+		log.Fatalf("Invalid CREDIT_LIMIT_VALIDITY_MONTHS or CREDIT_LIMIT_REVIEW_DAYS: %v", err)
+	}
+	documentPolicy, err := usecase.ParseDocumentPolicy(os.Getenv("DOCUMENT_MAX_SIZE_KB"))
+	if err != nil {
+		log.Fatalf("Invalid DOCUMENT_MAX_SIZE_KB: %v", err)
+	}
+	storageConfig, err := storage.LoadConfig(os.Getenv)
+	if err != nil {
+		log.Fatalf("Invalid DOCUMENT_STORAGE: %v", err)
+	}
+	documentStorage, err := storage.New(storageConfig)
+	if err != nil {
+		log.Fatalf("Invalid DOCUMENT_STORAGE: %v", err)
+	}
+	// Signed URL dokumen memakai DOCUMENT_URL_SECRET, atau secret yang diturunkan dari JWT_SECRET jika kosong.
+	documentURLConfig, err := usecase.ParseDocumentURLConfig(
+		os.Getenv("DOCUMENT_URL_SECRET"),
+		os.Getenv("JWT_SECRET"),
+		os.Getenv("DOCUMENT_URL_TTL_MINUTES"),
+	)
+	if err != nil {
+		log.Fatalf("Invalid DOCUMENT_URL_SECRET, JWT_SECRET, or DOCUMENT_URL_TTL_MINUTES: %v", err)
+	}
+
+	// Katalog produk menentukan tenor, bunga, dan aturan pembiayaan; INTEREST_PRICING tetap dipakai untuk
+	// kontrak lama yang jenis asetnya tidak terdaftar sebagai produk.
+	productCatalog := usecase.NewProductCatalog(productRepo, tenorPricing)
+	// Dokumen konsumen (foto KTP dan selfie) divalidasi lalu disimpan di DOCUMENT_STORAGE.
+	documentStore := usecase.NewDocumentStore(consumerDocumentRepo, documentStorage, documentPolicy)
 
 	// Usecase
-	consumerUsecase := usecase.NewConsumerUsecase(db, consumerRepo, userRepo)
+	consumerUsecase := usecase.NewConsumerUsecase(db, consumerRepo, userRepo, limitReviewPolicy, documentStore)
 	consumerCreditLimitUsecase := usecase.NewConsumerCreditLimitUsecase(
+		db,
 		consumerCreditLimitRepo,
 		consumerRepo,
+		transactionRepo,
+		creditLimitHistoryRepo,
+		limitHoldRepo,
+		productCatalog,
+		scoringRules,
+		limitReviewPolicy,
+	)
+	creditLimitReviewUsecase := usecase.NewCreditLimitReviewUsecase(
+		db,
+		consumerCreditLimitRepo,
+		consumerRepo,
+		transactionRepo,
+		creditLimitHistoryRepo,
+		creditLimitReviewRepo,
+		productCatalog,
+		scoringRules,
+		limitReviewPolicy,
 	)
 	transactionUsecase := usecase.NewTransactionUsecase(
 		db,
 		transactionRepo,
 		consumerRepo,
 		consumerCreditLimitRepo,
+		installmentRepo,
+		statusHistoryRepo,
+		idempotencyKeyRepo,
+		limitHoldRepo,
+		productCatalog,
+		usecase.NewContractNumberGenerator(contractSequenceRepo, contractNumberFormat, branchCode),
+		coolingOff,
+		limitHoldTTL,
+	)
+	limitHoldUsecase := usecase.NewLimitHoldUsecase(db, limitHoldRepo, consumerRepo)
+	creditFreezeUsecase := usecase.NewCreditFreezeUsecase(
+		db,
+		consumerRepo,
+		consumerCreditLimitRepo,
+		creditFreezeHistoryRepo,
+	)
+	kycUsecase := usecase.NewKYCUsecase(db, consumerRepo, kycHistoryRepo, documentStore)
+	documentAccessUsecase := usecase.NewDocumentAccessUsecase(
+		consumerRepo,
+		consumerDocumentRepo,
+		documentAccessLogRepo,
+		documentStorage,
+		documentURLConfig,
 	)
 	userUsecase := usecase.NewUserUsecase(userRepo)
+	productUsecase := usecase.NewProductUsecase(db, productRepo)
+	paymentUsecase := usecase.NewPaymentUsecase(
+		db,
+		paymentRepo,
+		transactionRepo,
+		installmentRepo,
+		statusHistoryRepo,
+		idempotencyKeyRepo,
+		productCatalog,
+		allocationOrder,
+	)
 
 	// Handler
 	consumerHandler := NewConsumerHandler(consumerUsecase)
@@ -68,6 +210,13 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 	)
 	transactionHandler := NewTransactionHandler(transactionUsecase, consumerRepo)
 	userHandler := NewUserHandler(userUsecase)
+	paymentHandler := NewPaymentHandler(paymentUsecase, consumerRepo)
+	productHandler := NewProductHandler(productUsecase)
+	limitHoldHandler := NewLimitHoldHandler(transactionUsecase, limitHoldUsecase, consumerRepo)
+	creditFreezeHandler := NewCreditFreezeHandler(creditFreezeUsecase)
+	creditLimitReviewHandler := NewCreditLimitReviewHandler(creditLimitReviewUsecase)
+	kycHandler := NewKYCHandler(kycUsecase, consumerUsecase)
+	documentHandler := NewDocumentHandler(documentAccessUsecase, consumerUsecase)
 
 	// === Pendaftaran Rute API ===
 	api := router.Group("/api/v1")
@@ -79,6 +228,9 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 			authRoutes.POST("/login", userHandler.Login)
 		}
 
+		// Unduhan dokumen konsumen melalui signed URL (Publik, diotorisasi oleh signature pada query)
+		api.GET("/documents/:documentId/content", documentHandler.GetSignedDocument)
+
 		// Grup rute yang memerlukan autentikasi JWT
 		protectedRoutes := api.Group("")
 		protectedRoutes.Use(auth.AuthMiddleware())
@@ -88,19 +240,142 @@ func SetupRouter(db *gorm.DB) *gin.Engine {
 			{
 				// Rute utama untuk consumers
 				consumerRoutes.POST("", auth.AuthorizeRole("admin"), consumerHandler.CreateConsumer)
-				consumerRoutes.GET("", auth.AuthorizeRole("admin"), consumerHandler.GetAllConsumers)
+				consumerRoutes.GET("", auth.AuthorizeRole("admin", "compliance"), consumerHandler.GetAllConsumers)
 				consumerRoutes.GET("/:id", consumerHandler.GetConsumerByID)
 				consumerRoutes.PUT("/:id", consumerHandler.UpdateConsumer)
 				consumerRoutes.DELETE("/:id", auth.AuthorizeRole("admin"), consumerHandler.DeleteConsumer)
 
+				// Verifikasi KYC: konsumen mengajukan dokumen, admin meninjau dan memutuskan
+				consumerRoutes.POST("/:id/kyc/submit", kycHandler.SubmitKYC)
+				consumerRoutes.GET("/:id/kyc/history", kycHandler.GetKYCHistory)
+				consumerRoutes.POST("/:id/kyc/review", auth.AuthorizeRole("admin"), kycHandler.StartKYCReview)
+				consumerRoutes.POST("/:id/kyc/approve", auth.AuthorizeRole("admin"), kycHandler.ApproveKYC)
+				consumerRoutes.POST("/:id/kyc/reject", auth.AuthorizeRole("admin"), kycHandler.RejectKYC)
+
+				// Dokumen identitas konsumen (ktp atau selfie) untuk admin atau pemiliknya; setiap akses diaudit
+				consumerRoutes.GET("/:id/documents/:type", documentHandler.GetDocument)
+				consumerRoutes.POST("/:id/documents/:type/signed-url", documentHandler.CreateSignedURL)
+				consumerRoutes.GET(
+					"/:id/document-access-logs",
+					auth.AuthorizeRole("admin"),
+					documentHandler.GetAccessLogs,
+				)
+
+				// Pembekuan kredit konsumen dan limit tenor oleh admin
+				consumerRoutes.POST("/:id/freeze", auth.AuthorizeRole("admin"), creditFreezeHandler.FreezeConsumer)
+				consumerRoutes.POST("/:id/unfreeze", auth.AuthorizeRole("admin"), creditFreezeHandler.UnfreezeConsumer)
+				consumerRoutes.GET(
+					"/:id/freeze-history",
+					auth.AuthorizeRole("admin"),
+					creditFreezeHandler.GetFreezeHistory,
+				)
+
 				consumerRoutes.POST(
 					"/:id/limits",
 					auth.AuthorizeRole("admin"),
 					consumerCreditLimitHandler.CreateLimitForConsumer,
 				)
+				consumerRoutes.GET("/:id/limits", consumerCreditLimitHandler.GetLimitsForConsumer)
+				consumerRoutes.GET("/:id/limits/availability", consumerCreditLimitHandler.GetLimitAvailability)
+				consumerRoutes.GET(
+					"/:id/limits/recommendation",
+					auth.AuthorizeRole("admin", "compliance"),
+					consumerCreditLimitHandler.GetLimitRecommendation,
+				)
+				consumerRoutes.POST(
+					"/:id/limits/recommendation/apply",
+					auth.AuthorizeRole("admin"),
+					consumerCreditLimitHandler.ApplyLimitRecommendation,
+				)
+				consumerRoutes.GET(
+					"/:id/limits/history",
+					auth.AuthorizeRole("admin"),
+					consumerCreditLimitHandler.GetLimitHistory,
+				)
+				consumerRoutes.PUT(
+					"/:id/limits/:tenor",
+					auth.AuthorizeRole("admin"),
+					consumerCreditLimitHandler.UpdateLimitForConsumer,
+				)
+				consumerRoutes.DELETE(
+					"/:id/limits/:tenor",
+					auth.AuthorizeRole("admin"),
+					consumerCreditLimitHandler.DeleteLimitForConsumer,
+				)
+				consumerRoutes.POST(
+					"/:id/limits/:tenor/freeze",
+					auth.AuthorizeRole("admin"),
+					creditFreezeHandler.FreezeCreditLimit,
+				)
+				consumerRoutes.POST(
+					"/:id/limits/:tenor/unfreeze",
+					auth.AuthorizeRole("admin"),
+					creditFreezeHandler.UnfreezeCreditLimit,
+				)
 
 				consumerRoutes.POST("/:id/transactions", transactionHandler.CreateTransaction)
+				consumerRoutes.POST("/:id/transactions/simulate", transactionHandler.SimulateTransaction)
 				consumerRoutes.GET("/:id/transactions", transactionHandler.GetTransactionsByConsumerID)
+				consumerRoutes.GET(
+					"/:id/transactions/:trxId/schedule",
+					transactionHandler.GetTransactionSchedule,
+				)
+				consumerRoutes.GET(
+					"/:id/transactions/:trxId/history",
+					transactionHandler.GetTransactionStatusHistory,
+				)
+				consumerRoutes.GET("/:id/transactions/:trxId/payoff-quote", paymentHandler.GetPayoffQuote)
+
+				consumerRoutes.POST("/:id/limit-holds", limitHoldHandler.CreateLimitHold)
+				consumerRoutes.GET("/:id/limit-holds", limitHoldHandler.GetLimitHolds)
+				consumerRoutes.POST("/:id/limit-holds/:holdId/confirm", limitHoldHandler.ConfirmLimitHold)
+				consumerRoutes.POST("/:id/limit-holds/:holdId/release", limitHoldHandler.ReleaseLimitHold)
+			}
+
+			// Grup rute untuk katalog produk; hanya admin yang dapat mengubahnya
+			productRoutes := protectedRoutes.Group("/products")
+			{
+				productRoutes.GET("", productHandler.GetAllProducts)
+				productRoutes.GET("/:id", productHandler.GetProductByID)
+				productRoutes.POST("", auth.AuthorizeRole("admin"), productHandler.CreateProduct)
+				productRoutes.PUT("/:id", auth.AuthorizeRole("admin"), productHandler.UpdateProduct)
+				productRoutes.DELETE("/:id", auth.AuthorizeRole("admin"), productHandler.DeleteProduct)
+			}
+
+			// Grup rute untuk antrean peninjauan limit berkala; hanya untuk admin
+			limitReviewRoutes := protectedRoutes.Group("/credit-limit-reviews")
+			limitReviewRoutes.Use(auth.AuthorizeRole("admin"))
+			{
+				limitReviewRoutes.GET("", creditLimitReviewHandler.GetLimitReviews)
+				limitReviewRoutes.GET("/:id", creditLimitReviewHandler.GetLimitReviewByID)
+				limitReviewRoutes.POST("/:id/approve", creditLimitReviewHandler.ApproveLimitReview)
+				limitReviewRoutes.POST("/:id/reject", creditLimitReviewHandler.RejectLimitReview)
+			}
+
+			// Antrean verifikasi KYC untuk reviewer; hanya untuk admin
+			protectedRoutes.GET("/kyc", auth.AuthorizeRole("admin"), kycHandler.GetKYCQueue)
+
+			// Grup rute untuk transaksi di dalam grup terproteksi
+			transactionRoutes := protectedRoutes.Group("/transactions")
+			{
+				transactionRoutes.POST("/:id/payments", auth.AuthorizeRole("admin"), paymentHandler.CreatePayment)
+				transactionRoutes.POST("/:id/payoff", auth.AuthorizeRole("admin"), paymentHandler.PayoffTransaction)
+				transactionRoutes.POST(
+					"/:id/activate",
+					auth.AuthorizeRole("admin"),
+					transactionHandler.ActivateTransaction,
+				)
+				transactionRoutes.POST("/:id/cancel", auth.AuthorizeRole("admin"), transactionHandler.CancelTransaction)
+				transactionRoutes.POST(
+					"/:id/restructure",
+					auth.AuthorizeRole("admin"),
+					transactionHandler.RestructureTransaction,
+				)
+				transactionRoutes.POST(
+					"/:id/write-off",
+					auth.AuthorizeRole("admin"),
+					transactionHandler.WriteOffTransaction,
+				)
 			}
 		}
 	}
@@ -1,9 +1,11 @@
 package http
 
 import (
+	"errors"
 	"github.com/adty404/kredit-plus/internal/domain"
 	"github.com/adty404/kredit-plus/internal/usecase"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 	"net/http"
 	"strconv"
 )
@@ -51,16 +53,59 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
 		return
 	}
+	idempotencyKey, ok := readIdempotencyKey(c)
+	if !ok {
+		return
+	}
+	input.IdempotencyKey = idempotencyKey
 
 	transaction, err := h.uc.CreateTransaction(uint(consumerIDFromURL), input)
 	if err != nil {
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		writeCreateError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Transaction created successfully", "data": transaction})
 }
 
+// SimulateTransaction mengembalikan penawaran cicilan per tenor tanpa membuat transaksi.
+func (h *TransactionHandler) SimulateTransaction(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerIDFromURL, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	loggedInUserID := c.GetUint("userID")
+	loggedInUserRole := c.GetString("userRole")
+
+	if loggedInUserRole != "admin" {
+		consumer, err := h.consumerRepo.FindByUserID(loggedInUserID)
+		if err != nil || consumer.ID != uint(consumerIDFromURL) {
+			c.JSON(
+				http.StatusForbidden,
+				gin.H{"error": "You are not authorized to simulate a transaction for this consumer"},
+			)
+			return
+		}
+	}
+
+	var input usecase.SimulateTransactionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	simulation, err := h.uc.SimulateTransaction(uint(consumerIDFromURL), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": simulation})
+}
+
 func (h *TransactionHandler) GetTransactionsByConsumerID(c *gin.Context) {
 	idStr := c.Param("id")
 	consumerIDFromURL, err := strconv.ParseUint(idStr, 10, 32)
@@ -88,3 +133,124 @@ func (h *TransactionHandler) GetTransactionsByConsumerID(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"data": transactions})
 }
+
+func (h *TransactionHandler) GetTransactionSchedule(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerIDFromURL, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	trxIDStr := c.Param("trxId")
+	transactionID, err := strconv.ParseUint(trxIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
+		return
+	}
+
+	loggedInUserID := c.GetUint("userID")
+	loggedInUserRole := c.GetString("userRole")
+
+	if loggedInUserRole != "admin" {
+		consumer, err := h.consumerRepo.FindByUserID(loggedInUserID)
+		if err != nil || consumer.ID != uint(consumerIDFromURL) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this transaction"})
+			return
+		}
+	}
+
+	schedule, err := h.uc.GetTransactionSchedule(uint(consumerIDFromURL), uint(transactionID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve transaction schedule"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schedule})
+}
+
+// ActivateTransaction menangani aktivasi kontrak PENDING oleh admin setelah barang diserahkan.
+func (h *TransactionHandler) ActivateTransaction(c *gin.Context) {
+	h.changeContractStatus(c, h.uc.ActivateTransaction, "Transaction activated successfully")
+}
+
+// CancelTransaction menangani pembatalan kontrak oleh admin selama masa cooling-off.
+func (h *TransactionHandler) CancelTransaction(c *gin.Context) {
+	h.changeContractStatus(c, h.uc.CancelTransaction, "Transaction cancelled successfully")
+}
+
+// RestructureTransaction menangani penandaan kontrak berjalan sebagai restrukturisasi oleh admin.
+func (h *TransactionHandler) RestructureTransaction(c *gin.Context) {
+	h.changeContractStatus(c, h.uc.RestructureTransaction, "Transaction restructured successfully")
+}
+
+// WriteOffTransaction menangani penghapusbukuan kontrak oleh admin.
+func (h *TransactionHandler) WriteOffTransaction(c *gin.Context) {
+	h.changeContractStatus(c, h.uc.WriteOffTransaction, "Transaction written off successfully")
+}
+
+func (h *TransactionHandler) changeContractStatus(
+	c *gin.Context,
+	change func(transactionID, changedBy uint, input usecase.ChangeContractStatusInput) (*domain.Transaction, error),
+	message string,
+) {
+	idStr := c.Param("id")
+	transactionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
+		return
+	}
+
+	var input usecase.ChangeContractStatusInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input data", "details": err.Error()})
+		return
+	}
+
+	transaction, err := change(uint(transactionID), c.GetUint("userID"), input)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": message, "data": transaction})
+}
+
+func (h *TransactionHandler) GetTransactionStatusHistory(c *gin.Context) {
+	idStr := c.Param("id")
+	consumerIDFromURL, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID format"})
+		return
+	}
+
+	trxIDStr := c.Param("trxId")
+	transactionID, err := strconv.ParseUint(trxIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID format"})
+		return
+	}
+
+	loggedInUserID := c.GetUint("userID")
+	loggedInUserRole := c.GetString("userRole")
+
+	if loggedInUserRole != "admin" {
+		consumer, err := h.consumerRepo.FindByUserID(loggedInUserID)
+		if err != nil || consumer.ID != uint(consumerIDFromURL) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to view this transaction"})
+			return
+		}
+	}
+
+	histories, err := h.uc.GetTransactionStatusHistory(uint(consumerIDFromURL), uint(transactionID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": histories})
+}
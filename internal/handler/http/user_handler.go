@@ -28,7 +28,7 @@ func (h *UserHandler) Register(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully", "data": user})
+	c.JSON(http.StatusCreated, gin.H{"message": "User registered successfully", "data": presentUser(user)})
 }
 
 func (h *UserHandler) Login(c *gin.Context) {
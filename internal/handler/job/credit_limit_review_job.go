@@ -0,0 +1,68 @@
+package job
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/repository/postgres"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// CreditLimitReviewJob membuat usulan peninjauan untuk plafon dan limit tenor yang akan atau sudah berakhir.
+type CreditLimitReviewJob struct {
+	uc usecase.CreditLimitReviewUsecase
+}
+
+func NewCreditLimitReviewJob(db *gorm.DB) *CreditLimitReviewJob {
+	tenorPricing, err := usecase.ParseTenorPricingTable(os.Getenv("INTEREST_PRICING"))
+	if err != nil {
+		log.Fatalf("Invalid INTEREST_PRICING: %v", err)
+	}
+	scoringRules, err := usecase.LoadScoringRules(os.Getenv("CREDIT_SCORING_RULES"))
+	if err != nil {
+		log.Fatalf("Invalid CREDIT_SCORING_RULES: %v", err)
+	}
+	reviewPolicy, err := usecase.ParseLimitReviewPolicy(
+		os.Getenv("CREDIT_LIMIT_VALIDITY_MONTHS"),
+		os.Getenv("CREDIT_LIMIT_REVIEW_DAYS"),
+	)
+	if err != nil {
+		log.Fatalf("Invalid CREDIT_LIMIT_VALIDITY_MONTHS or CREDIT_LIMIT_REVIEW_DAYS: %v", err)
+	}
+
+	return &CreditLimitReviewJob{
+		uc: usecase.NewCreditLimitReviewUsecase(
+			db,
+			postgres.NewConsumerCreditLimitRepository(db),
+			postgres.NewConsumerRepository(db),
+			postgres.NewTransactionRepository(db),
+			postgres.NewCreditLimitHistoryRepository(db),
+			postgres.NewCreditLimitReviewRepository(db),
+			usecase.NewProductCatalog(postgres.NewProductRepository(db), tenorPricing),
+			scoringRules,
+			reviewPolicy,
+		),
+	}
+}
+
+// Run membuat usulan peninjauan per tanggal now dan mencatat ringkasannya ke log.
+func (j *CreditLimitReviewJob) Run(now time.Time) error {
+	output, err := j.uc.GenerateLimitReviews(now)
+	if err != nil {
+		return err
+	}
+
+	log.Printf(
+		"Credit limit review %s: %d consumers reviewed, %d increases and %d decreases proposed",
+		now.Format("2006-01-02"),
+		output.KonsumenDitinjau,
+		output.UsulanKenaikan,
+		output.UsulanPenurunan,
+	)
+	for _, message := range output.Errors {
+		log.Printf("Credit limit review error: %s", message)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package job
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/repository/postgres"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// DelinquencyJob menjalankan penilaian keterlambatan (DPD, denda, dan kolektibilitas) harian.
+type DelinquencyJob struct {
+	uc usecase.DelinquencyUsecase
+}
+
+func NewDelinquencyJob(db *gorm.DB) *DelinquencyJob {
+	tenorPricing, err := usecase.ParseTenorPricingTable(os.Getenv("INTEREST_PRICING"))
+	if err != nil {
+		log.Fatalf("Invalid INTEREST_PRICING: %v", err)
+	}
+	freezeDPD, err := usecase.ParseFreezeDPDThreshold(os.Getenv("CREDIT_FREEZE_DPD"))
+	if err != nil {
+		log.Fatalf("Invalid CREDIT_FREEZE_DPD: %v", err)
+	}
+
+	return &DelinquencyJob{
+		uc: usecase.NewDelinquencyUsecase(
+			db,
+			postgres.NewTransactionRepository(db),
+			postgres.NewInstallmentRepository(db),
+			postgres.NewConsumerRepository(db),
+			postgres.NewCreditFreezeHistoryRepository(db),
+			usecase.NewProductCatalog(postgres.NewProductRepository(db), tenorPricing),
+			freezeDPD,
+		),
+	}
+}
+
+// Run menilai seluruh kontrak berjalan per tanggal now dan mencatat ringkasannya ke log.
+func (j *DelinquencyJob) Run(now time.Time) error {
+	output, err := j.uc.AssessDelinquency(now)
+	if err != nil {
+		return err
+	}
+
+	log.Printf(
+		"Delinquency assessment %s: %d contracts assessed, %d consumers past due, %d consumers frozen, "+
+			"new penalties %s",
+		now.Format("2006-01-02"),
+		output.KontrakDinilai,
+		output.KonsumenTerlambat,
+		output.KonsumenDibekukan,
+		output.TotalDendaBaru,
+	)
+	for _, message := range output.Errors {
+		log.Printf("Delinquency assessment error: %s", message)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package job
+
+import (
+	"log"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/repository/postgres"
+	"github.com/adty404/kredit-plus/internal/usecase"
+	"gorm.io/gorm"
+)
+
+// LimitHoldSweepInterval adalah jarak antar eksekusi job kedaluwarsa penahanan limit.
+const LimitHoldSweepInterval = time.Minute
+
+// LimitHoldJob mengakhiri penahanan limit checkout yang sudah melewati batas waktunya.
+type LimitHoldJob struct {
+	uc usecase.LimitHoldUsecase
+}
+
+func NewLimitHoldJob(db *gorm.DB) *LimitHoldJob {
+	return &LimitHoldJob{
+		uc: usecase.NewLimitHoldUsecase(
+			db,
+			postgres.NewLimitHoldRepository(db),
+			postgres.NewConsumerRepository(db),
+		),
+	}
+}
+
+// Run menandai penahanan yang kedaluwarsa per waktu now dan mencatat jumlahnya ke log jika ada.
+func (j *LimitHoldJob) Run(now time.Time) error {
+	expired, err := j.uc.ExpireLimitHolds(now)
+	if err != nil {
+		return err
+	}
+	if expired > 0 {
+		log.Printf("Limit hold sweep %s: %d holds expired", now.Format(time.RFC3339), expired)
+	}
+	return nil
+}
@@ -15,6 +15,23 @@ func Run(db *gorm.DB) error {
 		&domain.ConsumerCreditLimit{},
 		&domain.Transaction{},
 		&domain.User{},
+		&domain.Installment{},
+		&domain.Payment{},
+		&domain.PaymentAllocation{},
+		&domain.TransactionStatusHistory{},
+		&domain.ContractSequence{},
+		&domain.IdempotencyKey{},
+		&domain.CreditLimitHistory{},
+		&domain.Product{},
+		&domain.ProductTenor{},
+		&domain.LimitHold{},
+		&domain.CreditFreezeHistory{},
+		&domain.CreditLimitReview{},
+		&domain.CreditLimitReviewItem{},
+		&domain.KYCHistory{},
+		&domain.ConsumerDocument{},
+		&domain.DocumentAccessLog{},
+		&domain.PIIDataKey{},
 	)
 
 	if err != nil {
@@ -0,0 +1,310 @@
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ciphertextPrefix menandai nilai kolom yang terenkripsi, diikuti ID DEK dan payload base64 (nonce + ciphertext).
+// Nilai tanpa prefix ini adalah data lama yang belum dienkripsi ulang.
+const ciphertextPrefix = "pii:v2:"
+
+// legacyCiphertextPrefix menandai ciphertext lama yang associated data-nya hanya nama kolom. Nilai ini tetap
+// terbaca dan ditulis ulang sebagai ciphertextPrefix oleh --reencrypt-pii.
+const legacyCiphertextPrefix = "pii:v1:"
+
+// activeKeyRefreshInterval adalah selang pemeriksaan ulang DEK aktif, agar rotasi dari instance lain ikut terpakai.
+const activeKeyRefreshInterval = 5 * time.Minute
+
+// Cipher mengenkripsi data pribadi dengan envelope encryption: nilai dienkripsi AES-256-GCM dengan DEK, dan DEK
+// disimpan di database dalam keadaan terbungkus KEK dari KeyProvider. Location (tabel, kolom, dan ID baris)
+// dipakai sebagai associated data sehingga ciphertext tidak dapat dipindahkan ke kolom atau baris lain.
+type Cipher struct {
+	keys          KeyProvider
+	repo          domain.PIIDataKeyRepository
+	blindIndexKey []byte
+
+	mu              sync.Mutex
+	dataKeys        map[uint]cipher.AEAD
+	activeID        uint
+	activeCheckedAt time.Time
+}
+
+func NewCipher(keys KeyProvider, repo domain.PIIDataKeyRepository, blindIndexKey []byte) *Cipher {
+	return &Cipher{
+		keys:          keys,
+		repo:          repo,
+		blindIndexKey: blindIndexKey,
+		dataKeys:      make(map[uint]cipher.AEAD),
+	}
+}
+
+// Location adalah tempat sebuah nilai terenkripsi disimpan: kolom Column pada baris RowID di tabel Table.
+type Location struct {
+	Table  string
+	Column string
+	RowID  uint
+}
+
+func (l Location) String() string {
+	return l.Table + "." + l.Column
+}
+
+// associatedData mengikat ciphertext ke tabel, kolom, dan baris tempatnya disimpan.
+func (l Location) associatedData() []byte {
+	return []byte(l.Table + "." + l.Column + ":" + strconv.FormatUint(uint64(l.RowID), 10))
+}
+
+// IsEncrypted menandakan value adalah ciphertext yang dihasilkan Cipher.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix) || strings.HasPrefix(value, legacyCiphertextPrefix)
+}
+
+// Encrypt mengenkripsi plaintext untuk loc dengan DEK aktif. DEK pertama dibuat otomatis jika belum ada.
+func (c *Cipher) Encrypt(loc Location, plaintext []byte) (string, error) {
+	if loc.RowID == 0 {
+		return "", fmt.Errorf("row id is required to encrypt column %s", loc)
+	}
+	id, aead, err := c.activeDataKey()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, loc.associatedData())
+	return ciphertextPrefix + strconv.FormatUint(uint64(id), 10) + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt mengembalikan plaintext dari value yang disimpan di loc. Value yang belum terenkripsi dikembalikan apa
+// adanya, dan ciphertext lama (legacyCiphertextPrefix) diperiksa dengan nama kolom saja.
+func (c *Cipher) Decrypt(loc Location, value string) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return []byte(value), nil
+	}
+	associatedData := []byte(loc.Column)
+	body, legacy := strings.CutPrefix(value, legacyCiphertextPrefix)
+	if !legacy {
+		if loc.RowID == 0 {
+			return nil, fmt.Errorf("row id is required to decrypt column %s", loc)
+		}
+		body = strings.TrimPrefix(value, ciphertextPrefix)
+		associatedData = loc.associatedData()
+	}
+	rawID, payload, ok := strings.Cut(body, ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed ciphertext in column %s", loc)
+	}
+	id, err := strconv.ParseUint(rawID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("malformed ciphertext in column %s", loc)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("malformed ciphertext in column %s", loc)
+	}
+
+	aead, err := c.dataKey(uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("malformed ciphertext in column %s", loc)
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt column %s of row %d: %w", loc, loc.RowID, err)
+	}
+	return plaintext, nil
+}
+
+// BlindIndex menghitung HMAC-SHA256 dari value sehingga kolom terenkripsi dapat dicari dengan kesamaan nilai
+// tanpa menyimpan plaintext-nya.
+func (c *Cipher) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, c.blindIndexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RotateDataKey membuat DEK baru yang dibungkus KEK aktif dan menjadikannya DEK aktif. Data lama tetap dapat
+// didekripsi dengan DEK sebelumnya sampai dienkripsi ulang.
+func (c *Cipher) RotateDataKey() (*domain.PIIDataKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.createDataKey()
+}
+
+// RewrapDataKeys membungkus ulang DEK yang masih terbungkus KEK lama dengan KEK aktif, sehingga KEK lama dapat
+// dihapus dari konfigurasi.
+func (c *Cipher) RewrapDataKeys() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dataKeys, err := c.repo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+	activeKEK := c.keys.ActiveKeyID()
+	rewrapped := 0
+	for _, dataKey := range dataKeys {
+		if dataKey.KEKID == activeKEK {
+			continue
+		}
+		plainKey, err := c.unwrap(dataKey)
+		if err != nil {
+			return rewrapped, err
+		}
+		wrapped, err := c.wrap(activeKEK, plainKey)
+		if err != nil {
+			return rewrapped, err
+		}
+		err = c.repo.Update(dataKey.ID, map[string]interface{}{"kek_id": activeKEK, "wrapped_key": wrapped})
+		if err != nil {
+			return rewrapped, err
+		}
+		rewrapped++
+	}
+	return rewrapped, nil
+}
+
+// activeDataKey mengembalikan DEK aktif, memeriksa ulang ke database setiap activeKeyRefreshInterval.
+func (c *Cipher) activeDataKey() (uint, cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.activeID != 0 && time.Since(c.activeCheckedAt) < activeKeyRefreshInterval {
+		return c.activeID, c.dataKeys[c.activeID], nil
+	}
+
+	dataKey, err := c.repo.FindActive()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		dataKey, err = c.createDataKey()
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("could not load active PII data key: %w", err)
+	}
+	aead, err := c.loadDataKey(dataKey)
+	if err != nil {
+		return 0, nil, err
+	}
+	c.activeID = dataKey.ID
+	c.activeCheckedAt = time.Now()
+	return c.activeID, aead, nil
+}
+
+// dataKey mengembalikan DEK dengan ID tertentu dari cache atau database.
+func (c *Cipher) dataKey(id uint) (cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if aead, ok := c.dataKeys[id]; ok {
+		return aead, nil
+	}
+	dataKey, err := c.repo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("PII data key %d not found: %w", id, err)
+	}
+	return c.loadDataKey(dataKey)
+}
+
+// createDataKey membuat DEK baru dan menyimpannya sebagai DEK aktif. Pemanggil wajib memegang c.mu.
+func (c *Cipher) createDataKey() (*domain.PIIDataKey, error) {
+	plainKey := make([]byte, keySize)
+	if _, err := rand.Read(plainKey); err != nil {
+		return nil, err
+	}
+	activeKEK := c.keys.ActiveKeyID()
+	wrapped, err := c.wrap(activeKEK, plainKey)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := &domain.PIIDataKey{KEKID: activeKEK, WrappedKey: wrapped}
+	if err := c.repo.ReplaceActive(dataKey); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(plainKey)
+	if err != nil {
+		return nil, err
+	}
+	c.dataKeys[dataKey.ID] = aead
+	c.activeID = dataKey.ID
+	c.activeCheckedAt = time.Now()
+	return dataKey, nil
+}
+
+// loadDataKey membuka bungkus DEK dan menyimpannya di cache. Pemanggil wajib memegang c.mu.
+func (c *Cipher) loadDataKey(dataKey *domain.PIIDataKey) (cipher.AEAD, error) {
+	if aead, ok := c.dataKeys[dataKey.ID]; ok {
+		return aead, nil
+	}
+	plainKey, err := c.unwrap(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(plainKey)
+	if err != nil {
+		return nil, err
+	}
+	c.dataKeys[dataKey.ID] = aead
+	return aead, nil
+}
+
+// wrap membungkus DEK dengan KEK kekID. ID KEK menjadi associated data agar bungkus tidak dapat ditukar.
+func (c *Cipher) wrap(kekID string, plainKey []byte) ([]byte, error) {
+	kek, err := c.keys.Key(kekID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plainKey, []byte("pii-data-key:"+kekID)), nil
+}
+
+func (c *Cipher) unwrap(dataKey *domain.PIIDataKey) ([]byte, error) {
+	kek, err := c.keys.Key(dataKey.KEKID)
+	if err != nil {
+		return nil, fmt.Errorf("could not unwrap PII data key %d: %w", dataKey.ID, err)
+	}
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(dataKey.WrappedKey) < aead.NonceSize() {
+		return nil, fmt.Errorf("PII data key %d is malformed", dataKey.ID)
+	}
+	nonce, sealed := dataKey.WrappedKey[:aead.NonceSize()], dataKey.WrappedKey[aead.NonceSize():]
+	plainKey, err := aead.Open(nil, nonce, sealed, []byte("pii-data-key:"+dataKey.KEKID))
+	if err != nil {
+		return nil, fmt.Errorf("could not unwrap PII data key %d with key %s: %w", dataKey.ID, dataKey.KEKID, err)
+	}
+	return plainKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
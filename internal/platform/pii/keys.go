@@ -0,0 +1,148 @@
+package pii
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// keySize adalah panjang KEK, DEK, dan kunci blind index dalam byte (AES-256 dan HMAC-SHA256).
+const keySize = 32
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// KeyProvider menyediakan key encryption key (KEK) untuk membungkus DEK. KEK lama tetap harus tersedia sampai
+// semua DEK dibungkus ulang dengan KEK aktif.
+type KeyProvider interface {
+	// ActiveKeyID adalah ID KEK yang dipakai untuk membungkus DEK baru.
+	ActiveKeyID() string
+	// Key mengembalikan KEK dengan ID tertentu.
+	Key(id string) ([]byte, error)
+}
+
+type staticKeyProvider struct {
+	active string
+	keys   map[string][]byte
+}
+
+func (p *staticKeyProvider) ActiveKeyID() string {
+	return p.active
+}
+
+func (p *staticKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key encryption key %q is not configured", id)
+	}
+	return key, nil
+}
+
+// NewKeyProvider membuat KeyProvider dari daftar KEK (ID ke kunci base64). activeID boleh kosong jika hanya ada
+// satu KEK.
+func NewKeyProvider(activeID string, encodedKeys map[string]string) (KeyProvider, error) {
+	if len(encodedKeys) == 0 {
+		return nil, fmt.Errorf("at least one key encryption key is required")
+	}
+	provider := &staticKeyProvider{active: strings.TrimSpace(activeID), keys: make(map[string][]byte)}
+	for id, encoded := range encodedKeys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid key encryption key ID %q", id)
+		}
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key encryption key %q: %w", id, err)
+		}
+		provider.keys[id] = key
+	}
+	if provider.active == "" {
+		if len(provider.keys) > 1 {
+			return nil, fmt.Errorf("active key encryption key ID is required when more than one key is configured")
+		}
+		for id := range provider.keys {
+			provider.active = id
+		}
+	}
+	if _, ok := provider.keys[provider.active]; !ok {
+		return nil, fmt.Errorf("active key encryption key %q is not configured", provider.active)
+	}
+	return provider, nil
+}
+
+// NewEnvKeyProvider membaca KEK dari nilai seperti "2026-01:<base64>,2026-10:<base64>" (biasanya PII_KEKS).
+func NewEnvKeyProvider(activeID, keys string) (KeyProvider, error) {
+	encodedKeys := make(map[string]string)
+	for _, entry := range strings.Split(keys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid key encryption key entry %q, expected <id>:<base64 key>", entry)
+		}
+		encodedKeys[strings.TrimSpace(id)] = strings.TrimSpace(encoded)
+	}
+	return NewKeyProvider(activeID, encodedKeys)
+}
+
+// keyFile adalah format file KEK: {"active": "2026-10", "keys": {"2026-01": "<base64>", "2026-10": "<base64>"}}.
+type keyFile struct {
+	Active string            `json:"active"`
+	Keys   map[string]string `json:"keys"`
+}
+
+// NewFileKeyProvider membaca KEK dari file JSON, misalnya secret yang di-mount oleh orkestrator.
+func NewFileKeyProvider(path string) (KeyProvider, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key file: %w", err)
+	}
+	var file keyFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	return NewKeyProvider(file.Active, file.Keys)
+}
+
+// Config adalah konfigurasi enkripsi data pribadi konsumen.
+type Config struct {
+	Keys          KeyProvider
+	BlindIndexKey []byte
+}
+
+// LoadConfig membaca konfigurasi enkripsi dari environment melalui getenv (biasanya os.Getenv). KEK dibaca dari
+// file PII_KEK_FILE jika diisi, selain itu dari PII_KEKS dan PII_ACTIVE_KEK. PII_BLIND_INDEX_KEY wajib diisi.
+func LoadConfig(getenv func(string) string) (Config, error) {
+	var (
+		keys KeyProvider
+		err  error
+	)
+	if path := strings.TrimSpace(getenv("PII_KEK_FILE")); path != "" {
+		keys, err = NewFileKeyProvider(path)
+	} else {
+		keys, err = NewEnvKeyProvider(getenv("PII_ACTIVE_KEK"), getenv("PII_KEKS"))
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	blindIndexKey, err := decodeKey(getenv("PII_BLIND_INDEX_KEY"))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid PII_BLIND_INDEX_KEY: %w", err)
+	}
+	return Config{Keys: keys, BlindIndexKey: blindIndexKey}, nil
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded")
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
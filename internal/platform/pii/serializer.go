@@ -0,0 +1,275 @@
+package pii
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName adalah nama serializer GORM untuk kolom data pribadi, dipakai sebagai tag `serializer:pii`.
+const SerializerName = "pii"
+
+const dateLayout = "2006-01-02"
+
+const nameIndexPrefix = "name:"
+
+// defaultCipher adalah Cipher yang dipakai serializer GORM; serializer didaftarkan secara global sehingga
+// Cipher-nya juga global.
+var defaultCipher atomic.Pointer[Cipher]
+
+func init() {
+	schema.RegisterSerializer(SerializerName, serializer{})
+}
+
+// Use menetapkan Cipher yang dipakai untuk mengenkripsi dan mendekripsi kolom bertag `serializer:pii`.
+func Use(c *Cipher) {
+	defaultCipher.Store(c)
+}
+
+func current() (*Cipher, error) {
+	c := defaultCipher.Load()
+	if c == nil {
+		return nil, fmt.Errorf("PII encryption is not configured")
+	}
+	return c, nil
+}
+
+// BlindIndex menghitung blind index value dengan Cipher yang sedang dipakai.
+func BlindIndex(value string) (string, error) {
+	c, err := current()
+	if err != nil {
+		return "", err
+	}
+	return c.BlindIndex(value), nil
+}
+
+// NameIndex menghitung blind index setiap kata pada name (lihat domain.NameTokens), dipisahkan spasi. Nama dapat
+// dicari per kata utuh tanpa memperhatikan huruf besar-kecil, tetapi tidak sebagian kata. Kata diberi awalan
+// agar blind index-nya tidak pernah sama dengan blind index NIK.
+func NameIndex(name string) (string, error) {
+	c, err := current()
+	if err != nil {
+		return "", err
+	}
+	tokens := domain.NameTokens(name)
+	for i, token := range tokens {
+		tokens[i] = c.BlindIndex(nameIndexPrefix + token)
+	}
+	return strings.Join(tokens, " "), nil
+}
+
+// RegisterCallbacks memasang callback GORM yang mengisi primary key baris baru dari sequence tabelnya sebelum
+// INSERT. Ciphertext terikat pada ID baris, sehingga ID tersebut harus sudah ada saat kolom dienkripsi.
+func RegisterCallbacks(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register("pii:assign_row_id", assignRowID)
+}
+
+func assignRowID(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || !hasEncryptedField(db.Statement.Schema) {
+		return
+	}
+	primaryKey := db.Statement.Schema.PrioritizedPrimaryField
+	if primaryKey == nil {
+		return
+	}
+	assign := func(row reflect.Value) {
+		if _, zero := primaryKey.ValueOf(db.Statement.Context, row); !zero {
+			return
+		}
+		var id uint
+		err := db.Session(&gorm.Session{NewDB: true}).
+			Raw("SELECT nextval(pg_get_serial_sequence(?, ?))", db.Statement.Table, primaryKey.DBName).
+			Scan(&id).Error
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("could not assign id for table %s: %w", db.Statement.Table, err))
+			return
+		}
+		_ = db.AddError(primaryKey.Set(db.Statement.Context, row, id))
+	}
+	switch rows := db.Statement.ReflectValue; rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			assign(reflect.Indirect(rows.Index(i)))
+		}
+	case reflect.Struct:
+		assign(rows)
+	}
+}
+
+func hasEncryptedField(s *schema.Schema) bool {
+	for _, field := range s.Fields {
+		if _, ok := field.Serializer.(serializer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// EncryptUpdates mengenkripsi nilai kolom bertag `serializer:pii` pada map updates untuk model, yang wajib berisi
+// ID baris yang diperbarui. GORM tidak menjalankan serializer untuk Updates dengan map, sehingga repository wajib
+// memanggil ini sebelumnya.
+func EncryptUpdates(db *gorm.DB, model interface{}, updates map[string]interface{}) (map[string]interface{}, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, err
+	}
+	row := reflect.Indirect(reflect.ValueOf(model))
+	encrypted := make(map[string]interface{}, len(updates))
+	for column, value := range updates {
+		if field := stmt.Schema.LookUpField(column); field != nil {
+			if _, ok := field.Serializer.(serializer); ok {
+				sealed, err := (serializer{}).Value(context.Background(), field, row, value)
+				if err != nil {
+					return nil, err
+				}
+				value = sealed
+			}
+		}
+		encrypted[column] = value
+	}
+	return encrypted, nil
+}
+
+// serializer mengenkripsi kolom string, domain.Money, dan tanggal (domain.JSONDate) menjadi teks terenkripsi.
+type serializer struct{}
+
+func (serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	target := reflect.New(field.FieldType).Elem()
+	if dbValue != nil {
+		var raw string
+		switch v := dbValue.(type) {
+		case string:
+			raw = v
+		case []byte:
+			raw = string(v)
+		case time.Time:
+			// Kolom tanggal lama yang belum diubah menjadi teks.
+			raw = v.Format(dateLayout)
+		default:
+			return fmt.Errorf("unsupported value %T for encrypted column %s", dbValue, field.DBName)
+		}
+
+		plaintext := []byte(raw)
+		if IsEncrypted(raw) {
+			c, err := current()
+			if err != nil {
+				return err
+			}
+			if plaintext, err = c.Decrypt(location(ctx, field, dst), raw); err != nil {
+				return err
+			}
+		}
+		if err := decodePlaintext(target, string(plaintext)); err != nil {
+			return fmt.Errorf("could not decode column %s: %w", field.DBName, err)
+		}
+	}
+	field.ReflectValueOf(ctx, dst).Set(target)
+	return nil
+}
+
+func (serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (
+	interface{},
+	error,
+) {
+	plaintext, ok, err := encodePlaintext(fieldValue)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode column %s: %w", field.DBName, err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	c, err := current()
+	if err != nil {
+		return nil, err
+	}
+	return c.Encrypt(location(ctx, field, dst), []byte(plaintext))
+}
+
+// location menentukan Location kolom field pada baris dst. RowID bernilai nol jika primary key baris belum
+// diketahui.
+func location(ctx context.Context, field *schema.Field, dst reflect.Value) Location {
+	loc := Location{Table: field.Schema.Table, Column: field.DBName}
+	if primaryKey := field.Schema.PrioritizedPrimaryField; primaryKey != nil && dst.IsValid() {
+		if id, zero := primaryKey.ValueOf(ctx, dst); !zero {
+			if rowID, ok := id.(uint); ok {
+				loc.RowID = rowID
+			}
+		}
+	}
+	return loc
+}
+
+// encodePlaintext mengubah nilai field menjadi teks sebelum dienkripsi; ok bernilai false untuk nilai NULL.
+func encodePlaintext(value interface{}) (string, bool, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return v, true, nil
+	case *string:
+		if v == nil {
+			return "", false, nil
+		}
+		return *v, true, nil
+	case domain.Money:
+		return v.String(), true, nil
+	case *domain.Money:
+		if v == nil {
+			return "", false, nil
+		}
+		return v.String(), true, nil
+	case domain.JSONDate:
+		return time.Time(v).Format(dateLayout), true, nil
+	case *domain.JSONDate:
+		if v == nil {
+			return "", false, nil
+		}
+		return time.Time(*v).Format(dateLayout), true, nil
+	case time.Time:
+		return v.Format(dateLayout), true, nil
+	case *time.Time:
+		if v == nil {
+			return "", false, nil
+		}
+		return v.Format(dateLayout), true, nil
+	default:
+		return "", false, fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+// decodePlaintext mengisi target (bertipe field) dari teks hasil dekripsi.
+func decodePlaintext(target reflect.Value, plaintext string) error {
+	switch target.Addr().Interface().(type) {
+	case *string:
+		target.SetString(plaintext)
+	case *domain.Money:
+		money, err := domain.ParseMoney(plaintext)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(money))
+	case **domain.JSONDate:
+		date, err := time.Parse(dateLayout, plaintext)
+		if err != nil {
+			return err
+		}
+		jsonDate := domain.JSONDate(date)
+		target.Set(reflect.ValueOf(&jsonDate))
+	case *domain.JSONDate:
+		date, err := time.Parse(dateLayout, plaintext)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(domain.JSONDate(date)))
+	default:
+		return fmt.Errorf("unsupported type %s", target.Type())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// ParseTimeOfDay mengurai jam harian berformat HH:MM menjadi durasi sejak tengah malam.
+// String kosong menghasilkan fallback.
+func ParseTimeOfDay(raw string, fallback time.Duration) (time.Duration, error) {
+	if strings.TrimSpace(raw) == "" {
+		return fallback, nil
+	}
+	t, err := time.Parse("15:04", strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", raw)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// RunDaily menjalankan job setiap hari pada jam tertentu (waktu lokal) sampai ctx dibatalkan.
+// Error dari job hanya dicatat ke log agar jadwal berikutnya tetap berjalan.
+func RunDaily(ctx context.Context, name string, at time.Duration, job func(now time.Time) error) {
+	for {
+		now := time.Now()
+		next := nextRun(now, at)
+		log.Printf("Scheduler: %s scheduled at %s", name, next.Format(time.RFC3339))
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case firedAt := <-timer.C:
+			if err := job(firedAt); err != nil {
+				log.Printf("Scheduler: %s failed: %v", name, err)
+			}
+		}
+	}
+}
+
+// RunEvery menjalankan job setiap interval sampai ctx dibatalkan.
+// Error dari job hanya dicatat ke log agar eksekusi berikutnya tetap berjalan.
+func RunEvery(ctx context.Context, name string, interval time.Duration, job func(now time.Time) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Scheduler: %s scheduled every %s", name, interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case firedAt := <-ticker.C:
+			if err := job(firedAt); err != nil {
+				log.Printf("Scheduler: %s failed: %v", name, err)
+			}
+		}
+	}
+}
+
+// nextRun mengembalikan waktu eksekusi berikutnya setelah now untuk jam harian at.
+func nextRun(now time.Time, at time.Duration) time.Time {
+	year, month, day := now.Date()
+	next := time.Date(year, month, day, 0, 0, 0, 0, now.Location()).Add(at)
+	if !next.After(now) {
+		next = time.Date(year, month, day+1, 0, 0, 0, 0, now.Location()).Add(at)
+	}
+	return next
+}
@@ -3,6 +3,7 @@ package seeder
 import (
 	"errors"
 	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/pii"
 	"gorm.io/gorm"
 	"log"
 	"time"
@@ -17,6 +18,11 @@ func Run(db *gorm.DB) {
 		log.Fatalf("Failed to seed admin user: %v", err)
 	}
 
+	// Jalankan seeder untuk katalog produk pembiayaan
+	if err := createProducts(db); err != nil {
+		log.Fatalf("Failed to seed products: %v", err)
+	}
+
 	// Jalankan seeder untuk konsumen Budi (user dan data consumer)
 	if err := createBudi(db); err != nil {
 		log.Fatalf("Failed to seed Budi's data: %v", err)
@@ -57,7 +63,8 @@ func createAdminUser(db *gorm.DB) error {
 	return nil
 }
 
-func createUserIfNotExists(db *gorm.DB, fullName, email, password, role string) (uint, error) {
+// createUserIfNotExists membuat akun login. Nama konsumen hanya disimpan di data konsumen, tidak di users.
+func createUserIfNotExists(db *gorm.DB, email, password, role string) (uint, error) {
 	var existingUser domain.User
 	err := db.Where("email = ?", email).First(&existingUser).Error
 	if err == nil {
@@ -69,9 +76,8 @@ func createUserIfNotExists(db *gorm.DB, fullName, email, password, role string)
 	}
 
 	newUser := &domain.User{
-		FullName: fullName,
-		Email:    email,
-		Role:     role,
+		Email: email,
+		Role:  role,
 	}
 	if err := newUser.HashPassword(password); err != nil {
 		return 0, err
@@ -79,21 +85,21 @@ func createUserIfNotExists(db *gorm.DB, fullName, email, password, role string)
 	if err := db.Create(newUser).Error; err != nil {
 		return 0, err
 	}
-	log.Printf("Successfully seeded user '%s'.\n", fullName)
+	log.Printf("Successfully seeded user '%s'.\n", email)
 	return newUser.ID, nil
 }
 
 // createBudi membuat data user dan consumer untuk Budi.
 func createBudi(db *gorm.DB) error {
 	// 1. Buat User untuk Budi
-	budiUserID, err := createUserIfNotExists(db, "Budi Santoso", "budi@example.com", "passwordbudi", "consumer")
+	budiUserID, err := createUserIfNotExists(db, "budi@example.com", "passwordbudi", "consumer")
 	if err != nil {
 		return err
 	}
 
 	// 2. Buat Consumer Budi dan tautkan dengan UserID
 	var consumerBudi domain.Consumer
-	err = db.Where("nik = ?", "3271011505900001").First(&consumerBudi).Error
+	err = db.Where("user_id = ?", budiUserID).First(&consumerBudi).Error
 	if err == nil {
 		log.Printf("Consumer 'Budi' already exists. Skipping consumer creation.\n")
 	} else if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -106,8 +112,17 @@ func createBudi(db *gorm.DB) error {
 			LegalName:          "Budi Santoso",
 			TempatLahir:        "Bandung",
 			TanggalLahir:       &jsonDob,
-			Gaji:               8000000,
-			OverallCreditLimit: 20000000,
+			JenisKelamin:       domain.JenisKelaminLakiLaki,
+			Gaji:               domain.NewMoney(8000000),
+			OverallCreditLimit: domain.NewMoney(20000000),
+			// Konsumen contoh dianggap sudah lolos verifikasi KYC agar dapat langsung bertransaksi.
+			KYCVerification: domain.KYCVerification{StatusKYC: domain.StatusKYCApproved},
+		}
+		if consumerBudi.NikHash, err = pii.BlindIndex(consumerBudi.Nik); err != nil {
+			return err
+		}
+		if consumerBudi.FullNameTokens, err = pii.NameIndex(consumerBudi.FullName); err != nil {
+			return err
 		}
 		if err := db.Create(&consumerBudi).Error; err != nil {
 			return err
@@ -125,10 +140,10 @@ func createBudi(db *gorm.DB) error {
 		return nil
 	}
 	creditLimits := []domain.ConsumerCreditLimit{
-		{ConsumerID: consumerBudi.ID, TenorMonths: 1, CreditLimit: 2000000},
-		{ConsumerID: consumerBudi.ID, TenorMonths: 2, CreditLimit: 3500000},
-		{ConsumerID: consumerBudi.ID, TenorMonths: 3, CreditLimit: 5000000},
-		{ConsumerID: consumerBudi.ID, TenorMonths: 6, CreditLimit: 8000000},
+		{ConsumerID: consumerBudi.ID, TenorMonths: 1, CreditLimit: domain.NewMoney(2000000)},
+		{ConsumerID: consumerBudi.ID, TenorMonths: 2, CreditLimit: domain.NewMoney(3500000)},
+		{ConsumerID: consumerBudi.ID, TenorMonths: 3, CreditLimit: domain.NewMoney(5000000)},
+		{ConsumerID: consumerBudi.ID, TenorMonths: 6, CreditLimit: domain.NewMoney(8000000)},
 	}
 	if err := db.Create(&creditLimits).Error; err != nil {
 		return err
@@ -140,20 +155,14 @@ func createBudi(db *gorm.DB) error {
 // createAnnisa membuat data user dan consumer untuk Annisa.
 func createAnnisa(db *gorm.DB) error {
 	// 1. Buat User untuk Annisa
-	annisaUserID, err := createUserIfNotExists(
-		db,
-		"Annisa Fitriani",
-		"annisa@example.com",
-		"passwordannisa",
-		"consumer",
-	)
+	annisaUserID, err := createUserIfNotExists(db, "annisa@example.com", "passwordannisa", "consumer")
 	if err != nil {
 		return err
 	}
 
 	// 2. Buat Consumer Annisa dan tautkan dengan UserID
 	var consumerAnnisa domain.Consumer
-	err = db.Where("nik = ?", "3271011505900002").First(&consumerAnnisa).Error
+	err = db.Where("user_id = ?", annisaUserID).First(&consumerAnnisa).Error
 	if err == nil {
 		log.Printf("Consumer 'Annisa' already exists. Skipping consumer creation.\n")
 	} else if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -161,13 +170,22 @@ func createAnnisa(db *gorm.DB) error {
 		jsonDob := domain.JSONDate(tanggalLahirAnnisa)
 		consumerAnnisa = domain.Consumer{
 			UserID:             annisaUserID,
-			Nik:                "3271011505900002",
+			Nik:                "3174066008920001",
 			FullName:           "Annisa Fitriani",
 			LegalName:          "Annisa Fitriani",
 			TempatLahir:        "Jakarta",
 			TanggalLahir:       &jsonDob,
-			Gaji:               12000000,
-			OverallCreditLimit: 25000000,
+			JenisKelamin:       domain.JenisKelaminPerempuan,
+			Gaji:               domain.NewMoney(12000000),
+			OverallCreditLimit: domain.NewMoney(25000000),
+			// Konsumen contoh dianggap sudah lolos verifikasi KYC agar dapat langsung bertransaksi.
+			KYCVerification: domain.KYCVerification{StatusKYC: domain.StatusKYCApproved},
+		}
+		if consumerAnnisa.NikHash, err = pii.BlindIndex(consumerAnnisa.Nik); err != nil {
+			return err
+		}
+		if consumerAnnisa.FullNameTokens, err = pii.NameIndex(consumerAnnisa.FullName); err != nil {
+			return err
 		}
 		if err := db.Create(&consumerAnnisa).Error; err != nil {
 			return err
@@ -185,10 +203,10 @@ func createAnnisa(db *gorm.DB) error {
 		return nil
 	}
 	creditLimits := []domain.ConsumerCreditLimit{
-		{ConsumerID: consumerAnnisa.ID, TenorMonths: 1, CreditLimit: 1000000},
-		{ConsumerID: consumerAnnisa.ID, TenorMonths: 2, CreditLimit: 1200000},
-		{ConsumerID: consumerAnnisa.ID, TenorMonths: 3, CreditLimit: 1500000},
-		{ConsumerID: consumerAnnisa.ID, TenorMonths: 6, CreditLimit: 2000000},
+		{ConsumerID: consumerAnnisa.ID, TenorMonths: 1, CreditLimit: domain.NewMoney(1000000)},
+		{ConsumerID: consumerAnnisa.ID, TenorMonths: 2, CreditLimit: domain.NewMoney(1200000)},
+		{ConsumerID: consumerAnnisa.ID, TenorMonths: 3, CreditLimit: domain.NewMoney(1500000)},
+		{ConsumerID: consumerAnnisa.ID, TenorMonths: 6, CreditLimit: domain.NewMoney(2000000)},
 	}
 	if err := db.Create(&creditLimits).Error; err != nil {
 		return err
@@ -196,3 +214,71 @@ func createAnnisa(db *gorm.DB) error {
 	log.Printf("Successfully seeded %d credit limits for '%s'.\n", len(creditLimits), consumerAnnisa.FullName)
 	return nil
 }
+
+// createProducts membuat produk pembiayaan awal: MOTOR, ELEKTRONIK, dan WHITE_GOODS.
+func createProducts(db *gorm.DB) error {
+	tenors := func(rate float64, tenorBulan ...int) []domain.ProductTenor {
+		result := make([]domain.ProductTenor, 0, len(tenorBulan))
+		for _, tenor := range tenorBulan {
+			result = append(
+				result, domain.ProductTenor{
+					TenorBulan:       tenor,
+					MetodeBunga:      "FLAT",
+					SukuBungaTahunan: rate,
+					DendaHarian:      0.001,
+					MaksimalDenda:    0.5,
+				},
+			)
+		}
+		return result
+	}
+
+	products := []domain.Product{
+		{
+			Kode:                  "MOTOR",
+			Nama:                  "Motor",
+			MinimalUangMukaPersen: 0.2,
+			MaksimalPembiayaan:    domain.NewMoney(50000000),
+			MaksimalLTV:           0.85,
+			MaksimalOtr:           domain.NewMoney(75000000),
+			BiayaAdminTetap:       domain.NewMoney(250000),
+			Aktif:                 true,
+			Tenors:                tenors(0.24, 1, 2, 3, 6),
+		},
+		{
+			Kode:                  "ELEKTRONIK",
+			Nama:                  "Elektronik",
+			MinimalUangMukaPersen: 0.1,
+			MaksimalPembiayaan:    domain.NewMoney(20000000),
+			MinimalOtr:            domain.NewMoney(500000),
+			BiayaAdminTetap:       domain.NewMoney(100000),
+			MinimalBiayaAdmin:     domain.NewMoney(50000),
+			MaksimalBiayaAdmin:    domain.NewMoney(200000),
+			Aktif:                 true,
+			Tenors:                tenors(0.24, 1, 2, 3, 6),
+		},
+		{
+			Kode:                  "WHITE_GOODS",
+			Nama:                  "White Goods",
+			MinimalUangMukaPersen: 0.1,
+			MaksimalPembiayaan:    domain.NewMoney(30000000),
+			BiayaAdminPersen:      0.01,
+			Aktif:                 true,
+			Tenors:                tenors(0.24, 3, 6),
+		},
+	}
+
+	for i := range products {
+		var count int64
+		db.Model(&domain.Product{}).Where("kode = ?", products[i].Kode).Count(&count)
+		if count > 0 {
+			log.Printf("Product '%s' already exists. Skipping.\n", products[i].Kode)
+			continue
+		}
+		if err := db.Create(&products[i]).Error; err != nil {
+			return err
+		}
+		log.Printf("Product '%s' created.\n", products[i].Kode)
+	}
+	return nil
+}
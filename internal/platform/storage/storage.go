@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// Backend penyimpanan dokumen yang didukung.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// DefaultLocalDir adalah direktori penyimpanan lokal bawaan, relatif terhadap direktori kerja aplikasi.
+const DefaultLocalDir = "uploads"
+
+// Config memilih backend penyimpanan dokumen beserta konfigurasinya.
+type Config struct {
+	Backend  string
+	LocalDir string
+	S3       S3Config
+}
+
+// LoadConfig membaca konfigurasi penyimpanan dokumen dari environment melalui getenv (biasanya os.Getenv).
+// DOCUMENT_STORAGE kosong berarti penyimpanan lokal di DOCUMENT_STORAGE_DIR.
+func LoadConfig(getenv func(string) string) (Config, error) {
+	cfg := Config{
+		Backend:  strings.ToLower(strings.TrimSpace(getenv("DOCUMENT_STORAGE"))),
+		LocalDir: strings.TrimSpace(getenv("DOCUMENT_STORAGE_DIR")),
+		S3: S3Config{
+			Endpoint:        strings.TrimSpace(getenv("S3_ENDPOINT")),
+			Region:          strings.TrimSpace(getenv("S3_REGION")),
+			Bucket:          strings.TrimSpace(getenv("S3_BUCKET")),
+			AccessKeyID:     strings.TrimSpace(getenv("S3_ACCESS_KEY_ID")),
+			SecretAccessKey: getenv("S3_SECRET_ACCESS_KEY"),
+			UsePathStyle:    true,
+		},
+	}
+	if cfg.Backend == "" {
+		cfg.Backend = BackendLocal
+	}
+	if cfg.LocalDir == "" {
+		cfg.LocalDir = DefaultLocalDir
+	}
+	if raw := strings.TrimSpace(getenv("S3_USE_PATH_STYLE")); raw != "" {
+		usePathStyle, err := strconv.ParseBool(raw)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid S3_USE_PATH_STYLE %q, expected true or false", raw)
+		}
+		cfg.S3.UsePathStyle = usePathStyle
+	}
+	return cfg, nil
+}
+
+// New membuat DocumentStorage sesuai backend pada konfigurasi.
+func New(cfg Config) (domain.DocumentStorage, error) {
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocalStorage(cfg.LocalDir)
+	case BackendS3:
+		return NewS3Storage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown document storage backend %q, expected local or s3", cfg.Backend)
+	}
+}
+
+// cleanKey menolak key kosong, absolut, atau yang keluar dari root penyimpanan (misalnya berisi "..").
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid document key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("invalid document key %q", key)
+		}
+	}
+	return key, nil
+}
@@ -23,6 +23,24 @@ func (r *consumerCreditLimitRepository) Save(consumerCreditLimit *domain.Consume
 	return r.db.Create(consumerCreditLimit).Error
 }
 
+func (r *consumerCreditLimitRepository) Update(id uint, updates map[string]interface{}) error {
+	return r.db.Model(&domain.ConsumerCreditLimit{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete melakukan soft delete sehingga kontrak yang merujuk limit ini tetap valid.
+func (r *consumerCreditLimitRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.ConsumerCreditLimit{}, id).Error
+}
+
+func (r *consumerCreditLimitRepository) FindByID(id uint) (*domain.ConsumerCreditLimit, error) {
+	var limit domain.ConsumerCreditLimit
+	err := r.db.First(&limit, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
 func (r *consumerCreditLimitRepository) FindByConsumerAndTenor(
 	consumerID uint,
 	tenorMonths int,
@@ -34,3 +52,12 @@ func (r *consumerCreditLimitRepository) FindByConsumerAndTenor(
 	}
 	return &limit, nil
 }
+
+func (r *consumerCreditLimitRepository) FindByConsumerID(consumerID uint) ([]*domain.ConsumerCreditLimit, error) {
+	var limits []*domain.ConsumerCreditLimit
+	err := r.db.Where("consumer_id = ?", consumerID).Order("tenor_months asc").Find(&limits).Error
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type consumerDocumentRepository struct {
+	db *gorm.DB
+}
+
+func NewConsumerDocumentRepository(db *gorm.DB) domain.ConsumerDocumentRepository {
+	return &consumerDocumentRepository{db: db}
+}
+
+func (r *consumerDocumentRepository) WithTx(tx *gorm.DB) domain.ConsumerDocumentRepository {
+	return &consumerDocumentRepository{db: tx}
+}
+
+func (r *consumerDocumentRepository) Save(document *domain.ConsumerDocument) error {
+	return r.db.Create(document).Error
+}
+
+func (r *consumerDocumentRepository) FindByID(id uint) (*domain.ConsumerDocument, error) {
+	var document domain.ConsumerDocument
+	if err := r.db.First(&document, id).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindByConsumerID mengambil seluruh dokumen konsumen, termasuk dokumen yang sudah diganti, dari yang terbaru.
+func (r *consumerDocumentRepository) FindByConsumerID(consumerID uint) ([]*domain.ConsumerDocument, error) {
+	var documents []*domain.ConsumerDocument
+	err := r.db.Where("consumer_id = ?", consumerID).Order("created_at desc, id desc").Find(&documents).Error
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
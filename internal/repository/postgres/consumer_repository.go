@@ -1,7 +1,11 @@
 package postgres
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/pii"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -30,20 +34,64 @@ func (r *consumerRepository) FindByIDForUpdate(id uint) (*domain.Consumer, error
 	return &consumer, nil
 }
 
-// Save menyimpan data konsumen baru ke database.
+// Save menyimpan data konsumen baru ke database beserta blind index NIK dan namanya.
 func (r *consumerRepository) Save(consumer *domain.Consumer) error {
+	if err := setBlindIndexes(consumer); err != nil {
+		return err
+	}
 	return r.db.Create(consumer).Error
 }
 
-// Update memperbarui data konsumen yang sudah ada di database.
+// Update memperbarui data konsumen yang sudah ada di database. Kolom data pribadi dienkripsi terlebih dahulu
+// karena GORM tidak menjalankan serializer untuk update dengan map.
 func (r *consumerRepository) Update(id uint, updates map[string]interface{}) error {
-	return r.db.Model(&domain.Consumer{}).Where("id = ?", id).Updates(updates).Error
+	if fullName, ok := updates["full_name"].(string); ok {
+		fullNameTokens, err := pii.NameIndex(fullName)
+		if err != nil {
+			return err
+		}
+		updates["full_name_tokens"] = fullNameTokens
+	}
+	encrypted, err := pii.EncryptUpdates(r.db, &domain.Consumer{ID: id}, updates)
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&domain.Consumer{}).Where("id = ?", id).Updates(encrypted).Error
+}
+
+// UpdatePII menulis ulang kolom data pribadi konsumen dengan DEK aktif dan menghitung ulang blind index NIK dan
+// namanya.
+func (r *consumerRepository) UpdatePII(consumer *domain.Consumer) error {
+	if err := setBlindIndexes(consumer); err != nil {
+		return err
+	}
+	return r.db.Model(consumer).
+		Select("nik", "nik_hash", "full_name", "full_name_tokens", "legal_name", "tanggal_lahir", "gaji").
+		Updates(consumer).Error
+}
+
+// setBlindIndexes menghitung blind index NIK dan nama konsumen dari nilai plaintext-nya.
+func setBlindIndexes(consumer *domain.Consumer) error {
+	nikHash, err := pii.BlindIndex(consumer.Nik)
+	if err != nil {
+		return err
+	}
+	fullNameTokens, err := pii.NameIndex(consumer.FullName)
+	if err != nil {
+		return err
+	}
+	consumer.NikHash = nikHash
+	consumer.FullNameTokens = fullNameTokens
+	return nil
 }
 
 // FindByUserID mencari konsumen berdasarkan ID pengguna mereka.
 func (r *consumerRepository) FindByUserID(userID uint) (*domain.Consumer, error) {
 	var consumer domain.Consumer
-	err := r.db.Where("user_id = ?", userID).Preload("CreditLimits").Preload("Transactions").First(&consumer).Error
+	err := r.withDocuments().Where("user_id = ?", userID).
+		Preload("CreditLimits").
+		Preload("Transactions").
+		First(&consumer).Error
 	if err != nil {
 		return nil, err
 	}
@@ -53,34 +101,195 @@ func (r *consumerRepository) FindByUserID(userID uint) (*domain.Consumer, error)
 // FindByID mencari satu konsumen berdasarkan ID mereka.
 func (r *consumerRepository) FindByID(id uint) (*domain.Consumer, error) {
 	var consumer domain.Consumer
-	err := r.db.Preload("User").Preload("CreditLimits").Preload("Transactions").First(&consumer, id).Error
+	err := r.withDocuments().Preload("User").Preload("CreditLimits").Preload("Transactions").First(&consumer, id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &consumer, nil
 }
 
-// FindByNIK mencari satu konsumen berdasarkan NIK mereka.
+// FindByNIK mencari satu konsumen berdasarkan NIK mereka melalui blind index. Baris lama yang belum dienkripsi
+// ulang belum memiliki blind index sehingga dicari dari NIK plaintext-nya.
 func (r *consumerRepository) FindByNIK(nik string) (*domain.Consumer, error) {
+	nikHash, err := pii.BlindIndex(nik)
+	if err != nil {
+		return nil, err
+	}
 	var consumer domain.Consumer
-	err := r.db.Where("nik = ?", nik).First(&consumer).Error
+	err = r.db.Where("nik_hash = ?", nikHash).Or("nik_hash IS NULL AND nik = ?", nik).First(&consumer).Error
 	if err != nil {
 		return nil, err
 	}
 	return &consumer, nil
 }
 
-// FindAll mengambil semua data konsumen dari database.
-func (r *consumerRepository) FindAll() ([]*domain.Consumer, error) {
+// FindPage mengambil satu halaman konsumen sesuai filter, dengan offset atau cursor. Hanya relasi pada
+// filter.Include yang ikut dimuat.
+func (r *consumerRepository) FindPage(filter domain.ConsumerListFilter) ([]*domain.Consumer, error) {
+	query, err := r.filtered(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, relasi := range filter.Include {
+		switch relasi {
+		case domain.RelasiKonsumenUser:
+			query = query.Preload("User")
+		case domain.RelasiKonsumenCreditLimits:
+			query = query.Preload("CreditLimits")
+		case domain.RelasiKonsumenTransactions:
+			query = query.Preload("Transactions")
+		case domain.RelasiKonsumenDokumen:
+			query = query.Preload("FotoKtp").Preload("FotoSelfie")
+		}
+	}
+
+	// Kolom urut hanya berasal dari daftar yang diizinkan sehingga aman disisipkan ke SQL.
+	column := consumerSortColumns[filter.UrutBerdasarkan]
+	if column == "" {
+		column = consumerSortColumns[domain.UrutKonsumenID]
+	}
+	direction, comparison := "ASC", ">"
+	if filter.UrutMenurun {
+		direction, comparison = "DESC", "<"
+	}
+	if filter.Setelah != nil {
+		if column == "consumers.id" {
+			query = query.Where("consumers.id "+comparison+" ?", filter.Setelah.ID)
+		} else {
+			query = query.Where(
+				fmt.Sprintf("(%s, consumers.id) %s (?, ?)", column, comparison),
+				filter.Setelah.Nilai, filter.Setelah.ID,
+			)
+		}
+	}
+	if column != "consumers.id" {
+		query = query.Order(column + " " + direction)
+	}
+	query = query.Order("consumers.id " + direction)
+
 	var consumers []*domain.Consumer
-	err := r.db.Preload("User").Preload("CreditLimits").Preload("Transactions").Find(&consumers).Error
+	err = query.Offset(filter.Offset).Limit(filter.Limit).Find(&consumers).Error
 	if err != nil {
 		return nil, err
 	}
 	return consumers, nil
 }
 
+// Count menghitung jumlah konsumen yang cocok dengan filter, tanpa memperhatikan halaman dan urutan.
+func (r *consumerRepository) Count(filter domain.ConsumerListFilter) (int64, error) {
+	query, err := r.filtered(filter)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// FindByStatusKYC mengambil konsumen dengan status KYC tertentu, diurutkan dari pengajuan paling lama.
+func (r *consumerRepository) FindByStatusKYC(statuses []string) ([]*domain.Consumer, error) {
+	var consumers []*domain.Consumer
+	err := r.withDocuments().Preload("User").
+		Where("status_kyc IN ?", statuses).
+		Order("kyc_diajukan_pada asc, id asc").
+		Find(&consumers).Error
+	if err != nil {
+		return nil, err
+	}
+	return consumers, nil
+}
+
+// FindAfterID mengambil paling banyak limit konsumen dengan ID lebih besar dari afterID, terurut menurut ID.
+func (r *consumerRepository) FindAfterID(afterID uint, limit int) ([]*domain.Consumer, error) {
+	var consumers []*domain.Consumer
+	err := r.db.Where("id > ?", afterID).Order("id asc").Limit(limit).Find(&consumers).Error
+	if err != nil {
+		return nil, err
+	}
+	return consumers, nil
+}
+
+// FindDelinquentIDs mengambil ID konsumen yang masih tercatat terlambat atau berkolektibilitas selain LANCAR.
+func (r *consumerRepository) FindDelinquentIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.Consumer{}).
+		Where("hari_keterlambatan > 0 OR kolektibilitas <> ?", domain.KolektibilitasLancar).
+		Order("id asc").
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 // Delete menghapus data konsumen dari database berdasarkan ID.
 func (r *consumerRepository) Delete(id uint) error {
 	return r.db.Delete(&domain.Consumer{}, id).Error
 }
+
+// consumerSortColumns memetakan kolom urut daftar konsumen ke kolom tabel.
+var consumerSortColumns = map[string]string{
+	domain.UrutKonsumenID:                 "consumers.id",
+	domain.UrutKonsumenCreatedAt:          "consumers.created_at",
+	domain.UrutKonsumenOverallCreditLimit: "consumers.overall_credit_limit",
+	domain.UrutKonsumenHariKeterlambatan:  "consumers.hari_keterlambatan",
+}
+
+// filtered menerapkan kriteria penyaringan daftar konsumen.
+func (r *consumerRepository) filtered(filter domain.ConsumerListFilter) (*gorm.DB, error) {
+	query := r.db.Model(&domain.Consumer{})
+	if filter.Nik != "" {
+		nikHash, err := pii.BlindIndex(filter.Nik)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(
+			"consumers.nik_hash = ? OR (consumers.nik_hash IS NULL AND consumers.nik = ?)", nikHash, filter.Nik,
+		)
+	}
+	if filter.Nama != "" {
+		// Setiap kata pada kata kunci harus ada pada blind index nama. Baris lama yang belum dienkripsi ulang
+		// belum memiliki blind index sehingga dicari dari nama plaintext-nya.
+		fullNameTokens, err := pii.NameIndex(filter.Nama)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(
+			"string_to_array(consumers.full_name_tokens, ' ') @> string_to_array(?, ' ') OR "+
+				"(consumers.full_name_tokens IS NULL AND consumers.full_name ILIKE ? ESCAPE '\\')",
+			fullNameTokens, "%"+escapeLike(filter.Nama)+"%",
+		)
+	}
+	if len(filter.StatusKYC) > 0 {
+		query = query.Where("consumers.status_kyc IN ?", filter.StatusKYC)
+	}
+	if filter.PunyaKontrakAktif != nil {
+		activeContract := "EXISTS (SELECT 1 FROM transactions WHERE transactions.consumer_id = consumers.id " +
+			"AND transactions.status_kontrak IN ?)"
+		if !*filter.PunyaKontrakAktif {
+			activeContract = "NOT " + activeContract
+		}
+		query = query.Where(
+			activeContract, []string{domain.StatusKontrakAktif, domain.StatusKontrakRestrukturisasi},
+		)
+	}
+	if filter.DibuatDari != nil {
+		query = query.Where("consumers.created_at >= ?", *filter.DibuatDari)
+	}
+	if filter.DibuatSebelum != nil {
+		query = query.Where("consumers.created_at < ?", *filter.DibuatSebelum)
+	}
+	return query, nil
+}
+
+// escapeLike meloloskan karakter wildcard LIKE agar kata kunci pencarian dicocokkan apa adanya.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// withDocuments memuat metadata foto KTP dan selfie yang berlaku.
+func (r *consumerRepository) withDocuments() *gorm.DB {
+	return r.db.Preload("FotoKtp").Preload("FotoSelfie")
+}
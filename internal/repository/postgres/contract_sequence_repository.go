@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type contractSequenceRepository struct {
+	db *gorm.DB
+}
+
+func NewContractSequenceRepository(db *gorm.DB) domain.ContractSequenceRepository {
+	return &contractSequenceRepository{db: db}
+}
+
+func (r *contractSequenceRepository) WithTx(tx *gorm.DB) domain.ContractSequenceRepository {
+	return &contractSequenceRepository{db: tx}
+}
+
+// Next memakai upsert atomik: baris kunci dibuat dengan nilai 1 atau dinaikkan satu jika sudah ada.
+// Jika transaksi pemanggil di-rollback, kenaikan nomor urut ikut dibatalkan.
+func (r *contractSequenceRepository) Next(kunci string) (int64, error) {
+	var nilai int64
+	err := r.db.Raw(
+		`INSERT INTO contract_sequences (kunci, nilai_terakhir, updated_at) VALUES (?, 1, NOW())
+		ON CONFLICT (kunci) DO UPDATE
+		SET nilai_terakhir = contract_sequences.nilai_terakhir + 1, updated_at = NOW()
+		RETURNING nilai_terakhir`,
+		kunci,
+	).Scan(&nilai).Error
+	if err != nil {
+		return 0, err
+	}
+	return nilai, nil
+}
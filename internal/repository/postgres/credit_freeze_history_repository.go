@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type creditFreezeHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditFreezeHistoryRepository(db *gorm.DB) domain.CreditFreezeHistoryRepository {
+	return &creditFreezeHistoryRepository{db: db}
+}
+
+func (r *creditFreezeHistoryRepository) WithTx(tx *gorm.DB) domain.CreditFreezeHistoryRepository {
+	return &creditFreezeHistoryRepository{db: tx}
+}
+
+func (r *creditFreezeHistoryRepository) Save(history *domain.CreditFreezeHistory) error {
+	return r.db.Create(history).Error
+}
+
+func (r *creditFreezeHistoryRepository) FindByConsumerID(consumerID uint) ([]*domain.CreditFreezeHistory, error) {
+	var histories []*domain.CreditFreezeHistory
+	err := r.db.Where("consumer_id = ?", consumerID).Order("created_at asc, id asc").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
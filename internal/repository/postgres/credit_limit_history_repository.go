@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type creditLimitHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditLimitHistoryRepository(db *gorm.DB) domain.CreditLimitHistoryRepository {
+	return &creditLimitHistoryRepository{db: db}
+}
+
+func (r *creditLimitHistoryRepository) WithTx(tx *gorm.DB) domain.CreditLimitHistoryRepository {
+	return &creditLimitHistoryRepository{db: tx}
+}
+
+func (r *creditLimitHistoryRepository) Save(history *domain.CreditLimitHistory) error {
+	return r.db.Create(history).Error
+}
+
+func (r *creditLimitHistoryRepository) FindByConsumerID(consumerID uint) ([]*domain.CreditLimitHistory, error) {
+	var histories []*domain.CreditLimitHistory
+	err := r.db.Where("consumer_id = ?", consumerID).Order("created_at asc, id asc").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
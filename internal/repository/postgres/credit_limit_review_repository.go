@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type creditLimitReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewCreditLimitReviewRepository(db *gorm.DB) domain.CreditLimitReviewRepository {
+	return &creditLimitReviewRepository{db: db}
+}
+
+func (r *creditLimitReviewRepository) WithTx(tx *gorm.DB) domain.CreditLimitReviewRepository {
+	return &creditLimitReviewRepository{db: tx}
+}
+
+// Save menyimpan usulan peninjauan baru beserta usulan limit per tenornya.
+func (r *creditLimitReviewRepository) Save(review *domain.CreditLimitReview) error {
+	return r.db.Create(review).Error
+}
+
+// Update memperbarui status dan keputusan usulan tanpa menyentuh usulan per tenornya.
+func (r *creditLimitReviewRepository) Update(review *domain.CreditLimitReview) error {
+	return r.db.Omit("Items").Save(review).Error
+}
+
+func (r *creditLimitReviewRepository) FindByID(id uint) (*domain.CreditLimitReview, error) {
+	var review domain.CreditLimitReview
+	if err := r.withItems().First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindByIDForUpdate mencari usulan dan mengunci barisnya agar tidak disetujui dan ditolak bersamaan.
+func (r *creditLimitReviewRepository) FindByIDForUpdate(id uint) (*domain.CreditLimitReview, error) {
+	var review domain.CreditLimitReview
+	err := r.withItems().Clauses(clause.Locking{Strength: "UPDATE"}).First(&review, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindByStatus mengambil usulan dengan status tertentu, terlama lebih dulu; status kosong mengambil semuanya.
+func (r *creditLimitReviewRepository) FindByStatus(status string) ([]*domain.CreditLimitReview, error) {
+	var reviews []*domain.CreditLimitReview
+	query := r.withItems().Order("created_at asc")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// FindConsumerIDsDueForReview mengambil konsumen yang plafon atau salah satu limit tenornya berakhir sebelum
+// dueBefore atau belum pernah ditinjau. Konsumen yang masih memiliki usulan MENUNGGU, atau yang sudah ditinjau
+// setelah reviewedAfter, dilewati agar usulan yang ditolak tidak langsung dibuat ulang.
+func (r *creditLimitReviewRepository) FindConsumerIDsDueForReview(dueBefore, reviewedAfter time.Time) ([]uint, error) {
+	var consumerIDs []uint
+	err := r.db.Model(&domain.Consumer{}).
+		Where(
+			"((overall_credit_limit > 0 AND (plafon_berlaku_sampai IS NULL OR plafon_berlaku_sampai <= ?)) OR "+
+				"id IN (?))",
+			dueBefore,
+			r.db.Model(&domain.ConsumerCreditLimit{}).
+				Select("consumer_id").
+				Where("berlaku_sampai IS NULL OR berlaku_sampai <= ?", dueBefore),
+		).
+		Where(
+			"id NOT IN (?)",
+			r.db.Model(&domain.CreditLimitReview{}).
+				Select("consumer_id").
+				Where("status = ? OR created_at > ?", domain.StatusReviewMenunggu, reviewedAfter),
+		).
+		Order("id asc").
+		Pluck("id", &consumerIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return consumerIDs, nil
+}
+
+func (r *creditLimitReviewRepository) withItems() *gorm.DB {
+	return r.db.Preload(
+		"Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tenor_months asc")
+		},
+	)
+}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type documentAccessLogRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentAccessLogRepository(db *gorm.DB) domain.DocumentAccessLogRepository {
+	return &documentAccessLogRepository{db: db}
+}
+
+func (r *documentAccessLogRepository) WithTx(tx *gorm.DB) domain.DocumentAccessLogRepository {
+	return &documentAccessLogRepository{db: tx}
+}
+
+func (r *documentAccessLogRepository) Save(log *domain.DocumentAccessLog) error {
+	return r.db.Create(log).Error
+}
+
+func (r *documentAccessLogRepository) FindByConsumerID(consumerID uint) ([]*domain.DocumentAccessLog, error) {
+	var logs []*domain.DocumentAccessLog
+	err := r.db.Where("consumer_id = ?", consumerID).Order("created_at desc, id desc").Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) domain.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+func (r *idempotencyKeyRepository) WithTx(tx *gorm.DB) domain.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: tx}
+}
+
+func (r *idempotencyKeyRepository) Save(key *domain.IdempotencyKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *idempotencyKeyRepository) FindByScopeAndKey(scope, kunci string) (*domain.IdempotencyKey, error) {
+	var key domain.IdempotencyKey
+	err := r.db.Where("scope = ? AND kunci = ?", scope, kunci).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
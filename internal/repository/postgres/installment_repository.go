@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type installmentRepository struct {
+	db *gorm.DB
+}
+
+func NewInstallmentRepository(db *gorm.DB) domain.InstallmentRepository {
+	return &installmentRepository{db: db}
+}
+
+func (r *installmentRepository) WithTx(tx *gorm.DB) domain.InstallmentRepository {
+	return &installmentRepository{db: tx}
+}
+
+// SaveAll menyimpan seluruh baris jadwal angsuran dalam satu perintah INSERT.
+func (r *installmentRepository) SaveAll(installments []*domain.Installment) error {
+	if len(installments) == 0 {
+		return nil
+	}
+	return r.db.Create(&installments).Error
+}
+
+// FindByTransactionID mengambil jadwal angsuran sebuah transaksi, diurutkan berdasarkan periode.
+func (r *installmentRepository) FindByTransactionID(transactionID uint) ([]*domain.Installment, error) {
+	var installments []*domain.Installment
+	err := r.db.Where("transaction_id = ?", transactionID).Order("angsuran_ke asc").Find(&installments).Error
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+// FindOutstandingByTransactionID mengambil angsuran yang belum lunas, dimulai dari periode tertua.
+func (r *installmentRepository) FindOutstandingByTransactionID(transactionID uint) ([]*domain.Installment, error) {
+	var installments []*domain.Installment
+	err := r.db.Where(
+		"transaction_id = ? AND status NOT IN ?",
+		transactionID,
+		[]string{domain.InstallmentStatusLunas, domain.InstallmentStatusDitutup},
+	).Order("angsuran_ke asc").Find(&installments).Error
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+func (r *installmentRepository) Update(installment *domain.Installment) error {
+	return r.db.Save(installment).Error
+}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type kycHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewKYCHistoryRepository(db *gorm.DB) domain.KYCHistoryRepository {
+	return &kycHistoryRepository{db: db}
+}
+
+func (r *kycHistoryRepository) WithTx(tx *gorm.DB) domain.KYCHistoryRepository {
+	return &kycHistoryRepository{db: tx}
+}
+
+func (r *kycHistoryRepository) Save(history *domain.KYCHistory) error {
+	return r.db.Create(history).Error
+}
+
+func (r *kycHistoryRepository) FindByConsumerID(consumerID uint) ([]*domain.KYCHistory, error) {
+	var histories []*domain.KYCHistory
+	err := r.db.Where("consumer_id = ?", consumerID).Order("created_at asc, id asc").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
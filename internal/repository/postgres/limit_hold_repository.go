@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type limitHoldRepository struct {
+	db *gorm.DB
+}
+
+func NewLimitHoldRepository(db *gorm.DB) domain.LimitHoldRepository {
+	return &limitHoldRepository{db: db}
+}
+
+func (r *limitHoldRepository) WithTx(tx *gorm.DB) domain.LimitHoldRepository {
+	return &limitHoldRepository{db: tx}
+}
+
+func (r *limitHoldRepository) Save(hold *domain.LimitHold) error {
+	return r.db.Create(hold).Error
+}
+
+func (r *limitHoldRepository) Update(hold *domain.LimitHold) error {
+	return r.db.Save(hold).Error
+}
+
+// FindByIDForUpdate mencari penahanan limit dan mengunci barisnya agar tidak dikonfirmasi dan dilepas bersamaan.
+func (r *limitHoldRepository) FindByIDForUpdate(id uint) (*domain.LimitHold, error) {
+	var hold domain.LimitHold
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, id).Error; err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *limitHoldRepository) FindByConsumerID(consumerID uint) ([]*domain.LimitHold, error) {
+	var holds []*domain.LimitHold
+	if err := r.db.Where("consumer_id = ?", consumerID).Order("created_at desc").Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// FindActiveByConsumerID mengembalikan penahanan yang masih mengikat limit pada waktu now. Penahanan yang sudah
+// lewat waktu tidak ikut dihitung walaupun statusnya belum diubah oleh job kedaluwarsa.
+func (r *limitHoldRepository) FindActiveByConsumerID(consumerID uint, now time.Time) ([]*domain.LimitHold, error) {
+	var holds []*domain.LimitHold
+	err := r.db.Where(
+		"consumer_id = ? AND status = ? AND kedaluwarsa_pada > ?",
+		consumerID,
+		domain.StatusHoldAktif,
+		now,
+	).Find(&holds).Error
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// ExpireBefore menandai seluruh penahanan AKTIF yang sudah lewat waktu sebagai KEDALUWARSA.
+func (r *limitHoldRepository) ExpireBefore(now time.Time) (int64, error) {
+	result := r.db.Model(&domain.LimitHold{}).
+		Where("status = ? AND kedaluwarsa_pada <= ?", domain.StatusHoldAktif, now).
+		Updates(map[string]interface{}{"status": domain.StatusHoldKedaluwarsa, "updated_at": now})
+	return result.RowsAffected, result.Error
+}
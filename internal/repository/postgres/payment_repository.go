@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) domain.PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) WithTx(tx *gorm.DB) domain.PaymentRepository {
+	return &paymentRepository{db: tx}
+}
+
+// Save menyimpan pembayaran beserta rincian alokasinya per angsuran.
+func (r *paymentRepository) Save(payment *domain.Payment) error {
+	return r.db.Create(payment).Error
+}
+
+func (r *paymentRepository) FindByTransactionID(transactionID uint) ([]*domain.Payment, error) {
+	var payments []*domain.Payment
+	err := r.db.Where("transaction_id = ?", transactionID).
+		Preload("Allocations").
+		Order("tanggal_bayar asc, id asc").
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type piiDataKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewPIIDataKeyRepository(db *gorm.DB) domain.PIIDataKeyRepository {
+	return &piiDataKeyRepository{db: db}
+}
+
+func (r *piiDataKeyRepository) WithTx(tx *gorm.DB) domain.PIIDataKeyRepository {
+	return &piiDataKeyRepository{db: tx}
+}
+
+func (r *piiDataKeyRepository) FindActive() (*domain.PIIDataKey, error) {
+	var key domain.PIIDataKey
+	err := r.db.Where("aktif = ?", true).Order("id desc").First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *piiDataKeyRepository) FindByID(id uint) (*domain.PIIDataKey, error) {
+	var key domain.PIIDataKey
+	err := r.db.First(&key, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *piiDataKeyRepository) FindAll() ([]*domain.PIIDataKey, error) {
+	var keys []*domain.PIIDataKey
+	err := r.db.Order("id asc").Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ReplaceActive menonaktifkan DEK aktif sebelumnya dan menyimpan key sebagai DEK aktif dalam satu transaksi.
+func (r *piiDataKeyRepository) ReplaceActive(key *domain.PIIDataKey) error {
+	return r.db.Transaction(
+		func(tx *gorm.DB) error {
+			err := tx.Model(&domain.PIIDataKey{}).Where("aktif = ?", true).Update("aktif", false).Error
+			if err != nil {
+				return err
+			}
+			key.Aktif = true
+			return tx.Create(key).Error
+		},
+	)
+}
+
+func (r *piiDataKeyRepository) Update(id uint, updates map[string]interface{}) error {
+	return r.db.Model(&domain.PIIDataKey{}).Where("id = ?", id).Updates(updates).Error
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type productRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) domain.ProductRepository {
+	return &productRepository{db: db}
+}
+
+func (r *productRepository) WithTx(tx *gorm.DB) domain.ProductRepository {
+	return &productRepository{db: tx}
+}
+
+// Save menyimpan produk baru beserta tenornya.
+func (r *productRepository) Save(product *domain.Product) error {
+	return r.db.Create(product).Error
+}
+
+// Update memperbarui data produk tanpa menyentuh tenornya (lihat ReplaceTenors).
+func (r *productRepository) Update(product *domain.Product) error {
+	return r.db.Omit("Tenors").Save(product).Error
+}
+
+// ReplaceTenors mengganti seluruh tenor produk dengan daftar yang baru.
+func (r *productRepository) ReplaceTenors(productID uint, tenors []domain.ProductTenor) error {
+	if err := r.db.Where("product_id = ?", productID).Delete(&domain.ProductTenor{}).Error; err != nil {
+		return err
+	}
+	if len(tenors) == 0 {
+		return nil
+	}
+	for i := range tenors {
+		tenors[i].ID = 0
+		tenors[i].ProductID = productID
+	}
+	return r.db.Create(&tenors).Error
+}
+
+func (r *productRepository) Delete(id uint) error {
+	if err := r.db.Where("product_id = ?", id).Delete(&domain.ProductTenor{}).Error; err != nil {
+		return err
+	}
+	return r.db.Delete(&domain.Product{}, id).Error
+}
+
+func (r *productRepository) FindByID(id uint) (*domain.Product, error) {
+	var product domain.Product
+	err := r.withTenors().First(&product, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) FindByKode(kode string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.withTenors().Where("kode = ?", kode).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) FindAll() ([]*domain.Product, error) {
+	var products []*domain.Product
+	err := r.withTenors().Order("kode asc").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) FindAllActive() ([]*domain.Product, error) {
+	var products []*domain.Product
+	err := r.withTenors().Where("aktif = ?", true).Order("kode asc").Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) withTenors() *gorm.DB {
+	return r.db.Preload(
+		"Tenors", func(db *gorm.DB) *gorm.DB {
+			return db.Order("tenor_bulan asc")
+		},
+	)
+}
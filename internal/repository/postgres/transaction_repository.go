@@ -3,6 +3,7 @@ package postgres
 import (
 	"github.com/adty404/kredit-plus/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type transactionRepository struct {
@@ -29,6 +30,16 @@ func (r *transactionRepository) FindByID(id uint) (*domain.Transaction, error) {
 	return &transaction, nil
 }
 
+// FindByIDForUpdate mencari transaksi berdasarkan ID dan mengunci barisnya menggunakan 'SELECT ... FOR UPDATE'.
+func (r *transactionRepository) FindByIDForUpdate(id uint) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
 func (r *transactionRepository) FindByConsumerID(consumerID uint) ([]*domain.Transaction, error) {
 	var transactions []*domain.Transaction
 	if err := r.db.Where(
@@ -40,15 +51,29 @@ func (r *transactionRepository) FindByConsumerID(consumerID uint) ([]*domain.Tra
 	return transactions, nil
 }
 
+// FindActiveByConsumerID mengembalikan kontrak konsumen yang masih mengikat plafon (lihat domain.StatusKontrakMengikatPlafon).
 func (r *transactionRepository) FindActiveByConsumerID(consumerID uint) ([]*domain.Transaction, error) {
 	var transactions []*domain.Transaction
-	err := r.db.Where("consumer_id = ? AND status_kontrak = ?", consumerID, "AKTIF").Find(&transactions).Error
+	err := r.db.Where(
+		"consumer_id = ? AND status_kontrak IN ?",
+		consumerID,
+		domain.StatusKontrakMengikatPlafon,
+	).Find(&transactions).Error
 	if err != nil {
 		return nil, err
 	}
 	return transactions, nil
 }
 
+// FindByStatuses mengambil seluruh kontrak dengan salah satu status yang diberikan.
+func (r *transactionRepository) FindByStatuses(statuses []string) ([]*domain.Transaction, error) {
+	var transactions []*domain.Transaction
+	if err := r.db.Where("status_kontrak IN ?", statuses).Order("id asc").Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
 func (r *transactionRepository) Update(transaction *domain.Transaction) error {
 	return r.db.Save(transaction).Error
 }
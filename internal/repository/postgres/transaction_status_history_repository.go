@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type transactionStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionStatusHistoryRepository(db *gorm.DB) domain.TransactionStatusHistoryRepository {
+	return &transactionStatusHistoryRepository{db: db}
+}
+
+func (r *transactionStatusHistoryRepository) WithTx(tx *gorm.DB) domain.TransactionStatusHistoryRepository {
+	return &transactionStatusHistoryRepository{db: tx}
+}
+
+func (r *transactionStatusHistoryRepository) Save(history *domain.TransactionStatusHistory) error {
+	return r.db.Create(history).Error
+}
+
+func (r *transactionStatusHistoryRepository) FindByTransactionID(transactionID uint) (
+	[]*domain.TransactionStatusHistory,
+	error,
+) {
+	var histories []*domain.TransactionStatusHistory
+	err := r.db.Where("transaction_id = ?", transactionID).Order("created_at asc, id asc").Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
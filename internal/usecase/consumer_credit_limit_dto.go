@@ -1,6 +1,84 @@
 package usecase
 
+import "github.com/adty404/kredit-plus/internal/domain"
+
 type CreateConsumerCreditLimitInput struct {
-	TenorMonths int     `json:"tenor_months" binding:"required,gt=0"`
-	CreditLimit float64 `json:"credit_limit" binding:"required,gte=0"`
+	TenorMonths int          `json:"tenor_months" binding:"required,gt=0"`
+	CreditLimit domain.Money `json:"credit_limit" binding:"required,gte=0"`
+	Alasan      string       `json:"alasan"`
+}
+
+type UpdateConsumerCreditLimitInput struct {
+	CreditLimit domain.Money `json:"credit_limit" binding:"required,gte=0"`
+	Alasan      string       `json:"alasan" binding:"required,min=5"`
+}
+
+type DeleteConsumerCreditLimitInput struct {
+	Alasan string `json:"alasan" binding:"required,min=5"`
+}
+
+// LimitUsage merangkum pemakaian sebuah limit. Used adalah total pokok awal kontrak aktif, Outstanding adalah
+// sisa pokok yang belum dibayar kembali, dan Held adalah limit yang sedang ditahan untuk checkout.
+// Remaining dihitung dari Outstanding dan Held.
+type LimitUsage struct {
+	CreditLimit domain.Money `json:"credit_limit"`
+	Used        domain.Money `json:"used"`
+	Outstanding domain.Money `json:"outstanding"`
+	Held        domain.Money `json:"held"`
+	Remaining   domain.Money `json:"remaining"`
+}
+
+type TenorLimitUsage struct {
+	ConsumerCreditLimitID uint `json:"consumer_credit_limit_id"`
+	TenorMonths           int  `json:"tenor_months"`
+	LimitUsage
+}
+
+type LimitAvailabilityOutput struct {
+	ConsumerID uint              `json:"consumer_id"`
+	Overall    LimitUsage        `json:"overall"`
+	Tenors     []TenorLimitUsage `json:"tenors"`
+}
+
+// ScoringReason menjelaskan satu langkah penilaian yang memengaruhi rekomendasi limit.
+type ScoringReason struct {
+	Kode       string `json:"kode"`
+	Keterangan string `json:"keterangan"`
+}
+
+type RecommendedTenorLimit struct {
+	TenorMonths int          `json:"tenor_months"`
+	CreditLimit domain.Money `json:"credit_limit"`
+}
+
+// CreditLimitRecommendation adalah hasil scoring limit kredit. Jika Eligible bernilai false, alasan terakhir
+// pada Reasons menjelaskan aturan yang menolak konsumen.
+type CreditLimitRecommendation struct {
+	ConsumerID         uint                    `json:"consumer_id"`
+	VersiAturan        string                  `json:"versi_aturan"`
+	Eligible           bool                    `json:"eligible"`
+	Usia               int                     `json:"usia"`
+	KelompokUsia       string                  `json:"kelompok_usia"`
+	Gaji               domain.Money            `json:"gaji"`
+	KewajibanBulanan   domain.Money            `json:"kewajiban_bulanan"`
+	RasioDTI           float64                 `json:"rasio_dti"`
+	KapasitasAngsuran  domain.Money            `json:"kapasitas_angsuran"`
+	OverallCreditLimit domain.Money            `json:"overall_credit_limit"`
+	Limits             []RecommendedTenorLimit `json:"limits"`
+	Reasons            []ScoringReason         `json:"reasons"`
+}
+
+// ApplyCreditLimitRecommendationInput menerapkan rekomendasi scoring. Nilai yang diisi admin menggantikan
+// nilai rekomendasi (override), sedangkan yang kosong mengikuti rekomendasi.
+type ApplyCreditLimitRecommendationInput struct {
+	OverallCreditLimit *domain.Money           `json:"overall_credit_limit" binding:"omitempty,gte=0"`
+	Limits             []RecommendedTenorLimit `json:"limits" binding:"omitempty,dive"`
+	Alasan             string                  `json:"alasan"`
+}
+
+// ApplyCreditLimitRecommendationOutput adalah plafon dan limit per tenor setelah rekomendasi diterapkan.
+type ApplyCreditLimitRecommendationOutput struct {
+	Recommendation     *CreditLimitRecommendation    `json:"recommendation"`
+	OverallCreditLimit domain.Money                  `json:"overall_credit_limit"`
+	Limits             []*domain.ConsumerCreditLimit `json:"limits"`
 }
@@ -1,72 +1,616 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type ConsumerCreditLimitUsecase interface {
 	CreateConsumerCreditLimit(
-		consumerID uint,
+		consumerID, changedBy uint,
 		input CreateConsumerCreditLimitInput,
 	) (*domain.ConsumerCreditLimit, error)
+	GetConsumerCreditLimits(consumerID uint) ([]*domain.ConsumerCreditLimit, error)
+	UpdateConsumerCreditLimit(
+		consumerID uint,
+		tenorMonths int,
+		changedBy uint,
+		input UpdateConsumerCreditLimitInput,
+	) (*domain.ConsumerCreditLimit, error)
+	DeleteConsumerCreditLimit(consumerID uint, tenorMonths int, changedBy uint, input DeleteConsumerCreditLimitInput) error
+	GetCreditLimitHistory(consumerID uint) ([]*domain.CreditLimitHistory, error)
+	GetLimitAvailability(consumerID uint) (*LimitAvailabilityOutput, error)
+	RecommendCreditLimit(consumerID uint) (*CreditLimitRecommendation, error)
+	ApplyCreditLimitRecommendation(
+		consumerID, changedBy uint,
+		input ApplyCreditLimitRecommendationInput,
+	) (*ApplyCreditLimitRecommendationOutput, error)
 }
 
 type consumerCreditLimitUsecase struct {
-	repo         domain.ConsumerCreditLimitRepository
-	consumerRepo domain.ConsumerRepository
+	db              *gorm.DB
+	repo            domain.ConsumerCreditLimitRepository
+	consumerRepo    domain.ConsumerRepository
+	transactionRepo domain.TransactionRepository
+	historyRepo     domain.CreditLimitHistoryRepository
+	holdRepo        domain.LimitHoldRepository
+	products        ProductResolver
+	scoringRules    ScoringRules
+	reviewPolicy    LimitReviewPolicy
 }
 
 func NewConsumerCreditLimitUsecase(
+	db *gorm.DB,
 	repo domain.ConsumerCreditLimitRepository,
 	consumerRepo domain.ConsumerRepository,
+	transactionRepo domain.TransactionRepository,
+	historyRepo domain.CreditLimitHistoryRepository,
+	holdRepo domain.LimitHoldRepository,
+	products ProductResolver,
+	scoringRules ScoringRules,
+	reviewPolicy LimitReviewPolicy,
 ) ConsumerCreditLimitUsecase {
 	return &consumerCreditLimitUsecase{
-		repo:         repo,
-		consumerRepo: consumerRepo,
+		db:              db,
+		repo:            repo,
+		consumerRepo:    consumerRepo,
+		transactionRepo: transactionRepo,
+		historyRepo:     historyRepo,
+		holdRepo:        holdRepo,
+		products:        products,
+		scoringRules:    scoringRules,
+		reviewPolicy:    reviewPolicy,
 	}
 }
 
 func (uc *consumerCreditLimitUsecase) CreateConsumerCreditLimit(
-	consumerID uint,
+	consumerID, changedBy uint,
 	input CreateConsumerCreditLimitInput,
 ) (*domain.ConsumerCreditLimit, error) {
-	// Validasi 1: Pastikan konsumen ada
+	var newLimit *domain.ConsumerCreditLimit
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			repoTx := uc.repo.WithTx(tx)
+
+			// Validasi 1: Pastikan konsumen ada dan KUNCI barisnya agar tidak bersamaan dengan pembuatan transaksi
+			consumer, err := uc.consumerRepo.WithTx(tx).FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+			// Limit hanya boleh diberikan kepada konsumen yang identitasnya sudah diverifikasi
+			if err := checkKYCApproved(consumer); err != nil {
+				return err
+			}
+
+			// Validasi 2: Pastikan limit untuk tenor ini belum ada
+			_, err = repoTx.FindByConsumerAndTenor(consumerID, input.TenorMonths)
+			if err == nil {
+				return fmt.Errorf("credit limit for tenor %d months already exists for this consumer", input.TenorMonths)
+			}
+
+			// Validasi 3: Tenor Months harus ditawarkan oleh minimal satu produk aktif
+			if err := uc.validateOfferedTenor(input.TenorMonths); err != nil {
+				return err
+			}
+
+			// Validasi 4: Pastikan limit per tenor tidak melebihi plafon kredit keseluruhan
+			if err := validateAgainstOverallLimit(consumer, input.CreditLimit); err != nil {
+				return err
+			}
+
+			limit := &domain.ConsumerCreditLimit{
+				ConsumerID:    consumerID,
+				TenorMonths:   input.TenorMonths,
+				CreditLimit:   input.CreditLimit,
+				BerlakuSampai: uc.reviewPolicy.BerlakuSampai(time.Now()),
+			}
+			if err := repoTx.Save(limit); err != nil {
+				return err
+			}
+
+			alasan := input.Alasan
+			if alasan == "" {
+				alasan = "Penetapan limit awal"
+			}
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditLimitHistory{
+					ConsumerID:            consumerID,
+					ConsumerCreditLimitID: limit.ID,
+					TenorMonths:           limit.TenorMonths,
+					Aksi:                  domain.CreditLimitAksiCreate,
+					LimitSesudah:          limit.CreditLimit,
+					Alasan:                alasan,
+					ChangedBy:             changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			newLimit = limit
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newLimit, nil
+}
+
+func (uc *consumerCreditLimitUsecase) GetConsumerCreditLimits(consumerID uint) ([]*domain.ConsumerCreditLimit, error) {
+	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	return uc.repo.FindByConsumerID(consumerID)
+}
+
+// UpdateConsumerCreditLimit mengubah limit sebuah tenor dan memperpanjang masa berlakunya. Penurunan limit
+// ditolak jika nilainya lebih kecil dari sisa pokok kontrak berjalan yang memakai limit tersebut.
+func (uc *consumerCreditLimitUsecase) UpdateConsumerCreditLimit(
+	consumerID uint,
+	tenorMonths int,
+	changedBy uint,
+	input UpdateConsumerCreditLimitInput,
+) (*domain.ConsumerCreditLimit, error) {
+	var updatedLimit *domain.ConsumerCreditLimit
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			consumer, limit, usage, err := uc.lockLimit(tx, consumerID, tenorMonths)
+			if err != nil {
+				return err
+			}
+
+			if err := validateAgainstOverallLimit(consumer, input.CreditLimit); err != nil {
+				return err
+			}
+			if input.CreditLimit.LessThan(usage.Outstanding) {
+				return fmt.Errorf(
+					"credit limit (%s) cannot be lower than outstanding usage (%s) for tenor %d months",
+					input.CreditLimit,
+					usage.Outstanding,
+					tenorMonths,
+				)
+			}
+
+			berlakuSampai := uc.reviewPolicy.BerlakuSampai(time.Now())
+			err = uc.repo.WithTx(tx).Update(
+				limit.ID, map[string]interface{}{
+					"credit_limit":   input.CreditLimit,
+					"berlaku_sampai": berlakuSampai,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditLimitHistory{
+					ConsumerID:            consumerID,
+					ConsumerCreditLimitID: limit.ID,
+					TenorMonths:           tenorMonths,
+					Aksi:                  domain.CreditLimitAksiUpdate,
+					LimitSebelum:          limit.CreditLimit,
+					LimitSesudah:          input.CreditLimit,
+					Alasan:                input.Alasan,
+					ChangedBy:             changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			limit.CreditLimit = input.CreditLimit
+			limit.BerlakuSampai = berlakuSampai
+			updatedLimit = limit
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedLimit, nil
+}
+
+// DeleteConsumerCreditLimit menghapus limit sebuah tenor yang tidak lagi dipakai kontrak berjalan.
+func (uc *consumerCreditLimitUsecase) DeleteConsumerCreditLimit(
+	consumerID uint,
+	tenorMonths int,
+	changedBy uint,
+	input DeleteConsumerCreditLimitInput,
+) error {
+	return uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			_, limit, usage, err := uc.lockLimit(tx, consumerID, tenorMonths)
+			if err != nil {
+				return err
+			}
+
+			if usage.Outstanding.IsPositive() {
+				return fmt.Errorf(
+					"cannot delete credit limit for tenor %d months with outstanding usage (%s)",
+					tenorMonths,
+					usage.Outstanding,
+				)
+			}
+
+			if err := uc.repo.WithTx(tx).Delete(limit.ID); err != nil {
+				return err
+			}
+
+			return uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditLimitHistory{
+					ConsumerID:            consumerID,
+					ConsumerCreditLimitID: limit.ID,
+					TenorMonths:           tenorMonths,
+					Aksi:                  domain.CreditLimitAksiDelete,
+					LimitSebelum:          limit.CreditLimit,
+					Alasan:                input.Alasan,
+					ChangedBy:             changedBy,
+				},
+			)
+		},
+	)
+}
+
+func (uc *consumerCreditLimitUsecase) GetCreditLimitHistory(consumerID uint) ([]*domain.CreditLimitHistory, error) {
+	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	return uc.historyRepo.FindByConsumerID(consumerID)
+}
+
+// GetLimitAvailability menghitung pemakaian, penahanan, dan sisa plafon konsumen secara keseluruhan dan per tenor.
+func (uc *consumerCreditLimitUsecase) GetLimitAvailability(consumerID uint) (*LimitAvailabilityOutput, error) {
 	consumer, err := uc.consumerRepo.FindByID(consumerID)
 	if err != nil {
 		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
 	}
 
-	// Validasi 2: Pastikan limit untuk tenor ini belum ada
-	_, err = uc.repo.FindByConsumerAndTenor(consumerID, input.TenorMonths)
-	if err == nil {
-		return nil, fmt.Errorf("credit limit for tenor %d months already exists for this consumer", input.TenorMonths)
+	limits, err := uc.repo.FindByConsumerID(consumerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeTransactions, err := uc.transactionRepo.FindActiveByConsumerID(consumerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeHolds, err := uc.holdRepo.FindActiveByConsumerID(consumerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return computeLimitAvailability(consumer, limits, activeTransactions, activeHolds), nil
+}
+
+// RecommendCreditLimit menghitung rekomendasi plafon dan limit per tenor dari gaji, usia, kewajiban berjalan,
+// dan riwayat pembayaran konsumen tanpa mengubah limit yang ada.
+func (uc *consumerCreditLimitUsecase) RecommendCreditLimit(consumerID uint) (*CreditLimitRecommendation, error) {
+	consumer, err := uc.consumerRepo.FindByID(consumerID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("consumer with id %d not found: %w", consumerID, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.recommend(uc.transactionRepo, consumer)
+}
+
+// ApplyCreditLimitRecommendation menerapkan rekomendasi scoring ke plafon keseluruhan dan limit setiap tenor
+// yang ditawarkan, sekaligus memperpanjang masa berlakunya. Admin dapat mengganti (override) plafon maupun limit
+// tenor tertentu; setiap perubahan limit tenor dicatat ke riwayat limit beserta versi aturan scoring yang dipakai.
+func (uc *consumerCreditLimitUsecase) ApplyCreditLimitRecommendation(
+	consumerID, changedBy uint,
+	input ApplyCreditLimitRecommendationInput,
+) (*ApplyCreditLimitRecommendationOutput, error) {
+	var output *ApplyCreditLimitRecommendationOutput
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			// 1. Kunci baris konsumen agar tidak bersamaan dengan pembuatan kontrak baru
+			consumer, err := uc.consumerRepo.WithTx(tx).FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+
+			// 2. Hitung ulang rekomendasi di dalam transaksi dengan data yang sudah terkunci
+			recommendation, err := uc.recommend(uc.transactionRepo.WithTx(tx), consumer)
+			if err != nil {
+				return err
+			}
+			if !recommendation.Eligible && input.OverallCreditLimit == nil {
+				reason := recommendation.Reasons[len(recommendation.Reasons)-1]
+				return fmt.Errorf("consumer is not eligible for a recommended credit limit: %s", reason.Keterangan)
+			}
+
+			// 3. Terapkan override admin di atas nilai rekomendasi
+			targets := newLimitTargets(recommendation)
+			if input.OverallCreditLimit != nil {
+				targets.overall = *input.OverallCreditLimit
+			}
+			overridden := make(map[int]bool, len(input.Limits))
+			for _, override := range input.Limits {
+				if _, ok := targets.limits[override.TenorMonths]; !ok {
+					if err := uc.validateOfferedTenor(override.TenorMonths); err != nil {
+						return err
+					}
+					targets.tenors = append(targets.tenors, override.TenorMonths)
+				}
+				if override.CreditLimit.IsNegative() {
+					return fmt.Errorf("credit limit for tenor %d must not be negative", override.TenorMonths)
+				}
+				targets.limits[override.TenorMonths] = override.CreditLimit
+				overridden[override.TenorMonths] = true
+			}
+			targets.alasan = func(tenor int) string {
+				alasan := input.Alasan
+				if alasan == "" {
+					alasan = fmt.Sprintf("Rekomendasi scoring versi %s", recommendation.VersiAturan)
+				}
+				if overridden[tenor] {
+					alasan += " (override admin)"
+				}
+				return alasan
+			}
+
+			// 4. Terapkan plafon dan limit setiap tenor serta catat riwayatnya
+			limits, err := uc.applyLimitTargets(tx, consumer, changedBy, targets)
+			if err != nil {
+				return err
+			}
+
+			output = &ApplyCreditLimitRecommendationOutput{
+				Recommendation:     recommendation,
+				OverallCreditLimit: targets.overall,
+				Limits:             limits,
+			}
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
 	}
 
-	// Validasi 3: Tenor Months harus dalam 1, 2, 3, atau 6 bulan
-	allowedTenors := map[int]bool{1: true, 2: true, 3: true, 6: true}
-	if !allowedTenors[input.TenorMonths] {
-		return nil, fmt.Errorf("invalid tenor: %d. allowed tenors are 1, 2, 3, 6", input.TenorMonths)
+	return output, nil
+}
+
+// recommend mengumpulkan data scoring konsumen lalu menjalankan scoreCreditLimit.
+func (uc *consumerCreditLimitUsecase) recommend(
+	transactionRepo domain.TransactionRepository,
+	consumer *domain.Consumer,
+) (*CreditLimitRecommendation, error) {
+	transactions, err := transactionRepo.FindByConsumerID(consumer.ID)
+	if err != nil {
+		return nil, err
+	}
+	offeredTenors, err := uc.products.OfferedTenors()
+	if err != nil {
+		return nil, err
+	}
+
+	return scoreCreditLimit(
+		uc.scoringRules, scoringInput{
+			consumer:      consumer,
+			transactions:  transactions,
+			offeredTenors: offeredTenors,
+			asOf:          time.Now(),
+		},
+	)
+}
+
+// limitTargets adalah plafon dan limit per tenor yang akan diterapkan ke konsumen. tenors menentukan urutan
+// penerapan dan alasan menghasilkan alasan perubahan yang dicatat ke riwayat limit setiap tenor.
+type limitTargets struct {
+	overall domain.Money
+	limits  map[int]domain.Money
+	tenors  []int
+	alasan  func(tenor int) string
+}
+
+// newLimitTargets menyiapkan target penerapan dari rekomendasi scoring.
+func newLimitTargets(recommendation *CreditLimitRecommendation) *limitTargets {
+	targets := &limitTargets{
+		overall: recommendation.OverallCreditLimit,
+		limits:  make(map[int]domain.Money, len(recommendation.Limits)),
+		tenors:  make([]int, 0, len(recommendation.Limits)),
+	}
+	for _, limit := range recommendation.Limits {
+		targets.limits[limit.TenorMonths] = limit.CreditLimit
+		targets.tenors = append(targets.tenors, limit.TenorMonths)
+	}
+	return targets
+}
+
+// applyLimitTargets menerapkan plafon dan limit per tenor untuk konsumen yang barisnya sudah dikunci di dalam tx.
+// KYC konsumen harus sudah disetujui, dan plafon serta limit tidak boleh lebih kecil dari sisa pokok kontrak
+// berjalan. Masa berlaku plafon dan setiap limit yang diterapkan diperpanjang, sedangkan riwayat limit hanya
+// dicatat untuk limit yang nilainya berubah.
+func (uc *consumerCreditLimitUsecase) applyLimitTargets(
+	tx *gorm.DB,
+	consumer *domain.Consumer,
+	changedBy uint,
+	targets *limitTargets,
+) ([]*domain.ConsumerCreditLimit, error) {
+	repoTx := uc.repo.WithTx(tx)
+	historyRepoTx := uc.historyRepo.WithTx(tx)
+
+	if err := checkKYCApproved(consumer); err != nil {
+		return nil, err
 	}
 
-	// Validasi 4: Pastikan limit per tenor tidak melebihi plafon kredit keseluruhan
-	if input.CreditLimit > consumer.OverallCreditLimit {
+	// 1. Plafon baru tidak boleh lebih kecil dari sisa pokok kontrak berjalan
+	limits, err := repoTx.FindByConsumerID(consumer.ID)
+	if err != nil {
+		return nil, err
+	}
+	activeTransactions, err := uc.transactionRepo.WithTx(tx).FindActiveByConsumerID(consumer.ID)
+	if err != nil {
+		return nil, err
+	}
+	availability := computeLimitAvailability(consumer, limits, activeTransactions, nil)
+	if targets.overall.LessThan(availability.Overall.Outstanding) {
 		return nil, fmt.Errorf(
-			"credit limit (%.2f) cannot exceed consumer's overall credit limit (%.2f)",
-			input.CreditLimit,
-			consumer.OverallCreditLimit,
+			"overall credit limit (%s) cannot be lower than outstanding usage (%s)",
+			targets.overall,
+			availability.Overall.Outstanding,
 		)
 	}
 
-	limit := &domain.ConsumerCreditLimit{
-		ConsumerID:  consumerID,
-		TenorMonths: input.TenorMonths,
-		CreditLimit: input.CreditLimit,
+	berlakuSampai := uc.reviewPolicy.BerlakuSampai(time.Now())
+	err = uc.consumerRepo.WithTx(tx).Update(
+		consumer.ID, map[string]interface{}{
+			"overall_credit_limit":  targets.overall,
+			"plafon_berlaku_sampai": berlakuSampai,
+		},
+	)
+	if err != nil {
+		return nil, err
 	}
+	consumer.OverallCreditLimit = targets.overall
+	consumer.PlafonBerlakuSampai = berlakuSampai
 
-	if err := uc.repo.Save(limit); err != nil {
-		return nil, err
+	// 2. Buat atau ubah limit setiap tenor dan catat riwayatnya
+	existing := make(map[int]*domain.ConsumerCreditLimit, len(limits))
+	for _, limit := range limits {
+		existing[limit.TenorMonths] = limit
+	}
+	for _, tenor := range targets.tenors {
+		target := targets.limits[tenor]
+		if err := validateAgainstOverallLimit(consumer, target); err != nil {
+			return nil, err
+		}
+
+		history := &domain.CreditLimitHistory{
+			ConsumerID:   consumer.ID,
+			TenorMonths:  tenor,
+			LimitSesudah: target,
+			Alasan:       targets.alasan(tenor),
+			ChangedBy:    changedBy,
+		}
+		if limit, ok := existing[tenor]; ok {
+			if usage := availability.Tenor(tenor); target.LessThan(usage.Outstanding) {
+				return nil, fmt.Errorf(
+					"credit limit (%s) cannot be lower than outstanding usage (%s) for tenor %d months",
+					target,
+					usage.Outstanding,
+					tenor,
+				)
+			}
+			updates := map[string]interface{}{"berlaku_sampai": berlakuSampai}
+			unchanged := limit.CreditLimit.Cmp(target) == 0
+			if !unchanged {
+				updates["credit_limit"] = target
+			}
+			if err := repoTx.Update(limit.ID, updates); err != nil {
+				return nil, err
+			}
+			limit.BerlakuSampai = berlakuSampai
+			if unchanged {
+				continue
+			}
+			history.ConsumerCreditLimitID = limit.ID
+			history.Aksi = domain.CreditLimitAksiUpdate
+			history.LimitSebelum = limit.CreditLimit
+			limit.CreditLimit = target
+		} else {
+			limit = &domain.ConsumerCreditLimit{
+				ConsumerID:    consumer.ID,
+				TenorMonths:   tenor,
+				CreditLimit:   target,
+				BerlakuSampai: berlakuSampai,
+			}
+			if err := repoTx.Save(limit); err != nil {
+				return nil, err
+			}
+			existing[tenor] = limit
+			limits = append(limits, limit)
+			history.ConsumerCreditLimitID = limit.ID
+			history.Aksi = domain.CreditLimitAksiCreate
+		}
+		if err := historyRepoTx.Save(history); err != nil {
+			return nil, err
+		}
 	}
 
-	return limit, nil
+	return limits, nil
+}
+
+// lockLimit mengunci baris konsumen (jalur kunci yang sama dengan CreateTransaction) lalu mengambil limit
+// tenor beserta pemakaiannya, sehingga perubahan limit tidak bersamaan dengan pembuatan kontrak baru.
+func (uc *consumerCreditLimitUsecase) lockLimit(tx *gorm.DB, consumerID uint, tenorMonths int) (
+	*domain.Consumer,
+	*domain.ConsumerCreditLimit,
+	*TenorLimitUsage,
+	error,
+) {
+	consumer, err := uc.consumerRepo.WithTx(tx).FindByIDForUpdate(consumerID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+
+	limits, err := uc.repo.WithTx(tx).FindByConsumerID(consumerID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var limit *domain.ConsumerCreditLimit
+	for _, candidate := range limits {
+		if candidate.TenorMonths == tenorMonths {
+			limit = candidate
+			break
+		}
+	}
+	if limit == nil {
+		return nil, nil, nil, fmt.Errorf("credit limit for tenor %d not found for this consumer", tenorMonths)
+	}
+
+	activeTransactions, err := uc.transactionRepo.WithTx(tx).FindActiveByConsumerID(consumerID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	usage := computeLimitAvailability(consumer, limits, activeTransactions, nil).Tenor(tenorMonths)
+
+	return consumer, limit, usage, nil
+}
+
+// validateAgainstOverallLimit memastikan limit per tenor tidak melebihi plafon kredit keseluruhan konsumen.
+func validateAgainstOverallLimit(consumer *domain.Consumer, creditLimit domain.Money) error {
+	if creditLimit.GreaterThan(consumer.OverallCreditLimit) {
+		return fmt.Errorf(
+			"credit limit (%s) cannot exceed consumer's overall credit limit (%s)",
+			creditLimit,
+			consumer.OverallCreditLimit,
+		)
+	}
+	return nil
+}
+
+// validateOfferedTenor memastikan tenor ditawarkan oleh katalog produk.
+func (uc *consumerCreditLimitUsecase) validateOfferedTenor(tenorMonths int) error {
+	tenors, err := uc.products.OfferedTenors()
+	if err != nil {
+		return err
+	}
+
+	labels := make([]string, 0, len(tenors))
+	for _, tenor := range tenors {
+		if tenor == tenorMonths {
+			return nil
+		}
+		labels = append(labels, strconv.Itoa(tenor))
+	}
+	return fmt.Errorf("invalid tenor: %d. allowed tenors are %s", tenorMonths, strings.Join(labels, ", "))
 }
@@ -3,38 +3,88 @@ package usecase
 import (
 	"errors"
 	"fmt"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/adty404/kredit-plus/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
+type creditLimitTestDeps struct {
+	mockSQL             sqlmock.Sqlmock
+	mockConsumerRepo    *MockConsumerRepository
+	mockLimitRepo       *MockCreditLimitRepository
+	mockTransactionRepo *MockTransactionRepository
+	mockHistoryRepo     *MockCreditLimitHistoryRepository
+	mockHoldRepo        *MockLimitHoldRepository
+}
+
+func setupCreditLimitUsecase(t *testing.T) (ConsumerCreditLimitUsecase, creditLimitTestDeps) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(
+		postgres.New(
+			postgres.Config{
+				Conn: sqlDB,
+			},
+		), &gorm.Config{},
+	)
+	assert.NoError(t, err)
+
+	deps := creditLimitTestDeps{
+		mockSQL:             mockSQL,
+		mockConsumerRepo:    new(MockConsumerRepository),
+		mockLimitRepo:       new(MockCreditLimitRepository),
+		mockTransactionRepo: new(MockTransactionRepository),
+		mockHistoryRepo:     new(MockCreditLimitHistoryRepository),
+		mockHoldRepo:        new(MockLimitHoldRepository),
+	}
+	usecase := NewConsumerCreditLimitUsecase(
+		gormDB,
+		deps.mockLimitRepo,
+		deps.mockConsumerRepo,
+		deps.mockTransactionRepo,
+		deps.mockHistoryRepo,
+		deps.mockHoldRepo,
+		DefaultTenorPricing,
+		DefaultScoringRules,
+		DefaultLimitReviewPolicy,
+	)
+	return usecase, deps
+}
+
 func TestCreateConsumerCreditLimit_Success(t *testing.T) {
 	// Arrange
-	mockConsumerRepo := new(MockConsumerRepository)
-	mockLimitRepo := new(MockCreditLimitRepository)
-	usecase := NewConsumerCreditLimitUsecase(mockLimitRepo, mockConsumerRepo)
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo := deps.mockConsumerRepo, deps.mockLimitRepo
 
 	consumerID := uint(1)
 	input := CreateConsumerCreditLimitInput{
 		TenorMonths: 6,
-		CreditLimit: 10000000,
+		CreditLimit: domain.NewMoney(10000000),
 	}
 
 	// Mock data konsumen yang ada
 	existingConsumer := &domain.Consumer{
 		ID:                 consumerID,
-		OverallCreditLimit: 15000000,
+		OverallCreditLimit: domain.NewMoney(15000000),
+		KYCVerification:    kycApproved,
 	}
 
 	// Tentukan ekspektasi
-	mockConsumerRepo.On("FindByID", consumerID).Return(existingConsumer, nil).Once()
+	deps.mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(existingConsumer, nil).Once()
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(nil, gorm.ErrRecordNotFound).Once()
 	mockLimitRepo.On("Save", mock.AnythingOfType("*domain.ConsumerCreditLimit")).Return(nil).Once()
+	deps.mockHistoryRepo.On("Save", mock.AnythingOfType("*domain.CreditLimitHistory")).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
 
 	// Act
-	limit, err := usecase.CreateConsumerCreditLimit(consumerID, input)
+	limit, err := usecase.CreateConsumerCreditLimit(consumerID, 99, input)
 
 	// Assert
 	assert.NoError(t, err)
@@ -42,51 +92,61 @@ func TestCreateConsumerCreditLimit_Success(t *testing.T) {
 	assert.Equal(t, input.CreditLimit, limit.CreditLimit)
 	assert.Equal(t, input.TenorMonths, limit.TenorMonths)
 	assert.Equal(t, consumerID, limit.ConsumerID)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 }
 
 func TestCreateConsumerCreditLimit_ConsumerNotFound(t *testing.T) {
 	// Arrange
-	mockConsumerRepo := new(MockConsumerRepository)
-	mockLimitRepo := new(MockCreditLimitRepository)
-	usecase := NewConsumerCreditLimitUsecase(mockLimitRepo, mockConsumerRepo)
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo := deps.mockConsumerRepo, deps.mockLimitRepo
 
 	consumerID := uint(99) // ID yang tidak ada
-	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: 10000000}
+	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: domain.NewMoney(10000000)}
 
 	// Tentukan ekspektasi
-	mockConsumerRepo.On("FindByID", consumerID).Return(nil, gorm.ErrRecordNotFound).Once()
+	deps.mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(nil, gorm.ErrRecordNotFound).Once()
+
+	deps.mockSQL.ExpectRollback()
 
 	// Act
-	limit, err := usecase.CreateConsumerCreditLimit(consumerID, input)
+	limit, err := usecase.CreateConsumerCreditLimit(consumerID, 99, input)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, limit)
 	assert.Equal(t, fmt.Errorf("consumer with id %d not found", consumerID), err)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertNotCalled(t, "FindByConsumerAndTenor")
 }
 
 func TestCreateConsumerCreditLimit_LimitAlreadyExists(t *testing.T) {
 	// Arrange
-	mockConsumerRepo := new(MockConsumerRepository)
-	mockLimitRepo := new(MockCreditLimitRepository)
-	usecase := NewConsumerCreditLimitUsecase(mockLimitRepo, mockConsumerRepo)
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo := deps.mockConsumerRepo, deps.mockLimitRepo
 
 	consumerID := uint(1)
-	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: 10000000}
+	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: domain.NewMoney(10000000)}
 
-	existingConsumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 15000000}
+	existingConsumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(15000000),
+		KYCVerification:    kycApproved,
+	}
 	existingLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, TenorMonths: input.TenorMonths}
 
 	// Tentukan ekspektasi
-	mockConsumerRepo.On("FindByID", consumerID).Return(existingConsumer, nil).Once()
+	deps.mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(existingConsumer, nil).Once()
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(existingLimit, nil).Once()
 
+	deps.mockSQL.ExpectRollback()
+
 	// Act
-	limit, err := usecase.CreateConsumerCreditLimit(consumerID, input)
+	limit, err := usecase.CreateConsumerCreditLimit(consumerID, 99, input)
 
 	// Assert
 	assert.Error(t, err)
@@ -96,53 +156,67 @@ func TestCreateConsumerCreditLimit_LimitAlreadyExists(t *testing.T) {
 		fmt.Errorf("credit limit for tenor %d months already exists for this consumer", input.TenorMonths),
 		err,
 	)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 }
 
 func TestCreateConsumerCreditLimit_InvalidTenor(t *testing.T) {
 	// Arrange
-	mockConsumerRepo := new(MockConsumerRepository)
-	mockLimitRepo := new(MockCreditLimitRepository)
-	usecase := NewConsumerCreditLimitUsecase(mockLimitRepo, mockConsumerRepo)
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo := deps.mockConsumerRepo, deps.mockLimitRepo
 
 	consumerID := uint(1)
-	input := CreateConsumerCreditLimitInput{TenorMonths: 5, CreditLimit: 10000000} // Tenor 5 tidak valid
+	input := CreateConsumerCreditLimitInput{TenorMonths: 5, CreditLimit: domain.NewMoney(10000000)} // Tenor 5 tidak valid
 
-	existingConsumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 15000000}
+	existingConsumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(15000000),
+		KYCVerification:    kycApproved,
+	}
 
 	// Tentukan ekspektasi
-	mockConsumerRepo.On("FindByID", consumerID).Return(existingConsumer, nil).Once()
+	deps.mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(existingConsumer, nil).Once()
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(nil, gorm.ErrRecordNotFound).Once()
 
+	deps.mockSQL.ExpectRollback()
+
 	// Act
-	limit, err := usecase.CreateConsumerCreditLimit(consumerID, input)
+	limit, err := usecase.CreateConsumerCreditLimit(consumerID, 99, input)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, limit)
 	assert.Equal(t, fmt.Errorf("invalid tenor: %d. allowed tenors are 1, 2, 3, 6", input.TenorMonths), err)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 }
 
 func TestCreateConsumerCreditLimit_ExceedsOverallLimit(t *testing.T) {
 	// Arrange
-	mockConsumerRepo := new(MockConsumerRepository)
-	mockLimitRepo := new(MockCreditLimitRepository)
-	usecase := NewConsumerCreditLimitUsecase(mockLimitRepo, mockConsumerRepo)
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo := deps.mockConsumerRepo, deps.mockLimitRepo
 
 	consumerID := uint(1)
-	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: 20000000} // Melebihi overall limit
+	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: domain.NewMoney(20000000)} // Melebihi overall limit
 
-	existingConsumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 15000000}
+	existingConsumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(15000000),
+		KYCVerification:    kycApproved,
+	}
 
 	// Tentukan ekspektasi
-	mockConsumerRepo.On("FindByID", consumerID).Return(existingConsumer, nil).Once()
+	deps.mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(existingConsumer, nil).Once()
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(nil, gorm.ErrRecordNotFound).Once()
 
+	deps.mockSQL.ExpectRollback()
+
 	// Act
-	limit, err := usecase.CreateConsumerCreditLimit(consumerID, input)
+	limit, err := usecase.CreateConsumerCreditLimit(consumerID, 99, input)
 
 	// Assert
 	assert.Error(t, err)
@@ -150,40 +224,430 @@ func TestCreateConsumerCreditLimit_ExceedsOverallLimit(t *testing.T) {
 	assert.Equal(
 		t,
 		fmt.Errorf(
-			"credit limit (%.2f) cannot exceed consumer's overall credit limit (%.2f)",
+			"credit limit (%s) cannot exceed consumer's overall credit limit (%s)",
 			input.CreditLimit,
 			existingConsumer.OverallCreditLimit,
 		),
 		err,
 	)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 }
 
 func TestCreateConsumerCreditLimit_SaveError(t *testing.T) {
 	// Arrange
-	mockConsumerRepo := new(MockConsumerRepository)
-	mockLimitRepo := new(MockCreditLimitRepository)
-	usecase := NewConsumerCreditLimitUsecase(mockLimitRepo, mockConsumerRepo)
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo := deps.mockConsumerRepo, deps.mockLimitRepo
 
 	consumerID := uint(1)
-	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: 10000000}
+	input := CreateConsumerCreditLimitInput{TenorMonths: 6, CreditLimit: domain.NewMoney(10000000)}
 	dbError := errors.New("database save error")
 
-	existingConsumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 15000000}
+	existingConsumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(15000000),
+		KYCVerification:    kycApproved,
+	}
 
 	// Tentukan ekspektasi
-	mockConsumerRepo.On("FindByID", consumerID).Return(existingConsumer, nil).Once()
+	deps.mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(existingConsumer, nil).Once()
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(nil, gorm.ErrRecordNotFound).Once()
 	mockLimitRepo.On("Save", mock.AnythingOfType("*domain.ConsumerCreditLimit")).Return(dbError).Once()
 
+	deps.mockSQL.ExpectRollback()
+
 	// Act
-	limit, err := usecase.CreateConsumerCreditLimit(consumerID, input)
+	limit, err := usecase.CreateConsumerCreditLimit(consumerID, 99, input)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, limit)
 	assert.Equal(t, dbError, err)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 }
+
+func TestGetLimitAvailability_ReleasesRepaidPrincipal(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	mockConsumerRepo, mockLimitRepo, mockTransactionRepo := deps.mockConsumerRepo, deps.mockLimitRepo, deps.mockTransactionRepo
+
+	consumerID := uint(1)
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	limits := []*domain.ConsumerCreditLimit{
+		{ID: 10, ConsumerID: consumerID, TenorMonths: 3, CreditLimit: domain.NewMoney(4000000)},
+		{ID: 11, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(8000000)},
+	}
+	activeTransactions := []*domain.Transaction{
+		{ConsumerCreditLimitID: 11, PokokPembiayaanAwal: domain.NewMoney(6000000), PokokTerbayar: domain.NewMoney(5000000)},
+		{ConsumerCreditLimitID: 10, PokokPembiayaanAwal: domain.NewMoney(1000000)},
+	}
+
+	mockConsumerRepo.On("FindByID", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerID", consumerID).Return(limits, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return(activeTransactions, nil).Once()
+	deps.mockHoldRepo.On("FindActiveByConsumerID", consumerID, mock.AnythingOfType("time.Time")).
+		Return([]*domain.LimitHold{}, nil).Once()
+
+	// Act
+	availability, err := usecase.GetLimitAvailability(consumerID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(7000000), availability.Overall.Used)
+	assert.Equal(t, domain.NewMoney(2000000), availability.Overall.Outstanding)
+	assert.Equal(t, domain.NewMoney(8000000), availability.Overall.Remaining)
+	assert.Equal(t, domain.NewMoney(3000000), availability.Tenor(3).Remaining)
+	assert.Equal(t, domain.NewMoney(6000000), availability.Tenor(6).Used)
+	assert.Equal(t, domain.NewMoney(1000000), availability.Tenor(6).Outstanding)
+	assert.Equal(t, domain.NewMoney(7000000), availability.Tenor(6).Remaining)
+	mockConsumerRepo.AssertExpectations(t)
+	mockLimitRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestGetLimitAvailability_SubtractsActiveHolds(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+
+	consumerID := uint(1)
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	limits := []*domain.ConsumerCreditLimit{
+		{ID: 10, ConsumerID: consumerID, TenorMonths: 3, CreditLimit: domain.NewMoney(4000000)},
+		{ID: 11, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(8000000)},
+	}
+	activeTransactions := []*domain.Transaction{
+		{ConsumerCreditLimitID: 10, PokokPembiayaanAwal: domain.NewMoney(1000000)},
+	}
+	activeHolds := []*domain.LimitHold{
+		{ConsumerCreditLimitID: 10, Jumlah: domain.NewMoney(1500000), Status: domain.StatusHoldAktif},
+	}
+
+	deps.mockConsumerRepo.On("FindByID", consumerID).Return(consumer, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", consumerID).Return(limits, nil).Once()
+	deps.mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return(activeTransactions, nil).Once()
+	deps.mockHoldRepo.On("FindActiveByConsumerID", consumerID, mock.AnythingOfType("time.Time")).
+		Return(activeHolds, nil).Once()
+
+	// Act
+	availability, err := usecase.GetLimitAvailability(consumerID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(1500000), availability.Overall.Held)
+	assert.Equal(t, domain.NewMoney(7500000), availability.Overall.Remaining)
+	assert.Equal(t, domain.NewMoney(1500000), availability.Tenor(3).Held)
+	assert.Equal(t, domain.NewMoney(1500000), availability.Tenor(3).Remaining)
+	assert.True(t, availability.Tenor(6).Held.IsZero())
+	assert.Equal(t, domain.NewMoney(8000000), availability.Tenor(6).Remaining)
+}
+
+// expectLockedLimit menyiapkan ekspektasi lockLimit: konsumen dengan plafon 10.000.000, limit tenor 6 bulan
+// sebesar 5.000.000, dan satu kontrak berjalan dengan sisa pokok 3.000.000.
+func expectLockedLimit(deps creditLimitTestDeps, consumerID uint) *domain.ConsumerCreditLimit {
+	limit := &domain.ConsumerCreditLimit{ID: 11, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(5000000)}
+	deps.mockConsumerRepo.On("FindByIDForUpdate", consumerID).
+		Return(&domain.Consumer{
+			ID:                 consumerID,
+			OverallCreditLimit: domain.NewMoney(10000000),
+			KYCVerification:    kycApproved,
+		}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", consumerID).Return([]*domain.ConsumerCreditLimit{limit}, nil).Once()
+	deps.mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return(
+		[]*domain.Transaction{
+			{ConsumerCreditLimitID: 11, PokokPembiayaanAwal: domain.NewMoney(4000000), PokokTerbayar: domain.NewMoney(1000000)},
+		}, nil,
+	).Once()
+	return limit
+}
+
+func TestUpdateConsumerCreditLimit_RecordsHistory(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+	input := UpdateConsumerCreditLimitInput{CreditLimit: domain.NewMoney(3500000), Alasan: "Penyesuaian risiko"}
+
+	var history *domain.CreditLimitHistory
+
+	deps.mockSQL.ExpectBegin()
+	expectLockedLimit(deps, consumerID)
+	deps.mockLimitRepo.On(
+		"Update", uint(11), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["credit_limit"] == input.CreditLimit && updates["berlaku_sampai"] != nil
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On("Save", mock.AnythingOfType("*domain.CreditLimitHistory")).
+		Run(func(args mock.Arguments) { history = args.Get(0).(*domain.CreditLimitHistory) }).
+		Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	limit, err := usecase.UpdateConsumerCreditLimit(consumerID, 6, 99, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(3500000), limit.CreditLimit)
+	assert.True(t, limit.BerlakuSampai.After(time.Now().AddDate(0, 11, 0)))
+	assert.Equal(t, domain.CreditLimitAksiUpdate, history.Aksi)
+	assert.Equal(t, domain.NewMoney(5000000), history.LimitSebelum)
+	assert.Equal(t, domain.NewMoney(3500000), history.LimitSesudah)
+	assert.Equal(t, uint(99), history.ChangedBy)
+	assert.Equal(t, "Penyesuaian risiko", history.Alasan)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockLimitRepo.AssertExpectations(t)
+}
+
+func TestUpdateConsumerCreditLimit_BelowOutstandingUsage(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+	input := UpdateConsumerCreditLimitInput{CreditLimit: domain.NewMoney(2000000), Alasan: "Penyesuaian risiko"}
+
+	deps.mockSQL.ExpectBegin()
+	expectLockedLimit(deps, consumerID)
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	limit, err := usecase.UpdateConsumerCreditLimit(consumerID, 6, 99, input)
+
+	// Assert
+	assert.Nil(t, limit)
+	assert.EqualError(
+		t,
+		err,
+		"credit limit (2000000.00) cannot be lower than outstanding usage (3000000.00) for tenor 6 months",
+	)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockLimitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	deps.mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestUpdateConsumerCreditLimit_TenorNotFound(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+	input := UpdateConsumerCreditLimitInput{CreditLimit: domain.NewMoney(2000000), Alasan: "Penyesuaian risiko"}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(&domain.Consumer{ID: consumerID}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", consumerID).Return([]*domain.ConsumerCreditLimit{}, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	_, err := usecase.UpdateConsumerCreditLimit(consumerID, 3, 99, input)
+
+	// Assert
+	assert.EqualError(t, err, "credit limit for tenor 3 not found for this consumer")
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestDeleteConsumerCreditLimit_WithOutstandingUsage(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+
+	deps.mockSQL.ExpectBegin()
+	expectLockedLimit(deps, consumerID)
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	err := usecase.DeleteConsumerCreditLimit(consumerID, 6, 99, DeleteConsumerCreditLimitInput{Alasan: "Produk dihentikan"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockLimitRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestDeleteConsumerCreditLimit_Success(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+	limit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, TenorMonths: 3, CreditLimit: domain.NewMoney(4000000)}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", consumerID).
+		Return(&domain.Consumer{
+			ID:                 consumerID,
+			OverallCreditLimit: domain.NewMoney(10000000),
+			KYCVerification:    kycApproved,
+		}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", consumerID).Return([]*domain.ConsumerCreditLimit{limit}, nil).Once()
+	deps.mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockLimitRepo.On("Delete", uint(10)).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditLimitHistory) bool {
+				return history.Aksi == domain.CreditLimitAksiDelete &&
+					history.LimitSebelum == domain.NewMoney(4000000) &&
+					history.LimitSesudah.IsZero()
+			},
+		),
+	).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	err := usecase.DeleteConsumerCreditLimit(consumerID, 3, 99, DeleteConsumerCreditLimitInput{Alasan: "Produk dihentikan"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockLimitRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestRecommendCreditLimit_RepositoryErrors(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	testCases := []struct {
+		name         string
+		repoErr      error
+		wantNotFound bool
+	}{
+		{name: "konsumen tidak ditemukan", repoErr: gorm.ErrRecordNotFound, wantNotFound: true},
+		{name: "kegagalan database", repoErr: dbErr},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				usecase, deps := setupCreditLimitUsecase(t)
+				deps.mockConsumerRepo.On("FindByID", uint(9)).Return(nil, tc.repoErr).Once()
+
+				// Act
+				recommendation, err := usecase.RecommendCreditLimit(9)
+
+				// Assert: hanya data yang memang tidak ada yang dilaporkan sebagai not found.
+				assert.Nil(t, recommendation)
+				assert.ErrorIs(t, err, tc.repoErr)
+				assert.Equal(t, tc.wantNotFound, errors.Is(err, gorm.ErrRecordNotFound))
+				deps.mockTransactionRepo.AssertNotCalled(t, "FindByConsumerID")
+			},
+		)
+	}
+}
+
+func TestApplyCreditLimitRecommendation_WithOverride(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+	dob := domain.JSONDate(time.Now().AddDate(-30, 0, -1))
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		TanggalLahir:       &dob,
+		Gaji:               domain.NewMoney(10000000),
+		OverallCreditLimit: domain.NewMoney(5000000),
+		Kolektibilitas:     domain.KolektibilitasLancar,
+		KYCVerification:    kycApproved,
+	}
+	existingLimit := &domain.ConsumerCreditLimit{
+		ID:          7,
+		ConsumerID:  consumerID,
+		TenorMonths: 3,
+		CreditLimit: domain.NewMoney(5000000),
+	}
+	input := ApplyCreditLimitRecommendationInput{
+		Limits: []RecommendedTenorLimit{{TenorMonths: 1, CreditLimit: domain.NewMoney(1000000)}},
+	}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	deps.mockTransactionRepo.On("FindByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", consumerID).Return([]*domain.ConsumerCreditLimit{existingLimit}, nil).Once()
+	deps.mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockConsumerRepo.On(
+		"Update", consumerID, mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["overall_credit_limit"] == domain.NewMoney(16000000) &&
+					updates["plafon_berlaku_sampai"] != nil
+			},
+		),
+	).Return(nil).Once()
+	deps.mockLimitRepo.On("Save", mock.AnythingOfType("*domain.ConsumerCreditLimit")).Return(nil).Times(3)
+	deps.mockLimitRepo.On(
+		"Update", uint(7), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["credit_limit"] == domain.NewMoney(8400000) && updates["berlaku_sampai"] != nil
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditLimitHistory) bool {
+				return history.TenorMonths == 1 &&
+					history.Aksi == domain.CreditLimitAksiCreate &&
+					history.LimitSesudah == domain.NewMoney(1000000) &&
+					history.Alasan == "Rekomendasi scoring versi "+DefaultScoringRules.Versi+" (override admin)"
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditLimitHistory) bool {
+				return history.TenorMonths == 3 &&
+					history.Aksi == domain.CreditLimitAksiUpdate &&
+					history.LimitSebelum == domain.NewMoney(5000000) &&
+					history.LimitSesudah == domain.NewMoney(8400000)
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditLimitHistory) bool {
+				return history.TenorMonths != 1 && history.TenorMonths != 3 && history.ChangedBy == 99
+			},
+		),
+	).Return(nil).Twice()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	output, err := usecase.ApplyCreditLimitRecommendation(consumerID, 99, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(16000000), output.OverallCreditLimit)
+	assert.Len(t, output.Limits, 4)
+	assert.Equal(t, domain.NewMoney(8400000), existingLimit.CreditLimit)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockConsumerRepo.AssertExpectations(t)
+	deps.mockLimitRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestApplyCreditLimitRecommendation_NotEligible(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditLimitUsecase(t)
+	consumerID := uint(1)
+	consumer := &domain.Consumer{ID: consumerID, Gaji: domain.NewMoney(10000000)} // Tanggal lahir belum diisi
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	deps.mockTransactionRepo.On("FindByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	output, err := usecase.ApplyCreditLimitRecommendation(consumerID, 99, ApplyCreditLimitRecommendationInput{})
+
+	// Assert
+	assert.Nil(t, output)
+	assert.EqualError(
+		t,
+		err,
+		"consumer is not eligible for a recommended credit limit: date of birth is required to compute a credit limit",
+	)
+	deps.mockConsumerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
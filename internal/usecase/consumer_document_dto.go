@@ -0,0 +1,11 @@
+package usecase
+
+import "io"
+
+// DocumentUpload adalah dokumen yang diunggah klien. ContentType adalah tipe yang dinyatakan klien dan harus
+// sesuai dengan isi file; Isi dibaca sampai batas ukuran DocumentPolicy.
+type DocumentUpload struct {
+	NamaFile    string
+	ContentType string
+	Isi         io.Reader
+}
@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockConsumerDocumentRepository adalah implementasi mock dari domain.ConsumerDocumentRepository.
+type MockConsumerDocumentRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsumerDocumentRepository) WithTx(tx *gorm.DB) domain.ConsumerDocumentRepository {
+	return m
+}
+
+func (m *MockConsumerDocumentRepository) Save(document *domain.ConsumerDocument) error {
+	args := m.Called(document)
+	return args.Error(0)
+}
+
+func (m *MockConsumerDocumentRepository) FindByID(id uint) (*domain.ConsumerDocument, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ConsumerDocument), args.Error(1)
+}
+
+func (m *MockConsumerDocumentRepository) FindByConsumerID(consumerID uint) ([]*domain.ConsumerDocument, error) {
+	args := m.Called(consumerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ConsumerDocument), args.Error(1)
+}
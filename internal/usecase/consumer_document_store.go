@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// DefaultDocumentMaxSizeKB adalah ukuran maksimal dokumen konsumen yang diunggah, dalam kilobyte.
+const DefaultDocumentMaxSizeKB = 5120
+
+// DocumentPolicy membatasi dokumen konsumen yang boleh diunggah.
+type DocumentPolicy struct {
+	// UkuranMaksimal adalah ukuran maksimal dokumen dalam byte.
+	UkuranMaksimal int64
+}
+
+// DefaultDocumentPolicy membatasi dokumen sampai DefaultDocumentMaxSizeKB.
+var DefaultDocumentPolicy = DocumentPolicy{UkuranMaksimal: DefaultDocumentMaxSizeKB * 1024}
+
+// ParseDocumentPolicy mengurai ukuran maksimal dokumen (dalam kilobyte) dari konfigurasi. String kosong
+// menghasilkan DefaultDocumentPolicy.
+func ParseDocumentPolicy(maxSizeKB string) (DocumentPolicy, error) {
+	if strings.TrimSpace(maxSizeKB) == "" {
+		return DefaultDocumentPolicy, nil
+	}
+	kb, err := strconv.ParseInt(strings.TrimSpace(maxSizeKB), 10, 64)
+	if err != nil || kb <= 0 {
+		return DocumentPolicy{}, fmt.Errorf("invalid document max size %q, expected a positive number of KB", maxSizeKB)
+	}
+	return DocumentPolicy{UkuranMaksimal: kb * 1024}, nil
+}
+
+// documentFormats adalah format dokumen yang diterima beserta magic byte di awal file dan ekstensi yang dipakai
+// pada key penyimpanan.
+var documentFormats = []struct {
+	contentType string
+	magic       []byte
+	ext         string
+}{
+	{contentType: "image/jpeg", magic: []byte{0xFF, 0xD8, 0xFF}, ext: ".jpg"},
+	{contentType: "image/png", magic: []byte("\x89PNG\r\n\x1a\n"), ext: ".png"},
+}
+
+// PreparedDocument adalah dokumen yang sudah lolos validasi dan siap disimpan.
+type PreparedDocument struct {
+	jenis       string
+	namaFile    string
+	contentType string
+	ext         string
+	sha256      string
+	content     []byte
+}
+
+// DocumentStore memvalidasi dokumen konsumen, menyimpan isinya ke DocumentStorage, dan mencatat metadatanya.
+// Prepare dipanggil sebelum transaksi database agar dokumen yang tidak valid ditolak lebih awal, sedangkan Store
+// dipanggil di dalam transaksi setelah konsumennya ada.
+type DocumentStore interface {
+	Prepare(jenis string, upload *DocumentUpload) (*PreparedDocument, error)
+	Store(tx *gorm.DB, consumerID uint, uploadedBy *uint, document *PreparedDocument) (*domain.ConsumerDocument, error)
+	// Discard menghapus isi dokumen yang sudah tersimpan ketika transaksi database yang mencatatnya gagal.
+	Discard(documents []*domain.ConsumerDocument)
+}
+
+type documentStore struct {
+	repo    domain.ConsumerDocumentRepository
+	storage domain.DocumentStorage
+	policy  DocumentPolicy
+}
+
+func NewDocumentStore(
+	repo domain.ConsumerDocumentRepository,
+	storage domain.DocumentStorage,
+	policy DocumentPolicy,
+) DocumentStore {
+	return &documentStore{repo: repo, storage: storage, policy: policy}
+}
+
+// Prepare membaca isi dokumen dan memeriksa ukurannya, format dari magic byte, serta kesesuaiannya dengan
+// content type yang dinyatakan klien.
+func (s *documentStore) Prepare(jenis string, upload *DocumentUpload) (*PreparedDocument, error) {
+	field := documentField(jenis)
+
+	content, err := io.ReadAll(io.LimitReader(upload.Isi, s.policy.UkuranMaksimal+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", field, err)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("%s is empty", field)
+	}
+	if int64(len(content)) > s.policy.UkuranMaksimal {
+		return nil, fmt.Errorf("%s exceeds the maximum size of %d KB", field, s.policy.UkuranMaksimal/1024)
+	}
+
+	document := &PreparedDocument{jenis: jenis, namaFile: upload.NamaFile, content: content}
+	for _, format := range documentFormats {
+		if bytes.HasPrefix(content, format.magic) {
+			document.contentType = format.contentType
+			document.ext = format.ext
+			break
+		}
+	}
+	if document.contentType == "" {
+		return nil, fmt.Errorf("%s must be a JPEG or PNG image", field)
+	}
+	if declared := declaredContentType(upload.ContentType); declared != "" && declared != document.contentType {
+		return nil, fmt.Errorf(
+			"%s content type %s does not match its content (%s)", field, declared, document.contentType,
+		)
+	}
+
+	sum := sha256.Sum256(content)
+	document.sha256 = hex.EncodeToString(sum[:])
+	return document, nil
+}
+
+// Store menyimpan isi dokumen ke DocumentStorage dan mencatat metadatanya di dalam tx. Key penyimpanan
+// diturunkan dari konsumen, jenis dokumen, dan hash isinya, sehingga tidak memuat data pribadi seperti NIK.
+func (s *documentStore) Store(
+	tx *gorm.DB,
+	consumerID uint,
+	uploadedBy *uint,
+	document *PreparedDocument,
+) (*domain.ConsumerDocument, error) {
+	key := fmt.Sprintf(
+		"consumers/%d/%s-%s%s", consumerID, strings.ToLower(document.jenis), document.sha256, document.ext,
+	)
+	if err := s.storage.Put(key, document.content, document.contentType); err != nil {
+		return nil, fmt.Errorf("could not store %s: %w", documentField(document.jenis), err)
+	}
+
+	stored := &domain.ConsumerDocument{
+		ConsumerID:     consumerID,
+		Jenis:          document.jenis,
+		StorageBackend: s.storage.Backend(),
+		StorageKey:     key,
+		NamaFile:       document.namaFile,
+		ContentType:    document.contentType,
+		Ukuran:         int64(len(document.content)),
+		SHA256:         document.sha256,
+		DiunggahOleh:   uploadedBy,
+	}
+	if err := s.repo.WithTx(tx).Save(stored); err != nil {
+		s.Discard([]*domain.ConsumerDocument{stored})
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (s *documentStore) Discard(documents []*domain.ConsumerDocument) {
+	for _, document := range documents {
+		if err := s.storage.Delete(document.StorageKey); err != nil {
+			log.Printf("Failed to discard document %s: %v", document.StorageKey, err)
+		}
+	}
+}
+
+// documentField mengembalikan nama field request untuk jenis dokumen, dipakai pada pesan error.
+func documentField(jenis string) string {
+	if jenis == domain.JenisDokumenSelfie {
+		return "foto_selfie"
+	}
+	return "foto_ktp"
+}
+
+// declaredContentType menormalkan content type dari klien. Tipe generik application/octet-stream dianggap
+// tidak dinyatakan.
+func declaredContentType(raw string) string {
+	mediaType, _, err := mime.ParseMediaType(raw)
+	if err != nil || mediaType == "application/octet-stream" {
+		return ""
+	}
+	if mediaType == "image/jpg" || mediaType == "image/pjpeg" {
+		return "image/jpeg"
+	}
+	return mediaType
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// jpegContent adalah isi minimal berformat JPEG (diawali magic byte FF D8 FF).
+var jpegContent = append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, []byte("kredit-plus-test-image")...)
+
+func jpegUpload(namaFile string) *DocumentUpload {
+	return &DocumentUpload{NamaFile: namaFile, ContentType: "image/jpeg", Isi: bytes.NewReader(jpegContent)}
+}
+
+func TestDocumentStore_Prepare_ValidatesContent(t *testing.T) {
+	pngContent := append([]byte("\x89PNG\r\n\x1a\n"), []byte("kredit-plus-test-image")...)
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     []byte
+		wantErr     string
+	}{
+		{name: "jpeg", contentType: "image/jpeg", content: jpegContent},
+		{name: "png tanpa content type", contentType: "application/octet-stream", content: pngContent},
+		{name: "alias image/jpg", contentType: "image/jpg", content: jpegContent},
+		{name: "kosong", contentType: "image/jpeg", wantErr: "foto_ktp is empty"},
+		{
+			name:        "melebihi ukuran maksimal",
+			contentType: "image/jpeg",
+			content:     append(append([]byte{}, jpegContent...), make([]byte, 1024)...),
+			wantErr:     "foto_ktp exceeds the maximum size of 1 KB",
+		},
+		{
+			name:        "bukan gambar",
+			contentType: "image/jpeg",
+			content:     []byte("%PDF-1.7 bukan foto"),
+			wantErr:     "foto_ktp must be a JPEG or PNG image",
+		},
+		{
+			name:        "content type tidak sesuai isi",
+			contentType: "image/png",
+			content:     jpegContent,
+			wantErr:     "foto_ktp content type image/png does not match its content (image/jpeg)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Arrange
+				documents := NewDocumentStore(nil, nil, DocumentPolicy{UkuranMaksimal: 1024})
+				upload := &DocumentUpload{
+					NamaFile:    "ktp",
+					ContentType: tt.contentType,
+					Isi:         bytes.NewReader(tt.content),
+				}
+
+				// Act
+				prepared, err := documents.Prepare(domain.JenisDokumenKTP, upload)
+
+				// Assert
+				if tt.wantErr != "" {
+					assert.Nil(t, prepared)
+					assert.EqualError(t, err, tt.wantErr)
+					return
+				}
+				assert.NoError(t, err)
+				sum := sha256.Sum256(tt.content)
+				assert.Equal(t, hex.EncodeToString(sum[:]), prepared.sha256)
+			},
+		)
+	}
+}
+
+func TestParseDocumentPolicy(t *testing.T) {
+	policy, err := ParseDocumentPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultDocumentPolicy, policy)
+
+	policy, err = ParseDocumentPolicy("2048")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2048*1024), policy.UkuranMaksimal)
+
+	_, err = ParseDocumentPolicy("0")
+	assert.Error(t, err)
+}
+
+// fakeS3Server adalah pengganti server S3-compatible (seperti MinIO) untuk pengujian. Server memeriksa bahwa
+// setiap permintaan ditandatangani dengan AWS Signature V4 dan bahwa hash payload sesuai dengan isinya.
+type fakeS3Server struct {
+	t       *testing.T
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(s.t, err)
+
+	auth := r.Header.Get("Authorization")
+	assert.True(s.t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=minio-access/"), auth)
+	assert.Contains(s.t, auth, "/us-east-1/s3/aws4_request")
+	assert.Contains(s.t, auth, "SignedHeaders=")
+	assert.Contains(s.t, auth, "Signature=")
+	sum := sha256.Sum256(body)
+	assert.Equal(s.t, hex.EncodeToString(sum[:]), r.Header.Get("X-Amz-Content-Sha256"))
+	assert.NotEmpty(s.t, r.Header.Get("X-Amz-Date"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		s.objects[r.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		object, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(object)
+	case http.MethodDelete:
+		delete(s.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestDocumentStore_Store_S3RoundTrip(t *testing.T) {
+	// Arrange
+	fake := &fakeS3Server{t: t, objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	s3, err := storage.NewS3Storage(
+		storage.S3Config{
+			Endpoint:        server.URL,
+			Bucket:          "kredit-plus",
+			AccessKeyID:     "minio-access",
+			SecretAccessKey: "minio-secret",
+			UsePathStyle:    true,
+		},
+	)
+	assert.NoError(t, err)
+
+	mockDocumentRepo := new(MockConsumerDocumentRepository)
+	mockDocumentRepo.On("Save", mock.AnythingOfType("*domain.ConsumerDocument")).Return(nil).Once()
+	documents := NewDocumentStore(mockDocumentRepo, s3, DefaultDocumentPolicy)
+
+	prepared, err := documents.Prepare(domain.JenisDokumenSelfie, jpegUpload("selfie.jpg"))
+	assert.NoError(t, err)
+
+	// Act
+	stored, err := documents.Store(nil, 5, nil, prepared)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, storage.BackendS3, stored.StorageBackend)
+	assert.Equal(t, "consumers/5/selfie-"+prepared.sha256+".jpg", stored.StorageKey)
+	assert.Equal(t, "image/jpeg", stored.ContentType)
+	assert.Equal(t, int64(len(jpegContent)), stored.Ukuran)
+
+	object, err := s3.Get(stored.StorageKey)
+	assert.NoError(t, err)
+	content, err := io.ReadAll(object)
+	assert.NoError(t, err)
+	assert.NoError(t, object.Close())
+	assert.Equal(t, jpegContent, content)
+
+	documents.Discard([]*domain.ConsumerDocument{stored})
+	_, err = s3.Get(stored.StorageKey)
+	assert.ErrorIs(t, err, domain.ErrDocumentNotFound)
+	mockDocumentRepo.AssertExpectations(t)
+}
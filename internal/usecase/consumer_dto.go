@@ -1,6 +1,10 @@
 package usecase
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
 
 type CreateConsumerFormInput struct {
 	Nik                string                `form:"nik" binding:"required,len=16"`
@@ -11,7 +15,7 @@ type CreateConsumerFormInput struct {
 	TempatLahir        string                `form:"tempat_lahir" binding:"required"`
 	TanggalLahir       string                `form:"tanggal_lahir" binding:"required,datetime=2006-01-02"`
 	Gaji               string                `form:"gaji" binding:"required,numeric,gt=0"`
-	OverallCreditLimit string                `form:"overall_credit_limit" binding:"required,numeric,gte=0"`
+	OverallCreditLimit string                `form:"overall_credit_limit" binding:"omitempty,numeric,gte=0"`
 	FotoKtp            *multipart.FileHeader `form:"foto_ktp" binding:"omitempty"`
 	FotoSelfie         *multipart.FileHeader `form:"foto_selfie" binding:"omitempty"`
 }
@@ -24,19 +28,52 @@ type CreateConsumerInput struct {
 	Password           string
 	TempatLahir        string
 	TanggalLahir       string
-	Gaji               float64
-	OverallCreditLimit float64
-	FotoKtpPath        string
-	FotoSelfiePath     string
+	Gaji               domain.Money
+	OverallCreditLimit domain.Money
+	FotoKtp            *DocumentUpload
+	FotoSelfie         *DocumentUpload
 }
 
 type UpdateConsumerInput struct {
-	FullName           *string  `json:"full_name" validate:"omitempty,min=2"`
-	LegalName          *string  `json:"legal_name" validate:"omitempty,min=2"`
-	TempatLahir        *string  `json:"tempat_lahir" validate:"omitempty,min=1"`
-	TanggalLahir       *string  `json:"tanggal_lahir" validate:"omitempty,datetime=2006-01-02"`
-	Gaji               *float64 `json:"gaji" validate:"omitempty,gt=0"`
-	OverallCreditLimit *float64 `json:"overall_credit_limit" validate:"omitempty,gte=0"`
-	FotoKtp            *string  `json:"foto_ktp" validate:"omitempty,url|uri"`
-	FotoSelfie         *string  `json:"foto_selfie" validate:"omitempty,url|uri"`
+	FullName           *string       `json:"full_name" validate:"omitempty,min=2"`
+	LegalName          *string       `json:"legal_name" validate:"omitempty,min=2"`
+	TempatLahir        *string       `json:"tempat_lahir" validate:"omitempty,min=1"`
+	TanggalLahir       *string       `json:"tanggal_lahir" validate:"omitempty,datetime=2006-01-02"`
+	Gaji               *domain.Money `json:"gaji" validate:"omitempty,gt=0"`
+	OverallCreditLimit *domain.Money `json:"overall_credit_limit" validate:"omitempty,gte=0"`
+}
+
+// ConsumerListQuery adalah parameter query string daftar konsumen. Pagination offset memakai page dan limit;
+// pagination cursor dipakai jika cursor diisi atau pagination=cursor.
+type ConsumerListQuery struct {
+	// Q mencari NIK (16 digit, harus persis sama) atau sebagian nama konsumen.
+	Q                 string `form:"q"`
+	StatusKYC         string `form:"status_kyc"`
+	GajiMin           string `form:"gaji_min"`
+	GajiMax           string `form:"gaji_max"`
+	HasActiveContract string `form:"has_active_contract"`
+	CreatedFrom       string `form:"created_from"`
+	CreatedTo         string `form:"created_to"`
+	// Sort adalah kolom urut, diawali "-" untuk urutan menurun, misalnya -created_at.
+	Sort       string `form:"sort"`
+	Include    string `form:"include"`
+	Pagination string `form:"pagination"`
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+	Cursor     string `form:"cursor"`
+}
+
+// ConsumerListOutput adalah satu halaman daftar konsumen.
+type ConsumerListOutput struct {
+	Consumers  []*domain.Consumer
+	Pagination PaginationOutput
+}
+
+// PaginationOutput menjelaskan posisi halaman. Page dan Total diisi pada pagination offset; NextCursor diisi
+// pada pagination cursor selama masih ada halaman berikutnya.
+type PaginationOutput struct {
+	Limit      int    `json:"limit"`
+	Page       int    `json:"page,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
 }
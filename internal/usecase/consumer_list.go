@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+const (
+	// DefaultConsumerListLimit adalah jumlah konsumen per halaman jika limit tidak diisi.
+	DefaultConsumerListLimit = 20
+	// MaxConsumerListLimit adalah jumlah konsumen terbanyak per halaman.
+	MaxConsumerListLimit = 100
+)
+
+const (
+	// gajiFilterBatchFactor adalah jumlah konsumen yang diambil per batch (kelipatan limit) saat menyaring gaji.
+	gajiFilterBatchFactor = 5
+	// gajiFilterScanLimit membatasi jumlah konsumen yang didekripsi dalam satu permintaan saat menyaring gaji.
+	// Gaji disimpan terenkripsi sehingga hanya dapat disaring setelah didekripsi; jika batas ini tercapai,
+	// halaman dapat berisi kurang dari limit dan klien melanjutkan pemeriksaan dengan next_cursor.
+	gajiFilterScanLimit = 1000
+)
+
+// ErrInvalidConsumerListQuery dikembalikan jika parameter daftar konsumen tidak valid.
+var ErrInvalidConsumerListQuery = errors.New("invalid consumer list query")
+
+var consumerNIKQueryPattern = regexp.MustCompile(`^[0-9]{16}$`)
+
+var consumerListIncludes = []string{
+	domain.RelasiKonsumenUser,
+	domain.RelasiKonsumenCreditLimits,
+	domain.RelasiKonsumenTransactions,
+	domain.RelasiKonsumenDokumen,
+}
+
+var consumerListSorts = []string{
+	domain.UrutKonsumenID,
+	domain.UrutKonsumenCreatedAt,
+	domain.UrutKonsumenOverallCreditLimit,
+	domain.UrutKonsumenHariKeterlambatan,
+}
+
+// consumerListPlan adalah hasil penguraian ConsumerListQuery.
+type consumerListPlan struct {
+	filter     domain.ConsumerListFilter
+	cursorMode bool
+	page       int
+	limit      int
+	gajiMin    *domain.Money
+	gajiMax    *domain.Money
+}
+
+// consumerCursorToken adalah isi cursor daftar konsumen. Urutan ikut disimpan agar cursor tidak dipakai dengan
+// urutan lain.
+type consumerCursorToken struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d,omitempty"`
+	Value string `json:"v,omitempty"`
+	ID    uint   `json:"i"`
+}
+
+func invalidConsumerListQuery(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidConsumerListQuery, fmt.Sprintf(format, args...))
+}
+
+// parseConsumerListQuery memvalidasi parameter daftar konsumen dan menyusun filter repository.
+func parseConsumerListQuery(query ConsumerListQuery) (*consumerListPlan, error) {
+	plan := &consumerListPlan{limit: query.Limit}
+	if plan.limit == 0 {
+		plan.limit = DefaultConsumerListLimit
+	}
+	if plan.limit < 1 || plan.limit > MaxConsumerListLimit {
+		return nil, invalidConsumerListQuery("limit must be between 1 and %d", MaxConsumerListLimit)
+	}
+	filter := &plan.filter
+
+	if q := strings.TrimSpace(query.Q); consumerNIKQueryPattern.MatchString(q) {
+		filter.Nik = q
+	} else {
+		filter.Nama = q
+	}
+
+	for _, status := range splitList(query.StatusKYC) {
+		status = strings.ToUpper(status)
+		if !isValidKYCStatus(status) {
+			return nil, invalidConsumerListQuery(
+				"status_kyc %s is invalid. allowed statuses are DRAFT, SUBMITTED, IN_REVIEW, APPROVED, REJECTED",
+				status,
+			)
+		}
+		filter.StatusKYC = append(filter.StatusKYC, status)
+	}
+
+	var err error
+	if plan.gajiMin, err = parseOptionalMoney("gaji_min", query.GajiMin); err != nil {
+		return nil, err
+	}
+	if plan.gajiMax, err = parseOptionalMoney("gaji_max", query.GajiMax); err != nil {
+		return nil, err
+	}
+	if plan.gajiMin != nil && plan.gajiMax != nil && plan.gajiMin.GreaterThan(*plan.gajiMax) {
+		return nil, invalidConsumerListQuery("gaji_min must not be greater than gaji_max")
+	}
+
+	if raw := strings.TrimSpace(query.HasActiveContract); raw != "" {
+		hasActiveContract, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalidConsumerListQuery("has_active_contract must be true or false")
+		}
+		filter.PunyaKontrakAktif = &hasActiveContract
+	}
+
+	if filter.DibuatDari, err = parseOptionalDate("created_from", query.CreatedFrom); err != nil {
+		return nil, err
+	}
+	createdTo, err := parseOptionalDate("created_to", query.CreatedTo)
+	if err != nil {
+		return nil, err
+	}
+	if createdTo != nil {
+		// created_to inklusif: seluruh hari tersebut ikut disertakan.
+		dibuatSebelum := createdTo.AddDate(0, 0, 1)
+		filter.DibuatSebelum = &dibuatSebelum
+	}
+	if filter.DibuatDari != nil && filter.DibuatSebelum != nil && !filter.DibuatDari.Before(*filter.DibuatSebelum) {
+		return nil, invalidConsumerListQuery("created_from must not be after created_to")
+	}
+
+	sort := strings.TrimSpace(query.Sort)
+	filter.UrutMenurun = strings.HasPrefix(sort, "-")
+	filter.UrutBerdasarkan = strings.TrimPrefix(sort, "-")
+	if filter.UrutBerdasarkan == "" {
+		filter.UrutBerdasarkan = domain.UrutKonsumenID
+	}
+	if !slices.Contains(consumerListSorts, filter.UrutBerdasarkan) {
+		return nil, invalidConsumerListQuery(
+			"sort %s is invalid. allowed fields are %s", filter.UrutBerdasarkan, strings.Join(consumerListSorts, ", "),
+		)
+	}
+
+	for _, include := range splitList(query.Include) {
+		include = strings.ToLower(include)
+		if !slices.Contains(consumerListIncludes, include) {
+			return nil, invalidConsumerListQuery(
+				"include %s is invalid. allowed relations are %s", include, strings.Join(consumerListIncludes, ", "),
+			)
+		}
+		if !slices.Contains(filter.Include, include) {
+			filter.Include = append(filter.Include, include)
+		}
+	}
+
+	switch pagination := strings.ToLower(strings.TrimSpace(query.Pagination)); {
+	case pagination == "cursor" || query.Cursor != "":
+		if query.Page != 0 {
+			return nil, invalidConsumerListQuery("page cannot be combined with cursor pagination")
+		}
+		plan.cursorMode = true
+		if query.Cursor != "" {
+			if filter.Setelah, err = decodeConsumerCursor(query.Cursor, *filter); err != nil {
+				return nil, err
+			}
+		}
+	case pagination == "" || pagination == "offset":
+		plan.page = query.Page
+		if plan.page == 0 {
+			plan.page = 1
+		}
+		if plan.page < 1 {
+			return nil, invalidConsumerListQuery("page must be at least 1")
+		}
+		if plan.filtersGaji() {
+			return nil, invalidConsumerListQuery("gaji_min and gaji_max require cursor pagination")
+		}
+		filter.Offset = (plan.page - 1) * plan.limit
+	default:
+		return nil, invalidConsumerListQuery("pagination must be offset or cursor")
+	}
+	return plan, nil
+}
+
+// matchesGaji menandakan gaji konsumen berada dalam rentang gaji_min dan gaji_max.
+func (p *consumerListPlan) matchesGaji(consumer *domain.Consumer) bool {
+	if p.gajiMin != nil && consumer.Gaji.LessThan(*p.gajiMin) {
+		return false
+	}
+	return p.gajiMax == nil || !consumer.Gaji.GreaterThan(*p.gajiMax)
+}
+
+func (p *consumerListPlan) filtersGaji() bool {
+	return p.gajiMin != nil || p.gajiMax != nil
+}
+
+// consumerPosition mengembalikan posisi consumer pada urutan filter, untuk mengambil batch berikutnya.
+func consumerPosition(filter domain.ConsumerListFilter, consumer *domain.Consumer) *domain.ConsumerCursor {
+	position := &domain.ConsumerCursor{ID: consumer.ID}
+	switch filter.UrutBerdasarkan {
+	case domain.UrutKonsumenCreatedAt:
+		position.Nilai = consumer.CreatedAt
+	case domain.UrutKonsumenOverallCreditLimit:
+		position.Nilai = consumer.OverallCreditLimit
+	case domain.UrutKonsumenHariKeterlambatan:
+		position.Nilai = consumer.HariKeterlambatan
+	}
+	return position
+}
+
+// encodeConsumerCursor membuat cursor yang menunjuk ke posisi consumer pada urutan filter.
+func encodeConsumerCursor(filter domain.ConsumerListFilter, consumer *domain.Consumer) string {
+	token := consumerCursorToken{Sort: filter.UrutBerdasarkan, Desc: filter.UrutMenurun, ID: consumer.ID}
+	switch filter.UrutBerdasarkan {
+	case domain.UrutKonsumenCreatedAt:
+		token.Value = consumer.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case domain.UrutKonsumenOverallCreditLimit:
+		token.Value = consumer.OverallCreditLimit.String()
+	case domain.UrutKonsumenHariKeterlambatan:
+		token.Value = strconv.Itoa(consumer.HariKeterlambatan)
+	}
+	raw, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeConsumerCursor mengurai cursor dan memastikan cursor dibuat untuk urutan yang sama dengan filter.
+func decodeConsumerCursor(cursor string, filter domain.ConsumerListFilter) (*domain.ConsumerCursor, error) {
+	var token consumerCursorToken
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || json.Unmarshal(raw, &token) != nil || token.ID == 0 {
+		return nil, invalidConsumerListQuery("cursor is malformed")
+	}
+	if token.Sort != filter.UrutBerdasarkan || token.Desc != filter.UrutMenurun {
+		return nil, invalidConsumerListQuery("cursor was issued for a different sort order")
+	}
+
+	position := &domain.ConsumerCursor{ID: token.ID}
+	switch token.Sort {
+	case domain.UrutKonsumenCreatedAt:
+		position.Nilai, err = time.Parse(time.RFC3339Nano, token.Value)
+	case domain.UrutKonsumenOverallCreditLimit:
+		position.Nilai, err = domain.ParseMoney(token.Value)
+	case domain.UrutKonsumenHariKeterlambatan:
+		position.Nilai, err = strconv.Atoi(token.Value)
+	}
+	if err != nil {
+		return nil, invalidConsumerListQuery("cursor is malformed")
+	}
+	return position, nil
+}
+
+func parseOptionalMoney(name, raw string) (*domain.Money, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	money, err := domain.ParseMoney(raw)
+	if err != nil || money.IsNegative() {
+		return nil, invalidConsumerListQuery("%s must be a non-negative amount", name)
+	}
+	return &money, nil
+}
+
+func parseOptionalDate(name, raw string) (*time.Time, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(raw))
+	if err != nil {
+		return nil, invalidConsumerListQuery("%s must use the yyyy-MM-dd format", name)
+	}
+	return &date, nil
+}
+
+// splitList memecah daftar yang dipisahkan koma dan membuang elemen kosong.
+func splitList(raw string) []string {
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -57,8 +57,21 @@ func (m *MockConsumerRepository) FindByNIK(nik string) (*domain.Consumer, error)
 	return args.Get(0).(*domain.Consumer), args.Error(1)
 }
 
-func (m *MockConsumerRepository) FindAll() ([]*domain.Consumer, error) {
-	args := m.Called()
+func (m *MockConsumerRepository) FindPage(filter domain.ConsumerListFilter) ([]*domain.Consumer, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Consumer), args.Error(1)
+}
+
+func (m *MockConsumerRepository) Count(filter domain.ConsumerListFilter) (int64, error) {
+	args := m.Called(filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockConsumerRepository) FindByStatusKYC(statuses []string) ([]*domain.Consumer, error) {
+	args := m.Called(statuses)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -69,3 +82,24 @@ func (m *MockConsumerRepository) Delete(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
+
+func (m *MockConsumerRepository) FindAfterID(afterID uint, limit int) ([]*domain.Consumer, error) {
+	args := m.Called(afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Consumer), args.Error(1)
+}
+
+func (m *MockConsumerRepository) FindDelinquentIDs() ([]uint, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *MockConsumerRepository) UpdatePII(consumer *domain.Consumer) error {
+	args := m.Called(consumer)
+	return args.Error(0)
+}
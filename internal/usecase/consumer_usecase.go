@@ -10,7 +10,7 @@ import (
 
 type ConsumerUsecase interface {
 	CreateConsumer(input CreateConsumerInput) (*domain.Consumer, error)
-	GetAllConsumers() ([]*domain.Consumer, error)
+	ListConsumers(query ConsumerListQuery) (*ConsumerListOutput, error)
 	GetConsumerByUserID(userID uint) (*domain.Consumer, error)
 	GetConsumerByID(id uint) (*domain.Consumer, error)
 	UpdateConsumer(id uint, input UpdateConsumerInput) (*domain.Consumer, error)
@@ -18,24 +18,41 @@ type ConsumerUsecase interface {
 }
 
 type consumerUsecase struct {
-	db       *gorm.DB
-	repo     domain.ConsumerRepository
-	userRepo domain.UserRepository
+	db           *gorm.DB
+	repo         domain.ConsumerRepository
+	userRepo     domain.UserRepository
+	reviewPolicy LimitReviewPolicy
+	documents    DocumentStore
 }
 
-func NewConsumerUsecase(db *gorm.DB, repo domain.ConsumerRepository, userRepo domain.UserRepository) ConsumerUsecase {
+func NewConsumerUsecase(
+	db *gorm.DB,
+	repo domain.ConsumerRepository,
+	userRepo domain.UserRepository,
+	reviewPolicy LimitReviewPolicy,
+	documents DocumentStore,
+) ConsumerUsecase {
 	return &consumerUsecase{
-		db:       db,
-		repo:     repo,
-		userRepo: userRepo,
+		db:           db,
+		repo:         repo,
+		userRepo:     userRepo,
+		reviewPolicy: reviewPolicy,
+		documents:    documents,
 	}
 }
 
 func (uc *consumerUsecase) CreateConsumer(input CreateConsumerInput) (*domain.Consumer, error) {
 	var createdConsumer *domain.Consumer
 
+	// Dokumen divalidasi sebelum transaksi dimulai agar file yang tidak valid ditolak lebih awal.
+	fotoKtp, fotoSelfie, err := prepareKYCDocuments(uc.documents, input.FotoKtp, input.FotoSelfie)
+	if err != nil {
+		return nil, err
+	}
+	var storedDocuments []*domain.ConsumerDocument
+
 	// Membungkus seluruh operasi dalam sebuah transaksi database.
-	err := uc.db.Transaction(
+	err = uc.db.Transaction(
 		func(tx *gorm.DB) error {
 			// Gunakan repository dengan koneksi transaksi (tx)
 			userRepoTx := uc.userRepo.WithTx(tx)
@@ -53,11 +70,22 @@ func (uc *consumerUsecase) CreateConsumer(input CreateConsumerInput) (*domain.Co
 				return fmt.Errorf("consumer with NIK %s already exists", input.Nik)
 			}
 
-			// 3. Buat User baru
+			// 3. Validasi: NIK harus valid secara struktur dan tanggal lahir di dalamnya harus sama dengan
+			// tanggal_lahir
+			dob, err := time.Parse("2006-01-02", input.TanggalLahir)
+			if err != nil {
+				return fmt.Errorf("invalid date format for tanggal_lahir, please use yyyy-MM-dd")
+			}
+			nik, err := parseConsumerNIK(input.Nik, dob)
+			if err != nil {
+				return err
+			}
+
+			// 4. Buat User baru. Nama konsumen hanya disimpan terenkripsi di tabel consumers, tidak disalin ke
+			// users.full_name.
 			newUser := &domain.User{
-				FullName: input.FullName,
-				Email:    input.Email,
-				Role:     "consumer",
+				Email: input.Email,
+				Role:  "consumer",
 			}
 			if err := newUser.HashPassword(input.Password); err != nil {
 				return err
@@ -66,11 +94,7 @@ func (uc *consumerUsecase) CreateConsumer(input CreateConsumerInput) (*domain.Co
 				return err
 			}
 
-			// 4. Buat Consumer baru dan tautkan UserID
-			dob, err := time.Parse("2006-01-02", input.TanggalLahir)
-			if err != nil {
-				return fmt.Errorf("invalid date format for tanggal_lahir, please use yyyy-MM-dd")
-			}
+			// 5. Buat Consumer baru dan tautkan UserID
 			jsonDob := domain.JSONDate(dob)
 
 			consumer := &domain.Consumer{
@@ -80,31 +104,133 @@ func (uc *consumerUsecase) CreateConsumer(input CreateConsumerInput) (*domain.Co
 				LegalName:          input.LegalName,
 				TempatLahir:        input.TempatLahir,
 				TanggalLahir:       &jsonDob,
+				JenisKelamin:       nik.JenisKelamin,
 				Gaji:               input.Gaji,
 				OverallCreditLimit: input.OverallCreditLimit,
-				FotoKtp:            input.FotoKtpPath,
-				FotoSelfie:         input.FotoSelfiePath,
+			}
+			// Konsumen yang mendaftar dengan foto KTP dan selfie langsung masuk antrean verifikasi KYC;
+			// selain itu konsumen tetap DRAFT sampai dokumennya diajukan.
+			consumer.StatusKYC = domain.StatusKYCDraft
+			if fotoKtp != nil && fotoSelfie != nil {
+				now := time.Now()
+				consumer.StatusKYC = domain.StatusKYCSubmitted
+				consumer.KYCDiajukanPada = &now
+			}
+			// Plafon yang ditetapkan saat pendaftaran berlaku sesuai kebijakan peninjauan limit.
+			if consumer.OverallCreditLimit.IsPositive() {
+				consumer.PlafonBerlakuSampai = uc.reviewPolicy.BerlakuSampai(time.Now())
 			}
 
 			if err := consumerRepoTx.Save(consumer); err != nil {
 				return err
 			}
 
+			// 6. Simpan foto KTP dan selfie lalu tautkan ke konsumen
+			updates, err := storeKYCDocuments(
+				tx, uc.documents, consumer, &newUser.ID, fotoKtp, fotoSelfie, &storedDocuments,
+			)
+			if err != nil {
+				return err
+			}
+			if len(updates) > 0 {
+				if err := consumerRepoTx.Update(consumer.ID, updates); err != nil {
+					return err
+				}
+			}
+
 			createdConsumer = consumer
 			return nil // Commit transaksi jika tidak ada error
 		},
 	)
 
 	if err != nil {
+		if len(storedDocuments) > 0 {
+			uc.documents.Discard(storedDocuments)
+		}
 		return nil, err
 	}
 
 	return createdConsumer, nil
 }
 
-// GetAllConsumers mengambil semua data konsumen.
-func (uc *consumerUsecase) GetAllConsumers() ([]*domain.Consumer, error) {
-	return uc.repo.FindAll()
+// ListConsumers mengambil satu halaman konsumen sesuai filter, urutan, dan relasi yang diminta. Filter gaji
+// diterapkan setelah data didekripsi sehingga hanya tersedia dengan pagination cursor.
+func (uc *consumerUsecase) ListConsumers(query ConsumerListQuery) (*ConsumerListOutput, error) {
+	plan, err := parseConsumerListQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.cursorMode {
+		return uc.listConsumersByOffset(plan)
+	}
+	if plan.filtersGaji() {
+		return uc.listConsumersByGaji(plan)
+	}
+
+	// Satu baris tambahan diambil untuk mengetahui apakah masih ada halaman berikutnya.
+	filter := plan.filter
+	filter.Limit = plan.limit + 1
+	consumers, err := uc.repo.FindPage(filter)
+	if err != nil {
+		return nil, err
+	}
+	output := &ConsumerListOutput{Consumers: consumers, Pagination: PaginationOutput{Limit: plan.limit}}
+	if len(consumers) > plan.limit {
+		output.Consumers = consumers[:plan.limit]
+		output.Pagination.NextCursor = encodeConsumerCursor(filter, output.Consumers[plan.limit-1])
+	}
+	return output, nil
+}
+
+func (uc *consumerUsecase) listConsumersByOffset(plan *consumerListPlan) (*ConsumerListOutput, error) {
+	filter := plan.filter
+	filter.Limit = plan.limit
+	total, err := uc.repo.Count(filter)
+	if err != nil {
+		return nil, err
+	}
+	consumers, err := uc.repo.FindPage(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &ConsumerListOutput{
+		Consumers:  consumers,
+		Pagination: PaginationOutput{Limit: plan.limit, Page: plan.page, Total: &total},
+	}, nil
+}
+
+// listConsumersByGaji memeriksa konsumen batch demi batch sampai halaman penuh, data habis, atau
+// gajiFilterScanLimit konsumen diperiksa. Satu konsumen yang cocok setelah halaman penuh menandakan masih ada
+// halaman berikutnya; jika batas pemeriksaan tercapai, next_cursor menunjuk konsumen terakhir yang diperiksa.
+func (uc *consumerUsecase) listConsumersByGaji(plan *consumerListPlan) (*ConsumerListOutput, error) {
+	filter := plan.filter
+	output := &ConsumerListOutput{Consumers: []*domain.Consumer{}, Pagination: PaginationOutput{Limit: plan.limit}}
+	scanned := 0
+	for {
+		filter.Limit = min(plan.limit*gajiFilterBatchFactor, gajiFilterScanLimit-scanned)
+		batch, err := uc.repo.FindPage(filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, consumer := range batch {
+			if plan.matchesGaji(consumer) {
+				if len(output.Consumers) == plan.limit {
+					output.Pagination.NextCursor = encodeConsumerCursor(filter, output.Consumers[plan.limit-1])
+					return output, nil
+				}
+				output.Consumers = append(output.Consumers, consumer)
+			}
+			scanned++
+		}
+		if len(batch) < filter.Limit {
+			return output, nil
+		}
+		if scanned >= gajiFilterScanLimit {
+			output.Pagination.NextCursor = encodeConsumerCursor(filter, batch[len(batch)-1])
+			return output, nil
+		}
+		filter.Setelah = consumerPosition(filter, batch[len(batch)-1])
+	}
 }
 
 // GetConsumerByUserID mengambil konsumen berdasarkan ID pengguna.
@@ -134,7 +260,7 @@ func (uc *consumerUsecase) DeleteConsumer(id uint) error {
 // UpdateConsumer memperbarui data konsumen yang ada.
 func (uc *consumerUsecase) UpdateConsumer(id uint, input UpdateConsumerInput) (*domain.Consumer, error) {
 	// Pertama, pastikan konsumennya ada.
-	_, err := uc.repo.FindByID(id)
+	consumer, err := uc.repo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
@@ -159,16 +285,17 @@ func (uc *consumerUsecase) UpdateConsumer(id uint, input UpdateConsumerInput) (*
 		if err != nil {
 			return nil, fmt.Errorf("invalid date format for tanggal_lahir, please use yyyy-MM-dd")
 		}
+		// Tanggal lahir baru tetap harus sesuai dengan yang tercatat di NIK konsumen.
+		nik, err := parseConsumerNIK(consumer.Nik, dob)
+		if err != nil {
+			return nil, err
+		}
 		updates["tanggal_lahir"] = dob
+		updates["jenis_kelamin"] = nik.JenisKelamin
 	}
 	if input.OverallCreditLimit != nil {
 		updates["overall_credit_limit"] = *input.OverallCreditLimit
-	}
-	if input.FotoKtp != nil {
-		updates["foto_ktp"] = *input.FotoKtp
-	}
-	if input.FotoSelfie != nil {
-		updates["foto_selfie"] = *input.FotoSelfie
+		updates["plafon_berlaku_sampai"] = uc.reviewPolicy.BerlakuSampai(time.Now())
 	}
 
 	// Hanya jalankan update jika ada data yang perlu diubah.
@@ -181,3 +308,20 @@ func (uc *consumerUsecase) UpdateConsumer(id uint, input UpdateConsumerInput) (*
 	// Setelah update berhasil, ambil kembali data terbaru untuk dikembalikan.
 	return uc.repo.FindByID(id)
 }
+
+// parseConsumerNIK memvalidasi NIK konsumen dan memastikan tanggal lahir yang terkandung di dalamnya sama dengan
+// tanggal lahir yang diisikan.
+func parseConsumerNIK(nomor string, tanggalLahir time.Time) (*domain.NIK, error) {
+	nik, err := domain.ParseNIK(nomor, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !nik.CocokTanggalLahir(tanggalLahir) {
+		return nil, fmt.Errorf(
+			"tanggal_lahir %s does not match the birth date encoded in NIK (%s-%s-%s)",
+			tanggalLahir.Format("2006-01-02"),
+			nomor[6:8], nomor[8:10], nomor[10:12],
+		)
+	}
+	return nik, nil
+}
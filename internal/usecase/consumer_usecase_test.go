@@ -2,10 +2,14 @@ package usecase
 
 import (
 	"errors"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/driver/postgres"
@@ -37,10 +41,10 @@ func setupMocksForConsumerTest(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, *MockCo
 func TestConsumerUsecase_CreateConsumer_Success(t *testing.T) {
 	// Arrange
 	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 
 	input := CreateConsumerInput{
-		Nik:          "1234567890123456",
+		Nik:          "3273010101000001",
 		FullName:     "Test Consumer",
 		Email:        "consumer@example.com",
 		Password:     "password123",
@@ -50,7 +54,14 @@ func TestConsumerUsecase_CreateConsumer_Success(t *testing.T) {
 	mockSQL.ExpectBegin()
 	mockUserRepo.On("FindByEmail", input.Email).Return(nil, gorm.ErrRecordNotFound).Once()
 	mockConsumerRepo.On("FindByNIK", input.Nik).Return(nil, gorm.ErrRecordNotFound).Once()
-	mockUserRepo.On("Save", mock.AnythingOfType("*domain.User")).Return(nil).Once()
+	// Nama konsumen tidak disalin sebagai plaintext ke users.full_name.
+	mockUserRepo.On(
+		"Save", mock.MatchedBy(
+			func(user *domain.User) bool {
+				return user.FullName == "" && user.Email == input.Email
+			},
+		),
+	).Return(nil).Once()
 	mockConsumerRepo.On("Save", mock.AnythingOfType("*domain.Consumer")).Return(nil).Once()
 	mockSQL.ExpectCommit()
 
@@ -69,7 +80,7 @@ func TestConsumerUsecase_CreateConsumer_Success(t *testing.T) {
 func TestConsumerUsecase_CreateConsumer_NikExists(t *testing.T) {
 	// Arrange
 	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	input := CreateConsumerInput{Nik: "123", Email: "new@example.com"}
 
 	mockSQL.ExpectBegin()
@@ -92,8 +103,8 @@ func TestConsumerUsecase_CreateConsumer_NikExists(t *testing.T) {
 func TestConsumerUsecase_CreateConsumer_SaveError(t *testing.T) {
 	// Arrange
 	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
-	input := CreateConsumerInput{Nik: "123", Email: "new@example.com", TanggalLahir: "2000-01-01"}
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+	input := CreateConsumerInput{Nik: "3273010101000001", Email: "new@example.com", TanggalLahir: "2000-01-01"}
 	dbError := errors.New("database save error")
 
 	mockSQL.ExpectBegin()
@@ -121,7 +132,7 @@ func TestConsumerUsecase_CreateConsumer_SaveError(t *testing.T) {
 func TestConsumerUsecase_CreateConsumer_InvalidDateFormat(t *testing.T) {
 	// Arrange
 	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	input := CreateConsumerInput{Nik: "123", Email: "new@example.com", TanggalLahir: "01-01-2000"} // Format salah
 
 	mockSQL.ExpectBegin()
@@ -140,12 +151,201 @@ func TestConsumerUsecase_CreateConsumer_InvalidDateFormat(t *testing.T) {
 	assert.NoError(t, mockSQL.ExpectationsWereMet())
 }
 
+func TestConsumerUsecase_CreateConsumer_StoresKYCDocuments(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	storageDir := t.TempDir()
+	documentStorage, err := storage.NewLocalStorage(storageDir)
+	assert.NoError(t, err)
+	mockDocumentRepo := new(MockConsumerDocumentRepository)
+	documents := NewDocumentStore(mockDocumentRepo, documentStorage, DefaultDocumentPolicy)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, documents)
+
+	input := CreateConsumerInput{
+		Nik:          "3273010101000001",
+		FullName:     "Test Consumer",
+		Email:        "consumer@example.com",
+		Password:     "password123",
+		TanggalLahir: "2000-01-01",
+		FotoKtp:      jpegUpload("ktp.jpg"),
+		FotoSelfie:   jpegUpload("selfie.jpg"),
+	}
+
+	mockSQL.ExpectBegin()
+	mockUserRepo.On("FindByEmail", input.Email).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockConsumerRepo.On("FindByNIK", input.Nik).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockUserRepo.On("Save", mock.AnythingOfType("*domain.User")).Return(nil).Once()
+	mockConsumerRepo.On("Save", mock.AnythingOfType("*domain.Consumer")).Run(
+		func(args mock.Arguments) {
+			args.Get(0).(*domain.Consumer).ID = 9
+		},
+	).Return(nil).Once()
+	documentID := uint(20)
+	mockDocumentRepo.On("Save", mock.AnythingOfType("*domain.ConsumerDocument")).Run(
+		func(args mock.Arguments) {
+			documentID++
+			args.Get(0).(*domain.ConsumerDocument).ID = documentID
+		},
+	).Return(nil).Twice()
+	mockConsumerRepo.On(
+		"Update", uint(9), map[string]interface{}{"foto_ktp_id": uint(21), "foto_selfie_id": uint(22)},
+	).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	consumer, err := usecase.CreateConsumer(input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusKYCSubmitted, consumer.StatusKYC)
+	assert.Equal(t, uint(21), *consumer.FotoKtpID)
+	assert.Equal(t, domain.JenisDokumenKTP, consumer.FotoKtp.Jenis)
+	assert.Equal(t, uint(22), *consumer.FotoSelfieID)
+	// Key penyimpanan tidak memuat NIK konsumen.
+	assert.NotContains(t, consumer.FotoKtp.StorageKey, input.Nik)
+	assert.FileExists(t, filepath.Join(storageDir, consumer.FotoKtp.StorageKey))
+	assert.FileExists(t, filepath.Join(storageDir, consumer.FotoSelfie.StorageKey))
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockConsumerRepo.AssertExpectations(t)
+	mockDocumentRepo.AssertExpectations(t)
+}
+
+func TestConsumerUsecase_CreateConsumer_RejectsInvalidDocument(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	documents := NewDocumentStore(new(MockConsumerDocumentRepository), nil, DefaultDocumentPolicy)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, documents)
+
+	input := CreateConsumerInput{
+		Nik:          "3273010101000001",
+		Email:        "consumer@example.com",
+		TanggalLahir: "2000-01-01",
+		FotoKtp: &DocumentUpload{
+			NamaFile:    "ktp.jpg",
+			ContentType: "image/jpeg",
+			Isi:         strings.NewReader("<?php echo 'bukan foto'; ?>"),
+		},
+	}
+
+	// Act
+	consumer, err := usecase.CreateConsumer(input)
+
+	// Assert
+	assert.Nil(t, consumer)
+	assert.EqualError(t, err, "foto_ktp must be a JPEG or PNG image")
+	mockUserRepo.AssertNotCalled(t, "FindByEmail", mock.Anything)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestConsumerUsecase_CreateConsumer_DerivesJenisKelaminFromNIK(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+	// Tanggal lahir perempuan dicatat di NIK dengan tanggal ditambah 40: 20 Agustus 1992 menjadi 600892.
+	input := CreateConsumerInput{
+		Nik:          "3174066008920001",
+		FullName:     "Annisa Fitriani",
+		Email:        "annisa@example.com",
+		Password:     "password123",
+		TanggalLahir: "1992-08-20",
+	}
+
+	mockSQL.ExpectBegin()
+	mockUserRepo.On("FindByEmail", input.Email).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockConsumerRepo.On("FindByNIK", input.Nik).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockUserRepo.On("Save", mock.AnythingOfType("*domain.User")).Return(nil).Once()
+	mockConsumerRepo.On("Save", mock.AnythingOfType("*domain.Consumer")).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	consumer, err := usecase.CreateConsumer(input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.JenisKelaminPerempuan, consumer.JenisKelamin)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockConsumerRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestConsumerUsecase_CreateConsumer_RejectsInvalidNIK(t *testing.T) {
+	tests := []struct {
+		name         string
+		nik          string
+		tanggalLahir string
+		wantErr      string
+	}{
+		{
+			name:         "bukan angka",
+			nik:          "32730101010000AB",
+			tanggalLahir: "2000-01-01",
+			wantErr:      "invalid NIK: must be 16 digits",
+		},
+		{
+			name:         "kode provinsi tidak dikenal",
+			nik:          "9973010101000001",
+			tanggalLahir: "2000-01-01",
+			wantErr:      "invalid NIK: unknown province code 99",
+		},
+		{
+			name:         "kode kabupaten/kota tidak dikenal",
+			nik:          "3299010101000001",
+			tanggalLahir: "2000-01-01",
+			wantErr:      "invalid NIK: unknown regency/city code 3299 in Jawa Barat",
+		},
+		{
+			name:         "tanggal lahir tidak valid",
+			nik:          "3273013102000001",
+			tanggalLahir: "2000-02-29",
+			wantErr:      "invalid NIK: invalid birth date 310200",
+		},
+		{
+			name:         "nomor urut nol",
+			nik:          "3273010101000000",
+			tanggalLahir: "2000-01-01",
+			wantErr:      "invalid NIK: serial number must not be 0000",
+		},
+		{
+			name:         "tanggal lahir tidak sesuai NIK",
+			nik:          "3273010101000001",
+			tanggalLahir: "2000-01-02",
+			wantErr:      "tanggal_lahir 2000-01-02 does not match the birth date encoded in NIK (01-01-00)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Arrange
+				gormDB, mockSQL, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+				usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+				input := CreateConsumerInput{Nik: tt.nik, Email: "new@example.com", TanggalLahir: tt.tanggalLahir}
+
+				mockSQL.ExpectBegin()
+				mockUserRepo.On("FindByEmail", input.Email).Return(nil, gorm.ErrRecordNotFound).Once()
+				mockConsumerRepo.On("FindByNIK", input.Nik).Return(nil, gorm.ErrRecordNotFound).Once()
+				mockSQL.ExpectRollback()
+
+				// Act
+				consumer, err := usecase.CreateConsumer(input)
+
+				// Assert
+				assert.Nil(t, consumer)
+				assert.EqualError(t, err, tt.wantErr)
+				mockUserRepo.AssertNotCalled(t, "Save", mock.Anything)
+				mockConsumerRepo.AssertNotCalled(t, "Save", mock.Anything)
+				assert.NoError(t, mockSQL.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
 // --- Test untuk GetConsumerByID ---
 
 func TestConsumerUsecase_GetConsumerByID_Success(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	expectedConsumer := &domain.Consumer{ID: 1, FullName: "Test User"}
 
 	mockConsumerRepo.On("FindByID", uint(1)).Return(expectedConsumer, nil).Once()
@@ -163,7 +363,7 @@ func TestConsumerUsecase_GetConsumerByID_Success(t *testing.T) {
 func TestConsumerUsecase_GetConsumerByID_NotFound(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 
 	mockConsumerRepo.On("FindByID", uint(1)).Return(nil, gorm.ErrRecordNotFound).Once()
 
@@ -177,51 +377,328 @@ func TestConsumerUsecase_GetConsumerByID_NotFound(t *testing.T) {
 	mockConsumerRepo.AssertExpectations(t)
 }
 
-// --- Test untuk GetAllConsumers ---
+// --- Test untuk ListConsumers ---
 
-func TestConsumerUsecase_GetAllConsumers_Success(t *testing.T) {
+func TestConsumerUsecase_ListConsumers_OffsetPagination(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	expectedConsumers := []*domain.Consumer{
-		{ID: 1, FullName: "User Satu"},
-		{ID: 2, FullName: "User Dua"},
+		{ID: 21, FullName: "User Satu"},
+		{ID: 22, FullName: "User Dua"},
 	}
+	expectedFilter := domain.ConsumerListFilter{UrutBerdasarkan: domain.UrutKonsumenID, Offset: 20, Limit: 20}
+
+	mockConsumerRepo.On("Count", expectedFilter).Return(int64(22), nil).Once()
+	mockConsumerRepo.On("FindPage", expectedFilter).Return(expectedConsumers, nil).Once()
+
+	// Act
+	output, err := usecase.ListConsumers(ConsumerListQuery{Page: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, output.Consumers, 2)
+	assert.Equal(t, 2, output.Pagination.Page)
+	assert.Equal(t, DefaultConsumerListLimit, output.Pagination.Limit)
+	assert.Equal(t, int64(22), *output.Pagination.Total)
+	assert.Empty(t, output.Pagination.NextCursor)
+	mockConsumerRepo.AssertExpectations(t)
+}
+
+func TestConsumerUsecase_ListConsumers_Empty(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 
-	mockConsumerRepo.On("FindAll").Return(expectedConsumers, nil).Once()
+	mockConsumerRepo.On("Count", mock.Anything).Return(int64(0), nil).Once()
+	mockConsumerRepo.On("FindPage", mock.Anything).Return([]*domain.Consumer{}, nil).Once()
 
 	// Act
-	consumers, err := usecase.GetAllConsumers()
+	output, err := usecase.ListConsumers(ConsumerListQuery{})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Len(t, consumers, 2)
+	assert.Len(t, output.Consumers, 0)
+	assert.Equal(t, 1, output.Pagination.Page)
+	mockConsumerRepo.AssertExpectations(t)
+}
+
+func TestConsumerUsecase_ListConsumers_ParsesFilters(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+	hasActiveContract := true
+	createdFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	expectedFilter := domain.ConsumerListFilter{
+		Nik:               "3271011505900001",
+		StatusKYC:         []string{domain.StatusKYCSubmitted, domain.StatusKYCApproved},
+		PunyaKontrakAktif: &hasActiveContract,
+		DibuatDari:        &createdFrom,
+		DibuatSebelum:     &createdBefore,
+		UrutBerdasarkan:   domain.UrutKonsumenCreatedAt,
+		UrutMenurun:       true,
+		Limit:             10,
+		Include:           []string{domain.RelasiKonsumenUser, domain.RelasiKonsumenCreditLimits},
+	}
+
+	mockConsumerRepo.On("Count", expectedFilter).Return(int64(1), nil).Once()
+	mockConsumerRepo.On("FindPage", expectedFilter).Return([]*domain.Consumer{{ID: 1}}, nil).Once()
+	mockConsumerRepo.On(
+		"Count", mock.MatchedBy(
+			func(filter domain.ConsumerListFilter) bool {
+				return filter.Nama == "budi" && filter.Nik == ""
+			},
+		),
+	).Return(int64(0), nil).Once()
+	mockConsumerRepo.On("FindPage", mock.Anything).Return([]*domain.Consumer{}, nil).Once()
+
+	// Act
+	_, nikErr := usecase.ListConsumers(
+		ConsumerListQuery{
+			Q:                 " 3271011505900001 ",
+			StatusKYC:         "submitted,APPROVED",
+			HasActiveContract: "true",
+			CreatedFrom:       "2026-01-01",
+			CreatedTo:         "2026-01-31",
+			Sort:              "-created_at",
+			Include:           "user, credit_limits,user",
+			Limit:             10,
+		},
+	)
+	_, nameErr := usecase.ListConsumers(ConsumerListQuery{Q: "budi"})
+
+	// Assert
+	assert.NoError(t, nikErr)
+	assert.NoError(t, nameErr)
 	mockConsumerRepo.AssertExpectations(t)
 }
 
-func TestConsumerUsecase_GetAllConsumers_Empty(t *testing.T) {
+func TestNameTokens(t *testing.T) {
+	testCases := []struct {
+		name string
+		want []string
+	}{
+		{name: "Budi Santoso", want: []string{"budi", "santoso"}},
+		{name: "  BUDI   santoso\t", want: []string{"budi", "santoso"}},
+		{name: "Siti Siti Aminah", want: []string{"siti", "aminah"}},
+		{name: "   ", want: nil},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, domain.NameTokens(tc.name), "name %q", tc.name)
+	}
+}
+
+func TestConsumerUsecase_ListConsumers_CursorPagination(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
-	expectedConsumers := []*domain.Consumer{}
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+	firstPage := []*domain.Consumer{
+		{ID: 7, OverallCreditLimit: domain.NewMoney(30000000)},
+		{ID: 3, OverallCreditLimit: domain.NewMoney(25000000)},
+		{ID: 9, OverallCreditLimit: domain.NewMoney(25000000)},
+	}
+
+	mockConsumerRepo.On(
+		"FindPage", mock.MatchedBy(
+			func(filter domain.ConsumerListFilter) bool {
+				return filter.Setelah == nil && filter.Limit == 3
+			},
+		),
+	).Return(firstPage, nil).Once()
+	mockConsumerRepo.On(
+		"FindPage", mock.MatchedBy(
+			func(filter domain.ConsumerListFilter) bool {
+				return filter.Setelah != nil &&
+					filter.Setelah.ID == 3 &&
+					filter.Setelah.Nilai == domain.NewMoney(25000000) &&
+					filter.UrutMenurun
+			},
+		),
+	).Return([]*domain.Consumer{firstPage[2]}, nil).Once()
+
+	// Act
+	page1, err := usecase.ListConsumers(
+		ConsumerListQuery{Pagination: "cursor", Sort: "-overall_credit_limit", Limit: 2},
+	)
+	assert.NoError(t, err)
+	page2, err := usecase.ListConsumers(
+		ConsumerListQuery{Cursor: page1.Pagination.NextCursor, Sort: "-overall_credit_limit", Limit: 2},
+	)
+	assert.NoError(t, err)
+	_, otherSortErr := usecase.ListConsumers(ConsumerListQuery{Cursor: page1.Pagination.NextCursor, Limit: 2})
+
+	// Assert
+	assert.Equal(t, []*domain.Consumer{firstPage[0], firstPage[1]}, page1.Consumers)
+	assert.NotEmpty(t, page1.Pagination.NextCursor)
+	assert.Nil(t, page1.Pagination.Total)
+	assert.Equal(t, []*domain.Consumer{firstPage[2]}, page2.Consumers)
+	assert.Empty(t, page2.Pagination.NextCursor)
+	assert.ErrorIs(t, otherSortErr, ErrInvalidConsumerListQuery)
+	mockConsumerRepo.AssertExpectations(t)
+}
 
-	mockConsumerRepo.On("FindAll").Return(expectedConsumers, nil).Once()
+func TestConsumerUsecase_ListConsumers_FiltersGajiAfterDecryption(t *testing.T) {
+	// Batch pertama berisi 10 konsumen (limit 2 x gajiFilterBatchFactor) dengan hanya konsumen 2 di dalam rentang
+	// gaji, sehingga batch berikutnya diambil sampai halaman penuh atau data habis.
+	firstBatch := make([]*domain.Consumer, 0, 2*gajiFilterBatchFactor)
+	for id := uint(1); id <= 2*gajiFilterBatchFactor; id++ {
+		firstBatch = append(firstBatch, &domain.Consumer{ID: id, Gaji: domain.NewMoney(4000000)})
+	}
+	firstBatch[1].Gaji = domain.NewMoney(8000000)
+	matching := &domain.Consumer{ID: 11, Gaji: domain.NewMoney(10000000)}
+	extra := &domain.Consumer{ID: 12, Gaji: domain.NewMoney(6000000)}
+	outOfRange := &domain.Consumer{ID: 13, Gaji: domain.NewMoney(15000000)}
+
+	testCases := []struct {
+		name        string
+		secondBatch []*domain.Consumer
+		wantCursor  bool
+	}{
+		{
+			name:        "masih ada konsumen cocok setelah halaman penuh",
+			secondBatch: []*domain.Consumer{matching, extra},
+			wantCursor:  true,
+		},
+		{
+			name:        "data habis tepat saat halaman penuh",
+			secondBatch: []*domain.Consumer{matching, outOfRange},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+				usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+				mockConsumerRepo.On(
+					"FindPage", mock.MatchedBy(
+						func(filter domain.ConsumerListFilter) bool {
+							return filter.Setelah == nil && filter.Limit == 2*gajiFilterBatchFactor
+						},
+					),
+				).Return(firstBatch, nil).Once()
+				mockConsumerRepo.On(
+					"FindPage", mock.MatchedBy(
+						func(filter domain.ConsumerListFilter) bool {
+							return filter.Setelah != nil && filter.Setelah.ID == 10
+						},
+					),
+				).Return(tc.secondBatch, nil).Once()
+
+				// Act
+				output, err := usecase.ListConsumers(
+					ConsumerListQuery{Pagination: "cursor", GajiMin: "5000000", GajiMax: "10000000", Limit: 2},
+				)
+
+				// Assert
+				assert.NoError(t, err)
+				assert.Equal(t, []*domain.Consumer{firstBatch[1], matching}, output.Consumers)
+				if tc.wantCursor {
+					cursor, err := decodeConsumerCursor(output.Pagination.NextCursor, domain.ConsumerListFilter{
+						UrutBerdasarkan: domain.UrutKonsumenID,
+					})
+					assert.NoError(t, err)
+					assert.Equal(t, matching.ID, cursor.ID)
+				} else {
+					assert.Empty(t, output.Pagination.NextCursor)
+				}
+				mockConsumerRepo.AssertExpectations(t)
+			},
+		)
+	}
+}
+
+func TestConsumerUsecase_ListConsumers_GajiFilterStopsAtScanLimit(t *testing.T) {
+	// Arrange: tidak ada konsumen yang cocok, sehingga pemeriksaan berhenti di gajiFilterScanLimit dan
+	// next_cursor menunjuk konsumen terakhir yang diperiksa.
+	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+	batchSize := 2 * gajiFilterBatchFactor
+	for start := uint(1); start <= gajiFilterScanLimit; start += uint(batchSize) {
+		batch := make([]*domain.Consumer, 0, batchSize)
+		for id := start; id < start+uint(batchSize); id++ {
+			batch = append(batch, &domain.Consumer{ID: id, Gaji: domain.NewMoney(4000000)})
+		}
+		after := start - 1
+		mockConsumerRepo.On(
+			"FindPage", mock.MatchedBy(
+				func(filter domain.ConsumerListFilter) bool {
+					return (filter.Setelah == nil && after == 0) || (filter.Setelah != nil && filter.Setelah.ID == after)
+				},
+			),
+		).Return(batch, nil).Once()
+	}
 
 	// Act
-	consumers, err := usecase.GetAllConsumers()
+	output, err := usecase.ListConsumers(
+		ConsumerListQuery{Pagination: "cursor", GajiMin: "5000000", GajiMax: "10000000", Limit: 2},
+	)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Len(t, consumers, 0)
+	assert.Empty(t, output.Consumers)
+	cursor, err := decodeConsumerCursor(output.Pagination.NextCursor, domain.ConsumerListFilter{
+		UrutBerdasarkan: domain.UrutKonsumenID,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(gajiFilterScanLimit), cursor.ID)
 	mockConsumerRepo.AssertExpectations(t)
 }
 
+func TestConsumerUsecase_ListConsumers_GajiFilterRequiresCursor(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+
+	// Act
+	_, err := usecase.ListConsumers(ConsumerListQuery{GajiMin: "5000000"})
+
+	// Assert
+	assert.ErrorIs(t, err, ErrInvalidConsumerListQuery)
+	mockConsumerRepo.AssertNotCalled(t, "FindPage", mock.Anything)
+}
+
+func TestConsumerUsecase_ListConsumers_InvalidQuery(t *testing.T) {
+	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+
+	tests := []struct {
+		name  string
+		query ConsumerListQuery
+	}{
+		{name: "limit terlalu besar", query: ConsumerListQuery{Limit: MaxConsumerListLimit + 1}},
+		{name: "status KYC tidak dikenal", query: ConsumerListQuery{StatusKYC: "PENDING"}},
+		{name: "sort kolom terenkripsi", query: ConsumerListQuery{Sort: "gaji"}},
+		{name: "include tidak dikenal", query: ConsumerListQuery{Include: "payments"}},
+		{name: "rentang gaji terbalik", query: ConsumerListQuery{Pagination: "cursor", GajiMin: "9", GajiMax: "1"}},
+		{name: "tanggal tidak valid", query: ConsumerListQuery{CreatedFrom: "01-01-2026"}},
+		{name: "has_active_contract tidak valid", query: ConsumerListQuery{HasActiveContract: "ya"}},
+		{name: "page dengan cursor", query: ConsumerListQuery{Pagination: "cursor", Page: 2}},
+		{name: "cursor rusak", query: ConsumerListQuery{Cursor: "bukan-cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				output, err := usecase.ListConsumers(tt.query)
+
+				assert.Nil(t, output)
+				assert.ErrorIs(t, err, ErrInvalidConsumerListQuery)
+			},
+		)
+	}
+	mockConsumerRepo.AssertNotCalled(t, "FindPage", mock.Anything)
+}
+
 // --- Test untuk UpdateConsumer ---
 
 func TestConsumerUsecase_UpdateConsumer_Success(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	idToUpdate := uint(1)
 	newName := "Updated Name"
 	input := UpdateConsumerInput{FullName: &newName}
@@ -244,7 +721,7 @@ func TestConsumerUsecase_UpdateConsumer_Success(t *testing.T) {
 func TestConsumerUsecase_UpdateConsumer_NotFound(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	idToUpdate := uint(99)
 	newName := "Updated Name"
 	input := UpdateConsumerInput{FullName: &newName}
@@ -261,12 +738,33 @@ func TestConsumerUsecase_UpdateConsumer_NotFound(t *testing.T) {
 	mockConsumerRepo.AssertExpectations(t)
 }
 
+func TestConsumerUsecase_UpdateConsumer_RejectsBirthDateMismatchWithNIK(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
+	idToUpdate := uint(1)
+	tanggalLahir := "1990-05-16"
+	input := UpdateConsumerInput{TanggalLahir: &tanggalLahir}
+	existingConsumer := &domain.Consumer{ID: idToUpdate, Nik: "3271011505900001"}
+
+	mockConsumerRepo.On("FindByID", idToUpdate).Return(existingConsumer, nil).Once()
+
+	// Act
+	consumer, err := usecase.UpdateConsumer(idToUpdate, input)
+
+	// Assert
+	assert.Nil(t, consumer)
+	assert.EqualError(t, err, "tanggal_lahir 1990-05-16 does not match the birth date encoded in NIK (15-05-90)")
+	mockConsumerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockConsumerRepo.AssertExpectations(t)
+}
+
 // --- Test untuk DeleteConsumer ---
 
 func TestConsumerUsecase_DeleteConsumer_Success(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	idToDelete := uint(1)
 
 	mockConsumerRepo.On("FindByID", idToDelete).Return(&domain.Consumer{ID: idToDelete}, nil).Once()
@@ -283,7 +781,7 @@ func TestConsumerUsecase_DeleteConsumer_Success(t *testing.T) {
 func TestConsumerUsecase_DeleteConsumer_NotFound(t *testing.T) {
 	// Arrange
 	gormDB, _, mockConsumerRepo, mockUserRepo := setupMocksForConsumerTest(t)
-	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo)
+	usecase := NewConsumerUsecase(gormDB, mockConsumerRepo, mockUserRepo, DefaultLimitReviewPolicy, nil)
 	idToDelete := uint(99)
 
 	mockConsumerRepo.On("FindByID", idToDelete).Return(nil, gorm.ErrRecordNotFound).Once()
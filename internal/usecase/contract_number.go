@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// DefaultContractNumberFormat menghasilkan nomor seperti KP/PST/202610/000001-8.
+const DefaultContractNumberFormat = "KP/{branch}/{YYYYMM}/{seq:06}-{check}"
+
+// DefaultBranchCode dipakai jika CONTRACT_BRANCH_CODE tidak dikonfigurasi.
+const DefaultBranchCode = "PST"
+
+// maxContractNumberLength mengikuti panjang kolom transactions.nomor_kontrak.
+const maxContractNumberLength = 50
+
+var (
+	contractNumberTokenPattern = regexp.MustCompile(`\{([A-Za-z]+)(?::(\d+))?\}`)
+	branchCodePattern          = regexp.MustCompile(`^[A-Z0-9]{2,10}$`)
+)
+
+// ContractNumberFormat adalah pola nomor kontrak yang sudah divalidasi. Token yang didukung:
+// {branch}, {YYYY}, {YY}, {MM}, {YYYYMM}, {seq} atau {seq:0N} (nomor urut dengan lebar N),
+// dan {check} (check digit Luhn atas seluruh digit lain pada nomor kontrak).
+type ContractNumberFormat struct {
+	pattern string
+}
+
+// ParseContractNumberFormat memvalidasi pola nomor kontrak. Pola wajib memuat {branch}, {seq}, dan
+// periode bulan ({YYYYMM}, atau {MM} bersama {YYYY}/{YY}) karena nomor urut di-reset per cabang per bulan;
+// tanpa token tersebut nomor kontrak bisa berulang. String kosong menghasilkan DefaultContractNumberFormat.
+func ParseContractNumberFormat(raw string) (ContractNumberFormat, error) {
+	pattern := strings.TrimSpace(raw)
+	if pattern == "" {
+		pattern = DefaultContractNumberFormat
+	}
+
+	seen := make(map[string]int)
+	for _, match := range contractNumberTokenPattern.FindAllStringSubmatch(pattern, -1) {
+		token, width := match[1], match[2]
+		switch token {
+		case "branch", "YYYY", "YY", "MM", "YYYYMM", "check":
+			if width != "" {
+				return ContractNumberFormat{}, fmt.Errorf("token {%s} does not accept a width", token)
+			}
+		case "seq":
+		default:
+			return ContractNumberFormat{}, fmt.Errorf("unknown token {%s} in contract number format", token)
+		}
+		seen[token]++
+	}
+
+	if seen["seq"] != 1 || seen["branch"] == 0 {
+		return ContractNumberFormat{}, fmt.Errorf("contract number format must contain {branch} and exactly one {seq}")
+	}
+	if seen["YYYYMM"] == 0 && (seen["MM"] == 0 || seen["YYYY"]+seen["YY"] == 0) {
+		return ContractNumberFormat{}, fmt.Errorf("contract number format must contain {YYYYMM} or {MM} with {YYYY}/{YY}")
+	}
+	if seen["check"] > 1 {
+		return ContractNumberFormat{}, fmt.Errorf("contract number format may contain at most one {check}")
+	}
+
+	format := ContractNumberFormat{pattern: pattern}
+	// Pastikan nomor terpanjang yang wajar tetap muat di kolom database.
+	sample := format.render(strings.Repeat("X", 10), time.Date(2099, 12, 1, 0, 0, 0, 0, time.UTC), 999999)
+	if len(sample) > maxContractNumberLength {
+		return ContractNumberFormat{}, fmt.Errorf("contract number format produces numbers longer than %d characters", maxContractNumberLength)
+	}
+	return format, nil
+}
+
+// ParseBranchCode menormalisasi kode cabang menjadi huruf besar (2-10 karakter alfanumerik).
+// String kosong menghasilkan DefaultBranchCode.
+func ParseBranchCode(raw string) (string, error) {
+	branch := strings.ToUpper(strings.TrimSpace(raw))
+	if branch == "" {
+		return DefaultBranchCode, nil
+	}
+	if !branchCodePattern.MatchString(branch) {
+		return "", fmt.Errorf("invalid branch code %q, expected 2-10 alphanumeric characters", raw)
+	}
+	return branch, nil
+}
+
+// render menyusun nomor kontrak dari pola. Check digit dihitung setelah seluruh token lain terisi.
+func (f ContractNumberFormat) render(branch string, tanggal time.Time, seq int64) string {
+	const checkPlaceholder = "\x00"
+
+	rendered := contractNumberTokenPattern.ReplaceAllStringFunc(
+		f.pattern, func(token string) string {
+			match := contractNumberTokenPattern.FindStringSubmatch(token)
+			switch match[1] {
+			case "branch":
+				return branch
+			case "YYYY":
+				return tanggal.Format("2006")
+			case "YY":
+				return tanggal.Format("06")
+			case "MM":
+				return tanggal.Format("01")
+			case "YYYYMM":
+				return tanggal.Format("200601")
+			case "seq":
+				width, _ := strconv.Atoi(match[2])
+				return fmt.Sprintf("%0*d", width, seq)
+			case "check":
+				return checkPlaceholder
+			}
+			return token
+		},
+	)
+
+	if !strings.Contains(rendered, checkPlaceholder) {
+		return rendered
+	}
+	var digits strings.Builder
+	for _, c := range rendered {
+		if c >= '0' && c <= '9' {
+			digits.WriteRune(c)
+		}
+	}
+	return strings.Replace(rendered, checkPlaceholder, strconv.Itoa(luhnCheckDigit(digits.String())), 1)
+}
+
+// luhnCheckDigit menghitung check digit Luhn (mod 10) untuk deretan digit.
+func luhnCheckDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+// ContractNumberGenerator menerbitkan nomor kontrak yang unik dari nomor urut di database.
+type ContractNumberGenerator interface {
+	WithTx(tx *gorm.DB) ContractNumberGenerator
+	Generate(tanggalKontrak time.Time) (string, error)
+}
+
+type contractNumberGenerator struct {
+	sequenceRepo domain.ContractSequenceRepository
+	format       ContractNumberFormat
+	branch       string
+}
+
+func NewContractNumberGenerator(
+	sequenceRepo domain.ContractSequenceRepository,
+	format ContractNumberFormat,
+	branch string,
+) ContractNumberGenerator {
+	return &contractNumberGenerator{sequenceRepo: sequenceRepo, format: format, branch: branch}
+}
+
+// WithTx mengikat pengambilan nomor urut ke transaksi database pemanggil, sehingga nomor urut baru
+// benar-benar terpakai jika kontrak berhasil disimpan.
+func (g *contractNumberGenerator) WithTx(tx *gorm.DB) ContractNumberGenerator {
+	return &contractNumberGenerator{sequenceRepo: g.sequenceRepo.WithTx(tx), format: g.format, branch: g.branch}
+}
+
+// Generate mengambil nomor urut berikutnya untuk cabang dan bulan tanggalKontrak lalu menyusun nomor kontrak.
+func (g *contractNumberGenerator) Generate(tanggalKontrak time.Time) (string, error) {
+	seq, err := g.sequenceRepo.Next(fmt.Sprintf("%s/%s", g.branch, tanggalKontrak.Format("200601")))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate contract number: %w", err)
+	}
+	return g.format.render(g.branch, tanggalKontrak, seq), nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuhnCheckDigit(t *testing.T) {
+	assert.Equal(t, 3, luhnCheckDigit("7992739871"))
+	assert.Equal(t, 0, luhnCheckDigit("202610000001"))
+}
+
+func TestContractNumberGenerator_DefaultFormat(t *testing.T) {
+	// Arrange
+	sequenceRepo := new(MockContractSequenceRepository)
+	format, err := ParseContractNumberFormat("")
+	assert.NoError(t, err)
+	generator := NewContractNumberGenerator(sequenceRepo, format, "JKT")
+
+	sequenceRepo.On("Next", "JKT/202610").Return(int64(1), nil).Once()
+	sequenceRepo.On("Next", "JKT/202611").Return(int64(1), nil).Once()
+
+	// Act
+	oktober, err := generator.Generate(time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	november, err := generator.Generate(time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	// Assert: nomor urut di-reset per bulan, kunci nomor urut berbeda per periode
+	assert.Equal(t, "KP/JKT/202610/000001-0", oktober)
+	assert.Equal(t, "KP/JKT/202611/000001-8", november)
+	sequenceRepo.AssertExpectations(t)
+}
+
+func TestContractNumberGenerator_CustomFormat(t *testing.T) {
+	// Arrange
+	sequenceRepo := new(MockContractSequenceRepository)
+	format, err := ParseContractNumberFormat("{branch}-{YY}{MM}-{seq:04}")
+	assert.NoError(t, err)
+	generator := NewContractNumberGenerator(sequenceRepo, format, "BDG")
+
+	sequenceRepo.On("Next", "BDG/202603").Return(int64(42), nil).Once()
+
+	// Act
+	number, err := generator.Generate(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "BDG-2603-0042", number)
+}
+
+func TestContractNumberGenerator_SequenceError(t *testing.T) {
+	// Arrange
+	sequenceRepo := new(MockContractSequenceRepository)
+	format, _ := ParseContractNumberFormat("")
+	generator := NewContractNumberGenerator(sequenceRepo, format, DefaultBranchCode)
+
+	sequenceRepo.On("Next", "PST/202603").Return(int64(0), errors.New("db down")).Once()
+
+	// Act
+	_, err := generator.Generate(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestParseContractNumberFormat_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		"KP/{branch}/{YYYYMM}",                 // tanpa nomor urut
+		"KP/{YYYYMM}/{seq:06}",                 // tanpa cabang
+		"KP/{branch}/{YYYY}/{seq:06}",          // tanpa bulan
+		"KP/{branch}/{YYYYMM}/{seq}/{seq}",     // nomor urut ganda
+		"KP/{branch}/{YYYYMM}/{seq}/{unknown}", // token tidak dikenal
+		"KP/{branch:3}/{YYYYMM}/{seq}",         // lebar hanya untuk seq
+		"KONTRAK-PEMBIAYAAN-KONSUMEN/{branch}/{YYYYMM}/{seq:020}",
+	} {
+		_, err := ParseContractNumberFormat(raw)
+		assert.Error(t, err, raw)
+	}
+
+	_, err := ParseBranchCode("jakarta selatan")
+	assert.Error(t, err)
+
+	branch, err := ParseBranchCode("jkt")
+	assert.NoError(t, err)
+	assert.Equal(t, "JKT", branch)
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockContractSequenceRepository adalah implementasi mock dari domain.ContractSequenceRepository.
+type MockContractSequenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockContractSequenceRepository) WithTx(tx *gorm.DB) domain.ContractSequenceRepository {
+	return m
+}
+
+func (m *MockContractSequenceRepository) Next(kunci string) (int64, error) {
+	args := m.Called(kunci)
+	return args.Get(0).(int64), args.Error(1)
+}
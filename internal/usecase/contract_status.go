@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// DefaultCoolingOffPeriod adalah jangka waktu sejak tanggal kontrak di mana kontrak masih boleh dibatalkan.
+const DefaultCoolingOffPeriod = 14 * 24 * time.Hour
+
+// ParseCoolingOffPeriod mengurai jumlah hari masa cooling-off dari konfigurasi.
+// String kosong menghasilkan DefaultCoolingOffPeriod.
+func ParseCoolingOffPeriod(raw string) (time.Duration, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultCoolingOffPeriod, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid cooling-off period %q, expected a non-negative number of days", raw)
+	}
+	return time.Duration(days) * 24 * time.Hour, nil
+}
+
+// changeContractStatus memvalidasi transisi status kontrak, mengisi Catatan dengan alasan perubahan,
+// dan mencatat riwayatnya. Pemanggil bertanggung jawab menyimpan transaksi di dalam transaksi database yang sama.
+func changeContractStatus(
+	trx *domain.Transaction,
+	toStatus string,
+	alasan string,
+	changedBy *uint,
+	historyRepo domain.TransactionStatusHistoryRepository,
+) error {
+	if !trx.CanTransitionTo(toStatus) {
+		return fmt.Errorf("cannot change contract status from %s to %s", trx.StatusKontrak, toStatus)
+	}
+
+	history := &domain.TransactionStatusHistory{
+		TransactionID: trx.ID,
+		FromStatus:    trx.StatusKontrak,
+		ToStatus:      toStatus,
+		Alasan:        alasan,
+		ChangedBy:     changedBy,
+	}
+	trx.StatusKontrak = toStatus
+	trx.Catatan = alasan
+
+	return historyRepo.Save(history)
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// DefaultFreezeDPDThreshold adalah hari keterlambatan di mana kredit konsumen otomatis dibekukan.
+const DefaultFreezeDPDThreshold = 30
+
+// ParseFreezeDPDThreshold mengurai ambang hari keterlambatan untuk pembekuan otomatis dari konfigurasi.
+// String kosong menghasilkan DefaultFreezeDPDThreshold, sedangkan 0 menonaktifkan pembekuan otomatis.
+func ParseFreezeDPDThreshold(raw string) (int, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultFreezeDPDThreshold, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || days < 0 {
+		return 0, fmt.Errorf("invalid freeze DPD threshold %q, expected a non-negative number of days", raw)
+	}
+	return days, nil
+}
+
+// checkCreditNotFrozen menolak penarikan pinjaman baru jika konsumen atau limit tenornya sedang dibekukan.
+func checkCreditNotFrozen(consumer *domain.Consumer, creditLimit *domain.ConsumerCreditLimit) error {
+	if consumer.Dibekukan {
+		violation := newRuleViolation(
+			RuleConsumerFrozen, "",
+			"consumer credit is frozen (%s), new loans are not allowed", consumer.KodePembekuan,
+		)
+		violation.cause = ErrCreditFrozen
+		return violation
+	}
+	if creditLimit.Dibekukan {
+		violation := newRuleViolation(
+			RuleTenorLimitFrozen, "tenor_months",
+			"credit limit for tenor %d is frozen (%s), new loans are not allowed",
+			creditLimit.TenorMonths,
+			creditLimit.KodePembekuan,
+		)
+		violation.cause = ErrCreditFrozen
+		return violation
+	}
+	return nil
+}
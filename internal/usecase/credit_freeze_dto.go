@@ -0,0 +1,12 @@
+package usecase
+
+// FreezeCreditInput adalah kode alasan dan catatan pembekuan kredit. Kode harus salah satu dari
+// domain.KodePembekuanValid.
+type FreezeCreditInput struct {
+	Kode    string `json:"kode" binding:"required"`
+	Catatan string `json:"catatan" binding:"required,min=5"`
+}
+
+type UnfreezeCreditInput struct {
+	Alasan string `json:"alasan" binding:"required,min=5"`
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockCreditFreezeHistoryRepository adalah implementasi mock dari domain.CreditFreezeHistoryRepository.
+type MockCreditFreezeHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditFreezeHistoryRepository) WithTx(tx *gorm.DB) domain.CreditFreezeHistoryRepository {
+	return m
+}
+
+func (m *MockCreditFreezeHistoryRepository) Save(history *domain.CreditFreezeHistory) error {
+	args := m.Called(history)
+	return args.Error(0)
+}
+
+func (m *MockCreditFreezeHistoryRepository) FindByConsumerID(consumerID uint) ([]*domain.CreditFreezeHistory, error) {
+	args := m.Called(consumerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CreditFreezeHistory), args.Error(1)
+}
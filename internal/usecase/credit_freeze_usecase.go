@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CreditFreezeUsecase membekukan dan mencairkan kemampuan konsumen menarik pinjaman baru, baik untuk seluruh
+// kredit konsumen maupun untuk satu limit tenor, tanpa mengubah atau menghapus limitnya.
+type CreditFreezeUsecase interface {
+	FreezeConsumer(consumerID, changedBy uint, input FreezeCreditInput) (*domain.Consumer, error)
+	UnfreezeConsumer(consumerID, changedBy uint, input UnfreezeCreditInput) (*domain.Consumer, error)
+	FreezeCreditLimit(
+		consumerID uint,
+		tenorMonths int,
+		changedBy uint,
+		input FreezeCreditInput,
+	) (*domain.ConsumerCreditLimit, error)
+	UnfreezeCreditLimit(
+		consumerID uint,
+		tenorMonths int,
+		changedBy uint,
+		input UnfreezeCreditInput,
+	) (*domain.ConsumerCreditLimit, error)
+	GetFreezeHistory(consumerID uint) ([]*domain.CreditFreezeHistory, error)
+}
+
+type creditFreezeUsecase struct {
+	db           *gorm.DB
+	consumerRepo domain.ConsumerRepository
+	limitRepo    domain.ConsumerCreditLimitRepository
+	historyRepo  domain.CreditFreezeHistoryRepository
+}
+
+func NewCreditFreezeUsecase(
+	db *gorm.DB,
+	consumerRepo domain.ConsumerRepository,
+	limitRepo domain.ConsumerCreditLimitRepository,
+	historyRepo domain.CreditFreezeHistoryRepository,
+) CreditFreezeUsecase {
+	return &creditFreezeUsecase{
+		db:           db,
+		consumerRepo: consumerRepo,
+		limitRepo:    limitRepo,
+		historyRepo:  historyRepo,
+	}
+}
+
+// FreezeConsumer membekukan seluruh kredit konsumen. Baris konsumen dikunci agar pembekuan tidak bersamaan
+// dengan pembuatan transaksi.
+func (uc *creditFreezeUsecase) FreezeConsumer(
+	consumerID, changedBy uint,
+	input FreezeCreditInput,
+) (*domain.Consumer, error) {
+	kode, err := normalizeKodePembekuan(input.Kode)
+	if err != nil {
+		return nil, err
+	}
+
+	var frozen *domain.Consumer
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			consumerRepoTx := uc.consumerRepo.WithTx(tx)
+
+			consumer, err := consumerRepoTx.FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+			if consumer.Dibekukan {
+				return fmt.Errorf("consumer credit is already frozen (%s)", consumer.KodePembekuan)
+			}
+
+			now := time.Now()
+			updates := domain.FreezeUpdates(kode, input.Catatan, &changedBy, now)
+			if err := consumerRepoTx.Update(consumerID, updates); err != nil {
+				return err
+			}
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditFreezeHistory{
+					ConsumerID:    consumerID,
+					Aksi:          domain.PembekuanAksiFreeze,
+					KodePembekuan: kode,
+					Alasan:        input.Catatan,
+					ChangedBy:     &changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			consumer.CreditFreeze = domain.CreditFreeze{
+				Dibekukan:        true,
+				KodePembekuan:    kode,
+				CatatanPembekuan: input.Catatan,
+				DibekukanOleh:    &changedBy,
+				DibekukanPada:    &now,
+			}
+			frozen = consumer
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return frozen, nil
+}
+
+// UnfreezeConsumer mencairkan pembekuan kredit konsumen, termasuk pembekuan otomatis karena keterlambatan.
+func (uc *creditFreezeUsecase) UnfreezeConsumer(
+	consumerID, changedBy uint,
+	input UnfreezeCreditInput,
+) (*domain.Consumer, error) {
+	var unfrozen *domain.Consumer
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			consumerRepoTx := uc.consumerRepo.WithTx(tx)
+
+			consumer, err := consumerRepoTx.FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+			if !consumer.Dibekukan {
+				return fmt.Errorf("consumer credit is not frozen")
+			}
+
+			if err := consumerRepoTx.Update(consumerID, domain.UnfreezeUpdates()); err != nil {
+				return err
+			}
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditFreezeHistory{
+					ConsumerID:    consumerID,
+					Aksi:          domain.PembekuanAksiUnfreeze,
+					KodePembekuan: consumer.KodePembekuan,
+					Alasan:        input.Alasan,
+					ChangedBy:     &changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			consumer.CreditFreeze = domain.CreditFreeze{}
+			unfrozen = consumer
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unfrozen, nil
+}
+
+// FreezeCreditLimit membekukan penarikan pinjaman baru pada satu tenor; tenor lain tetap dapat dipakai.
+func (uc *creditFreezeUsecase) FreezeCreditLimit(
+	consumerID uint,
+	tenorMonths int,
+	changedBy uint,
+	input FreezeCreditInput,
+) (*domain.ConsumerCreditLimit, error) {
+	kode, err := normalizeKodePembekuan(input.Kode)
+	if err != nil {
+		return nil, err
+	}
+
+	var frozen *domain.ConsumerCreditLimit
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			limit, err := uc.lockLimit(tx, consumerID, tenorMonths)
+			if err != nil {
+				return err
+			}
+			if limit.Dibekukan {
+				return fmt.Errorf("credit limit for tenor %d is already frozen (%s)", tenorMonths, limit.KodePembekuan)
+			}
+
+			now := time.Now()
+			updates := domain.FreezeUpdates(kode, input.Catatan, &changedBy, now)
+			if err := uc.limitRepo.WithTx(tx).Update(limit.ID, updates); err != nil {
+				return err
+			}
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditFreezeHistory{
+					ConsumerID:            consumerID,
+					ConsumerCreditLimitID: &limit.ID,
+					TenorMonths:           tenorMonths,
+					Aksi:                  domain.PembekuanAksiFreeze,
+					KodePembekuan:         kode,
+					Alasan:                input.Catatan,
+					ChangedBy:             &changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			limit.CreditFreeze = domain.CreditFreeze{
+				Dibekukan:        true,
+				KodePembekuan:    kode,
+				CatatanPembekuan: input.Catatan,
+				DibekukanOleh:    &changedBy,
+				DibekukanPada:    &now,
+			}
+			frozen = limit
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return frozen, nil
+}
+
+// UnfreezeCreditLimit mencairkan pembekuan pada satu tenor.
+func (uc *creditFreezeUsecase) UnfreezeCreditLimit(
+	consumerID uint,
+	tenorMonths int,
+	changedBy uint,
+	input UnfreezeCreditInput,
+) (*domain.ConsumerCreditLimit, error) {
+	var unfrozen *domain.ConsumerCreditLimit
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			limit, err := uc.lockLimit(tx, consumerID, tenorMonths)
+			if err != nil {
+				return err
+			}
+			if !limit.Dibekukan {
+				return fmt.Errorf("credit limit for tenor %d is not frozen", tenorMonths)
+			}
+
+			if err := uc.limitRepo.WithTx(tx).Update(limit.ID, domain.UnfreezeUpdates()); err != nil {
+				return err
+			}
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.CreditFreezeHistory{
+					ConsumerID:            consumerID,
+					ConsumerCreditLimitID: &limit.ID,
+					TenorMonths:           tenorMonths,
+					Aksi:                  domain.PembekuanAksiUnfreeze,
+					KodePembekuan:         limit.KodePembekuan,
+					Alasan:                input.Alasan,
+					ChangedBy:             &changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			limit.CreditFreeze = domain.CreditFreeze{}
+			unfrozen = limit
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return unfrozen, nil
+}
+
+// GetFreezeHistory mengambil riwayat pembekuan dan pencairan kredit konsumen, terlama lebih dulu.
+func (uc *creditFreezeUsecase) GetFreezeHistory(consumerID uint) ([]*domain.CreditFreezeHistory, error) {
+	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	return uc.historyRepo.FindByConsumerID(consumerID)
+}
+
+// lockLimit mengunci baris konsumen, lalu mengambil limit tenornya.
+func (uc *creditFreezeUsecase) lockLimit(
+	tx *gorm.DB,
+	consumerID uint,
+	tenorMonths int,
+) (*domain.ConsumerCreditLimit, error) {
+	if _, err := uc.consumerRepo.WithTx(tx).FindByIDForUpdate(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	limit, err := uc.limitRepo.WithTx(tx).FindByConsumerAndTenor(consumerID, tenorMonths)
+	if err != nil {
+		return nil, fmt.Errorf("credit limit for tenor %d not found for this consumer", tenorMonths)
+	}
+	return limit, nil
+}
+
+// normalizeKodePembekuan menyeragamkan kode alasan pembekuan dan menolak kode yang tidak dikenal.
+func normalizeKodePembekuan(kode string) (string, error) {
+	kode = strings.ToUpper(strings.TrimSpace(kode))
+	if !domain.IsValidKodePembekuan(kode) {
+		return "", fmt.Errorf(
+			"invalid freeze reason code: %s. allowed codes are %s",
+			kode,
+			strings.Join(domain.KodePembekuanValid, ", "),
+		)
+	}
+	return kode, nil
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type creditFreezeTestDeps struct {
+	mockSQL          sqlmock.Sqlmock
+	mockConsumerRepo *MockConsumerRepository
+	mockLimitRepo    *MockCreditLimitRepository
+	mockHistoryRepo  *MockCreditFreezeHistoryRepository
+}
+
+func setupCreditFreezeUsecase(t *testing.T) (CreditFreezeUsecase, creditFreezeTestDeps) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	deps := creditFreezeTestDeps{
+		mockSQL:          mockSQL,
+		mockConsumerRepo: new(MockConsumerRepository),
+		mockLimitRepo:    new(MockCreditLimitRepository),
+		mockHistoryRepo:  new(MockCreditFreezeHistoryRepository),
+	}
+	usecase := NewCreditFreezeUsecase(gormDB, deps.mockConsumerRepo, deps.mockLimitRepo, deps.mockHistoryRepo)
+	return usecase, deps
+}
+
+func TestFreezeConsumer_Success(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditFreezeUsecase(t)
+	consumer := &domain.Consumer{ID: 1}
+	input := FreezeCreditInput{Kode: "fraud_suspicion", Catatan: "Dokumen identitas diduga palsu"}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockConsumerRepo.On(
+		"Update", uint(1), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["dibekukan"] == true && updates["kode_pembekuan"] == domain.KodePembekuanFraud
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditFreezeHistory) bool {
+				return history.Aksi == domain.PembekuanAksiFreeze &&
+					history.KodePembekuan == domain.KodePembekuanFraud &&
+					history.ConsumerCreditLimitID == nil &&
+					*history.ChangedBy == 99
+			},
+		),
+	).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	frozen, err := usecase.FreezeConsumer(1, 99, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, frozen.Dibekukan)
+	assert.Equal(t, domain.KodePembekuanFraud, frozen.KodePembekuan)
+	assert.Equal(t, uint(99), *frozen.DibekukanOleh)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockConsumerRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestFreezeConsumer_InvalidCode(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditFreezeUsecase(t)
+
+	// Act
+	frozen, err := usecase.FreezeConsumer(1, 99, FreezeCreditInput{Kode: "BOSAN", Catatan: "Tidak ada alasan"})
+
+	// Assert
+	assert.Nil(t, frozen)
+	assert.EqualError(
+		t, err,
+		"invalid freeze reason code: BOSAN. allowed codes are FRAUD_SUSPICION, DPD_BREACH, KYC_EXPIRED, OTHER",
+	)
+	deps.mockConsumerRepo.AssertNotCalled(t, "FindByIDForUpdate", mock.Anything)
+}
+
+func TestUnfreezeConsumer_NotFrozen(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditFreezeUsecase(t)
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(&domain.Consumer{ID: 1}, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	unfrozen, err := usecase.UnfreezeConsumer(1, 99, UnfreezeCreditInput{Alasan: "Verifikasi selesai"})
+
+	// Assert
+	assert.Nil(t, unfrozen)
+	assert.EqualError(t, err, "consumer credit is not frozen")
+	deps.mockConsumerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	deps.mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestUnfreezeConsumer_KeepsFreezeCodeInHistory(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditFreezeUsecase(t)
+	consumer := &domain.Consumer{
+		ID:           1,
+		CreditFreeze: domain.CreditFreeze{Dibekukan: true, KodePembekuan: domain.KodePembekuanDPD},
+	}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockConsumerRepo.On("Update", uint(1), domain.UnfreezeUpdates()).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditFreezeHistory) bool {
+				return history.Aksi == domain.PembekuanAksiUnfreeze && history.KodePembekuan == domain.KodePembekuanDPD
+			},
+		),
+	).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	unfrozen, err := usecase.UnfreezeConsumer(1, 99, UnfreezeCreditInput{Alasan: "Tunggakan sudah dilunasi"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, unfrozen.Dibekukan)
+	assert.Empty(t, unfrozen.KodePembekuan)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockConsumerRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestFreezeCreditLimit_Success(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditFreezeUsecase(t)
+	limit := &domain.ConsumerCreditLimit{ID: 11, ConsumerID: 1, TenorMonths: 6, CreditLimit: domain.NewMoney(5000000)}
+	input := FreezeCreditInput{Kode: domain.KodePembekuanKYC, Catatan: "KTP sudah kedaluwarsa"}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(&domain.Consumer{ID: 1}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerAndTenor", uint(1), 6).Return(limit, nil).Once()
+	deps.mockLimitRepo.On("Update", uint(11), mock.AnythingOfType("map[string]interface {}")).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditFreezeHistory) bool {
+				return *history.ConsumerCreditLimitID == 11 && history.TenorMonths == 6
+			},
+		),
+	).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	frozen, err := usecase.FreezeCreditLimit(1, 6, 99, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, frozen.Dibekukan)
+	assert.Equal(t, domain.KodePembekuanKYC, frozen.KodePembekuan)
+	assert.Equal(t, domain.NewMoney(5000000), frozen.CreditLimit)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockLimitRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestFreezeCreditLimit_AlreadyFrozen(t *testing.T) {
+	// Arrange
+	usecase, deps := setupCreditFreezeUsecase(t)
+	limit := &domain.ConsumerCreditLimit{
+		ID:           11,
+		ConsumerID:   1,
+		TenorMonths:  6,
+		CreditFreeze: domain.CreditFreeze{Dibekukan: true, KodePembekuan: domain.KodePembekuanLainnya},
+	}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(&domain.Consumer{ID: 1}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerAndTenor", uint(1), 6).Return(limit, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	frozen, err := usecase.FreezeCreditLimit(1, 6, 99, FreezeCreditInput{Kode: "FRAUD_SUSPICION", Catatan: "Laporan"})
+
+	// Assert
+	assert.Nil(t, frozen)
+	assert.EqualError(t, err, "credit limit for tenor 6 is already frozen (OTHER)")
+	deps.mockLimitRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestParseFreezeDPDThreshold(t *testing.T) {
+	days, err := ParseFreezeDPDThreshold("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultFreezeDPDThreshold, days)
+
+	days, err = ParseFreezeDPDThreshold("0")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, days)
+
+	_, err = ParseFreezeDPDThreshold("-5")
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockCreditLimitHistoryRepository adalah implementasi mock dari domain.CreditLimitHistoryRepository.
+type MockCreditLimitHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditLimitHistoryRepository) WithTx(tx *gorm.DB) domain.CreditLimitHistoryRepository {
+	return m
+}
+
+func (m *MockCreditLimitHistoryRepository) Save(history *domain.CreditLimitHistory) error {
+	args := m.Called(history)
+	return args.Error(0)
+}
+
+func (m *MockCreditLimitHistoryRepository) FindByConsumerID(consumerID uint) ([]*domain.CreditLimitHistory, error) {
+	args := m.Called(consumerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CreditLimitHistory), args.Error(1)
+}
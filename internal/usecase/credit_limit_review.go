@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// LimitReviewPolicy mengatur masa berlaku plafon dan limit tenor, serta kapan limit yang akan berakhir mulai
+// diusulkan untuk ditinjau ulang.
+type LimitReviewPolicy struct {
+	// MasaBerlakuBulan adalah masa berlaku limit sejak ditetapkan atau ditinjau; nol berarti limit tidak kedaluwarsa.
+	MasaBerlakuBulan int
+	// HariSebelumBerakhir adalah jumlah hari sebelum limit berakhir di mana usulan peninjauan mulai dibuat.
+	HariSebelumBerakhir int
+}
+
+// DefaultLimitReviewPolicy memberlakukan limit selama 12 bulan dan meninjaunya 30 hari sebelum berakhir.
+var DefaultLimitReviewPolicy = LimitReviewPolicy{MasaBerlakuBulan: 12, HariSebelumBerakhir: 30}
+
+// ParseLimitReviewPolicy mengurai masa berlaku limit (bulan) dan jendela peninjauan (hari) dari konfigurasi.
+// String kosong memakai nilai DefaultLimitReviewPolicy, sedangkan masa berlaku 0 menonaktifkan kedaluwarsa limit.
+func ParseLimitReviewPolicy(validityMonths, reviewDays string) (LimitReviewPolicy, error) {
+	policy := DefaultLimitReviewPolicy
+	if strings.TrimSpace(validityMonths) != "" {
+		months, err := strconv.Atoi(strings.TrimSpace(validityMonths))
+		if err != nil || months < 0 {
+			return LimitReviewPolicy{}, fmt.Errorf(
+				"invalid credit limit validity %q, expected a non-negative number of months",
+				validityMonths,
+			)
+		}
+		policy.MasaBerlakuBulan = months
+	}
+	if strings.TrimSpace(reviewDays) != "" {
+		days, err := strconv.Atoi(strings.TrimSpace(reviewDays))
+		if err != nil || days < 0 {
+			return LimitReviewPolicy{}, fmt.Errorf(
+				"invalid credit limit review window %q, expected a non-negative number of days",
+				reviewDays,
+			)
+		}
+		policy.HariSebelumBerakhir = days
+	}
+	return policy, nil
+}
+
+// Enabled menandakan limit memiliki masa berlaku sehingga perlu ditinjau secara berkala.
+func (p LimitReviewPolicy) Enabled() bool {
+	return p.MasaBerlakuBulan > 0
+}
+
+// BerlakuSampai mengembalikan akhir masa berlaku limit yang ditetapkan pada waktu from, atau nil jika limit
+// tidak kedaluwarsa.
+func (p LimitReviewPolicy) BerlakuSampai(from time.Time) *time.Time {
+	if !p.Enabled() {
+		return nil
+	}
+	berlakuSampai := from.AddDate(0, p.MasaBerlakuBulan, 0)
+	return &berlakuSampai
+}
+
+// ReviewDueBefore mengembalikan batas waktu berakhirnya limit yang sudah perlu ditinjau pada waktu now.
+func (p LimitReviewPolicy) ReviewDueBefore(now time.Time) time.Time {
+	return now.AddDate(0, 0, p.HariSebelumBerakhir)
+}
+
+// checkLimitNotExpired menolak penarikan pinjaman baru jika masa berlaku plafon atau limit tenor sudah habis.
+func checkLimitNotExpired(consumer *domain.Consumer, creditLimit *domain.ConsumerCreditLimit, now time.Time) error {
+	if consumer.IsPlafonExpired(now) {
+		return newRuleViolation(
+			RuleOverallLimitExpired, "",
+			"overall credit limit expired on %s and is awaiting review",
+			consumer.PlafonBerlakuSampai.Format("2006-01-02"),
+		)
+	}
+	if creditLimit.IsExpired(now) {
+		return newRuleViolation(
+			RuleTenorLimitExpired, "tenor_months",
+			"credit limit for tenor %d expired on %s and is awaiting review",
+			creditLimit.TenorMonths,
+			creditLimit.BerlakuSampai.Format("2006-01-02"),
+		)
+	}
+	return nil
+}
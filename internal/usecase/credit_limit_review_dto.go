@@ -0,0 +1,21 @@
+package usecase
+
+import "github.com/adty404/kredit-plus/internal/domain"
+
+// ApproveLimitReviewInput adalah catatan opsional admin saat menyetujui usulan peninjauan limit.
+type ApproveLimitReviewInput struct {
+	Catatan string `json:"catatan"`
+}
+
+type RejectLimitReviewInput struct {
+	Catatan string `json:"catatan" binding:"required,min=5"`
+}
+
+// LimitReviewRunOutput merangkum hasil satu kali job peninjauan limit berkala.
+type LimitReviewRunOutput struct {
+	TanggalPeninjauan domain.JSONDate `json:"tanggal_peninjauan"`
+	KonsumenDitinjau  int             `json:"konsumen_ditinjau"`
+	UsulanKenaikan    int             `json:"usulan_kenaikan"`
+	UsulanPenurunan   int             `json:"usulan_penurunan"`
+	Errors            []string        `json:"errors,omitempty"`
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockCreditLimitReviewRepository adalah implementasi mock dari domain.CreditLimitReviewRepository.
+type MockCreditLimitReviewRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditLimitReviewRepository) WithTx(tx *gorm.DB) domain.CreditLimitReviewRepository {
+	return m
+}
+
+func (m *MockCreditLimitReviewRepository) Save(review *domain.CreditLimitReview) error {
+	args := m.Called(review)
+	return args.Error(0)
+}
+
+func (m *MockCreditLimitReviewRepository) Update(review *domain.CreditLimitReview) error {
+	args := m.Called(review)
+	return args.Error(0)
+}
+
+func (m *MockCreditLimitReviewRepository) FindByID(id uint) (*domain.CreditLimitReview, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreditLimitReview), args.Error(1)
+}
+
+func (m *MockCreditLimitReviewRepository) FindByIDForUpdate(id uint) (*domain.CreditLimitReview, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreditLimitReview), args.Error(1)
+}
+
+func (m *MockCreditLimitReviewRepository) FindByStatus(status string) ([]*domain.CreditLimitReview, error) {
+	args := m.Called(status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CreditLimitReview), args.Error(1)
+}
+
+func (m *MockCreditLimitReviewRepository) FindConsumerIDsDueForReview(dueBefore, reviewedAfter time.Time) (
+	[]uint,
+	error,
+) {
+	args := m.Called(dueBefore, reviewedAfter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// CreditLimitReviewUsecase mengelola peninjauan limit berkala: job membuat usulan plafon dan limit per tenor
+// dari scoring ulang untuk limit yang akan atau sudah berakhir, lalu admin menyetujui atau menolaknya.
+type CreditLimitReviewUsecase interface {
+	GenerateLimitReviews(now time.Time) (*LimitReviewRunOutput, error)
+	GetLimitReviews(status string) ([]*domain.CreditLimitReview, error)
+	GetLimitReviewByID(id uint) (*domain.CreditLimitReview, error)
+	ApproveLimitReview(id, changedBy uint, input ApproveLimitReviewInput) (*domain.CreditLimitReview, error)
+	RejectLimitReview(id, changedBy uint, input RejectLimitReviewInput) (*domain.CreditLimitReview, error)
+}
+
+type creditLimitReviewUsecase struct {
+	db         *gorm.DB
+	limits     *consumerCreditLimitUsecase
+	reviewRepo domain.CreditLimitReviewRepository
+}
+
+// NewCreditLimitReviewUsecase memakai scoring dan penerapan limit yang sama dengan ConsumerCreditLimitUsecase
+// sehingga usulan yang disetujui divalidasi dan dicatat seperti penerapan rekomendasi oleh admin.
+func NewCreditLimitReviewUsecase(
+	db *gorm.DB,
+	repo domain.ConsumerCreditLimitRepository,
+	consumerRepo domain.ConsumerRepository,
+	transactionRepo domain.TransactionRepository,
+	historyRepo domain.CreditLimitHistoryRepository,
+	reviewRepo domain.CreditLimitReviewRepository,
+	products ProductResolver,
+	scoringRules ScoringRules,
+	reviewPolicy LimitReviewPolicy,
+) CreditLimitReviewUsecase {
+	return &creditLimitReviewUsecase{
+		db: db,
+		limits: &consumerCreditLimitUsecase{
+			db:              db,
+			repo:            repo,
+			consumerRepo:    consumerRepo,
+			transactionRepo: transactionRepo,
+			historyRepo:     historyRepo,
+			products:        products,
+			scoringRules:    scoringRules,
+			reviewPolicy:    reviewPolicy,
+		},
+		reviewRepo: reviewRepo,
+	}
+}
+
+// GenerateLimitReviews membuat usulan untuk setiap konsumen yang plafon atau limit tenornya berakhir dalam
+// jendela peninjauan atau belum pernah ditinjau. Kegagalan pada satu konsumen dicatat ke Errors tanpa
+// menghentikan peninjauan konsumen lainnya.
+func (uc *creditLimitReviewUsecase) GenerateLimitReviews(now time.Time) (*LimitReviewRunOutput, error) {
+	output := &LimitReviewRunOutput{TanggalPeninjauan: domain.JSONDate(now)}
+	policy := uc.limits.reviewPolicy
+	if !policy.Enabled() {
+		return output, nil
+	}
+
+	consumerIDs, err := uc.reviewRepo.FindConsumerIDsDueForReview(
+		policy.ReviewDueBefore(now),
+		now.AddDate(0, 0, -policy.HariSebelumBerakhir),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, consumerID := range consumerIDs {
+		review, err := uc.proposeReview(consumerID)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("consumer %d: %v", consumerID, err))
+			continue
+		}
+		output.KonsumenDitinjau++
+		switch {
+		case review.PlafonUsulan.GreaterThan(review.PlafonSebelum):
+			output.UsulanKenaikan++
+		case review.PlafonUsulan.LessThan(review.PlafonSebelum):
+			output.UsulanPenurunan++
+		}
+	}
+
+	return output, nil
+}
+
+// GetLimitReviews mengambil antrean usulan peninjauan; status kosong mengambil seluruh usulan.
+func (uc *creditLimitReviewUsecase) GetLimitReviews(status string) ([]*domain.CreditLimitReview, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	switch status {
+	case "", domain.StatusReviewMenunggu, domain.StatusReviewDisetujui, domain.StatusReviewDitolak:
+	default:
+		return nil, fmt.Errorf(
+			"invalid review status: %s. allowed statuses are %s, %s, %s",
+			status,
+			domain.StatusReviewMenunggu,
+			domain.StatusReviewDisetujui,
+			domain.StatusReviewDitolak,
+		)
+	}
+	return uc.reviewRepo.FindByStatus(status)
+}
+
+func (uc *creditLimitReviewUsecase) GetLimitReviewByID(id uint) (*domain.CreditLimitReview, error) {
+	review, err := uc.reviewRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("credit limit review with id %d not found", id)
+	}
+	return review, nil
+}
+
+// ApproveLimitReview menerapkan plafon dan limit per tenor yang diusulkan serta memperpanjang masa berlakunya.
+// Baris konsumen dikunci lebih dulu, sama seperti pembuatan transaksi, lalu baris usulan.
+func (uc *creditLimitReviewUsecase) ApproveLimitReview(
+	id, changedBy uint,
+	input ApproveLimitReviewInput,
+) (*domain.CreditLimitReview, error) {
+	pending, err := uc.GetLimitReviewByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var approved *domain.CreditLimitReview
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			consumer, err := uc.limits.consumerRepo.WithTx(tx).FindByIDForUpdate(pending.ConsumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", pending.ConsumerID)
+			}
+			review, err := uc.lockPendingReview(tx, id)
+			if err != nil {
+				return err
+			}
+
+			targets := &limitTargets{
+				overall: review.PlafonUsulan,
+				limits:  make(map[int]domain.Money, len(review.Items)),
+				tenors:  make([]int, 0, len(review.Items)),
+			}
+			alasan := fmt.Sprintf("Peninjauan limit berkala #%d (scoring versi %s)", review.ID, review.VersiAturan)
+			if input.Catatan != "" {
+				alasan += ": " + input.Catatan
+			}
+			targets.alasan = func(int) string { return alasan }
+			for _, item := range review.Items {
+				targets.limits[item.TenorMonths] = item.LimitUsulan
+				targets.tenors = append(targets.tenors, item.TenorMonths)
+			}
+			if _, err := uc.limits.applyLimitTargets(tx, consumer, changedBy, targets); err != nil {
+				return err
+			}
+
+			decideReview(review, domain.StatusReviewDisetujui, changedBy, input.Catatan)
+			if err := uc.reviewRepo.WithTx(tx).Update(review); err != nil {
+				return err
+			}
+			approved = review
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return approved, nil
+}
+
+// RejectLimitReview menolak usulan tanpa mengubah limit. Limit yang sudah berakhir tetap menolak transaksi
+// baru sampai admin menetapkan limitnya kembali.
+func (uc *creditLimitReviewUsecase) RejectLimitReview(
+	id, changedBy uint,
+	input RejectLimitReviewInput,
+) (*domain.CreditLimitReview, error) {
+	var rejected *domain.CreditLimitReview
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			review, err := uc.lockPendingReview(tx, id)
+			if err != nil {
+				return err
+			}
+
+			decideReview(review, domain.StatusReviewDitolak, changedBy, input.Catatan)
+			if err := uc.reviewRepo.WithTx(tx).Update(review); err != nil {
+				return err
+			}
+			rejected = review
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rejected, nil
+}
+
+// proposeReview menjalankan ulang scoring konsumen dan menyimpan hasilnya sebagai usulan yang menunggu keputusan
+// admin. Tenor yang sudah memiliki limit tetapi tidak lagi direkomendasikan diusulkan menjadi nol.
+func (uc *creditLimitReviewUsecase) proposeReview(consumerID uint) (*domain.CreditLimitReview, error) {
+	consumer, err := uc.limits.consumerRepo.FindByID(consumerID)
+	if err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	recommendation, err := uc.limits.recommend(uc.limits.transactionRepo, consumer)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := uc.limits.repo.FindByConsumerID(consumerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make([]string, 0, len(recommendation.Reasons))
+	for _, reason := range recommendation.Reasons {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", reason.Kode, reason.Keterangan))
+	}
+	review := &domain.CreditLimitReview{
+		ConsumerID:    consumerID,
+		Status:        domain.StatusReviewMenunggu,
+		VersiAturan:   recommendation.VersiAturan,
+		Eligible:      recommendation.Eligible,
+		BerlakuSampai: earliestBerlakuSampai(consumer, limits),
+		PlafonSebelum: consumer.OverallCreditLimit,
+		PlafonUsulan:  recommendation.OverallCreditLimit,
+		Alasan:        strings.Join(reasons, "; "),
+	}
+
+	recommended := make(map[int]domain.Money, len(recommendation.Limits))
+	for _, limit := range recommendation.Limits {
+		recommended[limit.TenorMonths] = limit.CreditLimit
+	}
+	for _, limit := range limits {
+		review.Items = append(
+			review.Items, domain.CreditLimitReviewItem{
+				TenorMonths:  limit.TenorMonths,
+				LimitSebelum: limit.CreditLimit,
+				LimitUsulan:  recommended[limit.TenorMonths],
+			},
+		)
+		delete(recommended, limit.TenorMonths)
+	}
+	for _, limit := range recommendation.Limits {
+		if _, isNew := recommended[limit.TenorMonths]; isNew {
+			review.Items = append(
+				review.Items,
+				domain.CreditLimitReviewItem{TenorMonths: limit.TenorMonths, LimitUsulan: limit.CreditLimit},
+			)
+		}
+	}
+
+	if err := uc.reviewRepo.Save(review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// lockPendingReview mengunci usulan dan memastikan usulan masih menunggu keputusan.
+func (uc *creditLimitReviewUsecase) lockPendingReview(tx *gorm.DB, id uint) (*domain.CreditLimitReview, error) {
+	review, err := uc.reviewRepo.WithTx(tx).FindByIDForUpdate(id)
+	if err != nil {
+		return nil, fmt.Errorf("credit limit review with id %d not found", id)
+	}
+	if review.Status != domain.StatusReviewMenunggu {
+		return nil, fmt.Errorf("credit limit review is already %s", review.Status)
+	}
+	return review, nil
+}
+
+// decideReview mencatat keputusan admin pada usulan.
+func decideReview(review *domain.CreditLimitReview, status string, decidedBy uint, catatan string) {
+	now := time.Now()
+	review.Status = status
+	review.DiputuskanOleh = &decidedBy
+	review.DiputuskanPada = &now
+	review.CatatanKeputusan = catatan
+}
+
+// earliestBerlakuSampai mengembalikan masa berlaku paling awal di antara plafon dan limit tenor konsumen, atau
+// nil jika ada yang belum pernah ditinjau.
+func earliestBerlakuSampai(consumer *domain.Consumer, limits []*domain.ConsumerCreditLimit) *time.Time {
+	candidates := make([]*time.Time, 0, len(limits)+1)
+	if consumer.OverallCreditLimit.IsPositive() {
+		candidates = append(candidates, consumer.PlafonBerlakuSampai)
+	}
+	for _, limit := range limits {
+		candidates = append(candidates, limit.BerlakuSampai)
+	}
+
+	var earliest *time.Time
+	for _, candidate := range candidates {
+		if candidate == nil {
+			return nil
+		}
+		if earliest == nil || candidate.Before(*earliest) {
+			earliest = candidate
+		}
+	}
+	return earliest
+}
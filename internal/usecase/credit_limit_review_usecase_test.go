@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type limitReviewTestDeps struct {
+	mockSQL             sqlmock.Sqlmock
+	mockConsumerRepo    *MockConsumerRepository
+	mockLimitRepo       *MockCreditLimitRepository
+	mockTransactionRepo *MockTransactionRepository
+	mockHistoryRepo     *MockCreditLimitHistoryRepository
+	mockReviewRepo      *MockCreditLimitReviewRepository
+}
+
+func setupLimitReviewUsecase(t *testing.T, policy LimitReviewPolicy) (CreditLimitReviewUsecase, limitReviewTestDeps) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	deps := limitReviewTestDeps{
+		mockSQL:             mockSQL,
+		mockConsumerRepo:    new(MockConsumerRepository),
+		mockLimitRepo:       new(MockCreditLimitRepository),
+		mockTransactionRepo: new(MockTransactionRepository),
+		mockHistoryRepo:     new(MockCreditLimitHistoryRepository),
+		mockReviewRepo:      new(MockCreditLimitReviewRepository),
+	}
+	usecase := NewCreditLimitReviewUsecase(
+		gormDB,
+		deps.mockLimitRepo,
+		deps.mockConsumerRepo,
+		deps.mockTransactionRepo,
+		deps.mockHistoryRepo,
+		deps.mockReviewRepo,
+		DefaultTenorPricing,
+		DefaultScoringRules,
+		policy,
+	)
+	return usecase, deps
+}
+
+// newReviewConsumer menyiapkan konsumen berusia 30 tahun dengan gaji 10 juta yang plafonnya berakhir 10 hari lagi.
+func newReviewConsumer(now time.Time) *domain.Consumer {
+	dob := domain.JSONDate(time.Now().AddDate(-30, 0, -1))
+	berlakuSampai := now.AddDate(0, 0, 10)
+	return &domain.Consumer{
+		ID:                  1,
+		TanggalLahir:        &dob,
+		Gaji:                domain.NewMoney(10000000),
+		OverallCreditLimit:  domain.NewMoney(5000000),
+		PlafonBerlakuSampai: &berlakuSampai,
+		Kolektibilitas:      domain.KolektibilitasLancar,
+		KYCVerification:     kycApproved,
+	}
+}
+
+func TestGenerateLimitReviews_ProposesIncrease(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitReviewUsecase(t, DefaultLimitReviewPolicy)
+	now := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+	consumer := newReviewConsumer(now)
+	existingLimit := &domain.ConsumerCreditLimit{
+		ID:            7,
+		ConsumerID:    1,
+		TenorMonths:   3,
+		CreditLimit:   domain.NewMoney(5000000),
+		BerlakuSampai: consumer.PlafonBerlakuSampai,
+	}
+	var saved *domain.CreditLimitReview
+
+	deps.mockReviewRepo.On("FindConsumerIDsDueForReview", now.AddDate(0, 0, 30), now.AddDate(0, 0, -30)).
+		Return([]uint{1}, nil).Once()
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(consumer, nil).Once()
+	deps.mockTransactionRepo.On("FindByConsumerID", uint(1)).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", uint(1)).Return([]*domain.ConsumerCreditLimit{existingLimit}, nil).Once()
+	deps.mockReviewRepo.On("Save", mock.AnythingOfType("*domain.CreditLimitReview")).
+		Run(func(args mock.Arguments) { saved = args.Get(0).(*domain.CreditLimitReview) }).
+		Return(nil).Once()
+
+	// Act
+	output, err := usecase.GenerateLimitReviews(now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.KonsumenDitinjau)
+	assert.Equal(t, 1, output.UsulanKenaikan)
+	assert.Empty(t, output.Errors)
+	assert.Equal(t, domain.StatusReviewMenunggu, saved.Status)
+	assert.Equal(t, domain.NewMoney(5000000), saved.PlafonSebelum)
+	assert.Equal(t, domain.NewMoney(16000000), saved.PlafonUsulan)
+	assert.Equal(t, consumer.PlafonBerlakuSampai, saved.BerlakuSampai)
+	assert.Equal(t, 3, saved.Items[0].TenorMonths)
+	assert.Equal(t, domain.NewMoney(5000000), saved.Items[0].LimitSebelum)
+	assert.Equal(t, domain.NewMoney(8400000), saved.Items[0].LimitUsulan)
+	for _, item := range saved.Items[1:] {
+		assert.True(t, item.LimitSebelum.IsZero())
+		assert.NotEqual(t, 3, item.TenorMonths)
+	}
+	deps.mockReviewRepo.AssertExpectations(t)
+}
+
+func TestGenerateLimitReviews_ErrorDoesNotStopRun(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitReviewUsecase(t, DefaultLimitReviewPolicy)
+	now := time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)
+
+	deps.mockReviewRepo.On("FindConsumerIDsDueForReview", mock.Anything, mock.Anything).
+		Return([]uint{1, 2}, nil).Once()
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(nil, assert.AnError).Once()
+	deps.mockConsumerRepo.On("FindByID", uint(2)).Return(&domain.Consumer{ID: 2}, nil).Once()
+	deps.mockTransactionRepo.On("FindByConsumerID", uint(2)).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", uint(2)).Return([]*domain.ConsumerCreditLimit{}, nil).Once()
+	deps.mockReviewRepo.On(
+		"Save", mock.MatchedBy(
+			func(review *domain.CreditLimitReview) bool {
+				return review.ConsumerID == 2 && !review.Eligible && review.Alasan != ""
+			},
+		),
+	).Return(nil).Once()
+
+	// Act
+	output, err := usecase.GenerateLimitReviews(now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.KonsumenDitinjau)
+	assert.Equal(t, []string{"consumer 1: consumer with id 1 not found"}, output.Errors)
+	deps.mockReviewRepo.AssertExpectations(t)
+}
+
+func TestGenerateLimitReviews_ValidityDisabled(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitReviewUsecase(t, LimitReviewPolicy{})
+
+	// Act
+	output, err := usecase.GenerateLimitReviews(time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Zero(t, output.KonsumenDitinjau)
+	deps.mockReviewRepo.AssertNotCalled(t, "FindConsumerIDsDueForReview", mock.Anything, mock.Anything)
+}
+
+func TestApproveLimitReview_AppliesProposal(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitReviewUsecase(t, DefaultLimitReviewPolicy)
+	consumer := newReviewConsumer(time.Now())
+	existingLimit := &domain.ConsumerCreditLimit{
+		ID:          7,
+		ConsumerID:  1,
+		TenorMonths: 3,
+		CreditLimit: domain.NewMoney(5000000),
+	}
+	review := &domain.CreditLimitReview{
+		ID:            4,
+		ConsumerID:    1,
+		Status:        domain.StatusReviewMenunggu,
+		VersiAturan:   DefaultScoringRules.Versi,
+		PlafonSebelum: domain.NewMoney(5000000),
+		PlafonUsulan:  domain.NewMoney(4000000),
+		Items: []domain.CreditLimitReviewItem{
+			{TenorMonths: 3, LimitSebelum: domain.NewMoney(5000000), LimitUsulan: domain.NewMoney(3000000)},
+		},
+	}
+
+	deps.mockReviewRepo.On("FindByID", uint(4)).Return(review, nil).Once()
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockReviewRepo.On("FindByIDForUpdate", uint(4)).Return(review, nil).Once()
+	deps.mockLimitRepo.On("FindByConsumerID", uint(1)).Return([]*domain.ConsumerCreditLimit{existingLimit}, nil).Once()
+	deps.mockTransactionRepo.On("FindActiveByConsumerID", uint(1)).Return([]*domain.Transaction{}, nil).Once()
+	deps.mockConsumerRepo.On("Update", uint(1), mock.AnythingOfType("map[string]interface {}")).Return(nil).Once()
+	deps.mockLimitRepo.On(
+		"Update", uint(7), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["credit_limit"] == domain.NewMoney(3000000)
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditLimitHistory) bool {
+				return history.Aksi == domain.CreditLimitAksiUpdate &&
+					history.LimitSesudah == domain.NewMoney(3000000) &&
+					history.Alasan == "Peninjauan limit berkala #4 (scoring versi "+DefaultScoringRules.Versi+
+						"): Penurunan pendapatan"
+			},
+		),
+	).Return(nil).Once()
+	deps.mockReviewRepo.On("Update", review).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	approved, err := usecase.ApproveLimitReview(4, 99, ApproveLimitReviewInput{Catatan: "Penurunan pendapatan"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusReviewDisetujui, approved.Status)
+	assert.Equal(t, uint(99), *approved.DiputuskanOleh)
+	assert.Equal(t, domain.NewMoney(4000000), consumer.OverallCreditLimit)
+	assert.True(t, consumer.PlafonBerlakuSampai.After(time.Now().AddDate(0, 11, 0)))
+	assert.Equal(t, domain.NewMoney(3000000), existingLimit.CreditLimit)
+	assert.NotNil(t, existingLimit.BerlakuSampai)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockLimitRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+	deps.mockReviewRepo.AssertExpectations(t)
+}
+
+func TestRejectLimitReview_AlreadyDecided(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitReviewUsecase(t, DefaultLimitReviewPolicy)
+	review := &domain.CreditLimitReview{ID: 4, ConsumerID: 1, Status: domain.StatusReviewDisetujui}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockReviewRepo.On("FindByIDForUpdate", uint(4)).Return(review, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	rejected, err := usecase.RejectLimitReview(4, 99, RejectLimitReviewInput{Catatan: "Data gaji belum valid"})
+
+	// Assert
+	assert.Nil(t, rejected)
+	assert.EqualError(t, err, "credit limit review is already DISETUJUI")
+	deps.mockReviewRepo.AssertNotCalled(t, "Update", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestGetLimitReviews_InvalidStatus(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitReviewUsecase(t, DefaultLimitReviewPolicy)
+
+	// Act
+	reviews, err := usecase.GetLimitReviews("selesai")
+
+	// Assert
+	assert.Nil(t, reviews)
+	assert.EqualError(t, err, "invalid review status: SELESAI. allowed statuses are MENUNGGU, DISETUJUI, DITOLAK")
+	deps.mockReviewRepo.AssertNotCalled(t, "FindByStatus", mock.Anything)
+}
+
+func TestParseLimitReviewPolicy(t *testing.T) {
+	policy, err := ParseLimitReviewPolicy("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLimitReviewPolicy, policy)
+
+	policy, err = ParseLimitReviewPolicy("6", "14")
+	assert.NoError(t, err)
+	assert.Equal(t, LimitReviewPolicy{MasaBerlakuBulan: 6, HariSebelumBerakhir: 14}, policy)
+
+	policy, err = ParseLimitReviewPolicy("0", "")
+	assert.NoError(t, err)
+	assert.False(t, policy.Enabled())
+	assert.Nil(t, policy.BerlakuSampai(time.Now()))
+
+	_, err = ParseLimitReviewPolicy("setahun", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// Kode alasan pada rekomendasi limit kredit.
+const (
+	ScoringReasonGaji           = "GAJI"
+	ScoringReasonUsia           = "USIA"
+	ScoringReasonKolektibilitas = "KOLEKTIBILITAS"
+	ScoringReasonWriteOff       = "WRITE_OFF"
+	ScoringReasonRiwayatLunas   = "RIWAYAT_LUNAS"
+	ScoringReasonDTI            = "DTI"
+	ScoringReasonPlafon         = "PLAFON"
+	ScoringReasonKapasitas      = "KAPASITAS"
+)
+
+// AgeBandRule memberi faktor pengali plafon untuk rentang usia (inklusif).
+type AgeBandRule struct {
+	UsiaMin int     `json:"usia_min"`
+	UsiaMax int     `json:"usia_max"`
+	Faktor  float64 `json:"faktor"`
+}
+
+// ScoringRules adalah aturan penilaian limit kredit yang berversi. Versi dicatat pada setiap rekomendasi dan
+// riwayat limit agar keputusan dapat ditelusuri kembali ke aturan yang berlaku saat itu.
+//
+// Plafon dasar = Gaji x PengaliGaji x faktor usia x faktor kolektibilitas x (1 + bonus kontrak lunas).
+// Kapasitas angsuran bulanan = Gaji x MaksimalDTI - angsuran kontrak berjalan. Limit tiap tenor adalah pokok
+// yang dapat dilunasi dengan kapasitas tersebut selama tenor (dengan asumsi bunga flat AsumsiBungaTahunan),
+// dibatasi plafon dasar; plafon keseluruhan tidak melebihi limit tenor terbesar.
+type ScoringRules struct {
+	Versi                     string             `json:"versi"`
+	PengaliGaji               float64            `json:"pengali_gaji"`
+	MaksimalDTI               float64            `json:"maksimal_dti"`
+	AsumsiBungaTahunan        float64            `json:"asumsi_bunga_tahunan"`
+	KelompokUsia              []AgeBandRule      `json:"kelompok_usia"`
+	FaktorKolektibilitas      map[string]float64 `json:"faktor_kolektibilitas"`
+	BonusKontrakLunas         float64            `json:"bonus_kontrak_lunas"`
+	MaksimalBonusKontrakLunas float64            `json:"maksimal_bonus_kontrak_lunas"`
+	PembulatanLimit           domain.Money       `json:"pembulatan_limit"`
+}
+
+// DefaultScoringRules dipakai jika CREDIT_SCORING_RULES tidak dikonfigurasi.
+var DefaultScoringRules = ScoringRules{
+	Versi:              "2026.10-1",
+	PengaliGaji:        3,
+	MaksimalDTI:        0.3,
+	AsumsiBungaTahunan: 0.24,
+	KelompokUsia: []AgeBandRule{
+		{UsiaMin: 21, UsiaMax: 24, Faktor: 0.7},
+		{UsiaMin: 25, UsiaMax: 50, Faktor: 1},
+		{UsiaMin: 51, UsiaMax: 55, Faktor: 0.8},
+		{UsiaMin: 56, UsiaMax: 60, Faktor: 0.5},
+	},
+	FaktorKolektibilitas: map[string]float64{
+		domain.KolektibilitasLancar:       1,
+		domain.KolektibilitasDPK:          0.5,
+		domain.KolektibilitasKurangLancar: 0,
+		domain.KolektibilitasDiragukan:    0,
+		domain.KolektibilitasMacet:        0,
+	},
+	BonusKontrakLunas:         0.05,
+	MaksimalBonusKontrakLunas: 0.25,
+	PembulatanLimit:           domain.NewMoney(100000),
+}
+
+// LoadScoringRules membaca aturan scoring dari berkas JSON. Path kosong menghasilkan DefaultScoringRules.
+func LoadScoringRules(path string) (ScoringRules, error) {
+	if strings.TrimSpace(path) == "" {
+		return DefaultScoringRules, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ScoringRules{}, err
+	}
+	return ParseScoringRules(data)
+}
+
+// ParseScoringRules mengurai dan memvalidasi aturan scoring dalam format JSON.
+func ParseScoringRules(data []byte) (ScoringRules, error) {
+	var rules ScoringRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return ScoringRules{}, fmt.Errorf("invalid scoring rules: %w", err)
+	}
+
+	if strings.TrimSpace(rules.Versi) == "" {
+		return ScoringRules{}, fmt.Errorf("scoring rules must have a versi")
+	}
+	if rules.PengaliGaji <= 0 {
+		return ScoringRules{}, fmt.Errorf("pengali_gaji must be greater than 0")
+	}
+	if rules.MaksimalDTI <= 0 || rules.MaksimalDTI > 1 {
+		return ScoringRules{}, fmt.Errorf("maksimal_dti must be between 0 and 1")
+	}
+	if rules.AsumsiBungaTahunan < 0 {
+		return ScoringRules{}, fmt.Errorf("asumsi_bunga_tahunan must not be negative")
+	}
+	if len(rules.KelompokUsia) == 0 {
+		return ScoringRules{}, fmt.Errorf("kelompok_usia must not be empty")
+	}
+	for _, band := range rules.KelompokUsia {
+		if band.UsiaMin <= 0 || band.UsiaMax < band.UsiaMin || band.Faktor < 0 {
+			return ScoringRules{}, fmt.Errorf("invalid age band %d-%d", band.UsiaMin, band.UsiaMax)
+		}
+	}
+	for kolektibilitas, faktor := range rules.FaktorKolektibilitas {
+		if kolektibilitasRank(kolektibilitas) < 0 {
+			return ScoringRules{}, fmt.Errorf("unknown kolektibilitas %q in faktor_kolektibilitas", kolektibilitas)
+		}
+		if faktor < 0 {
+			return ScoringRules{}, fmt.Errorf("faktor_kolektibilitas for %s must not be negative", kolektibilitas)
+		}
+	}
+	if rules.BonusKontrakLunas < 0 || rules.MaksimalBonusKontrakLunas < 0 || rules.PembulatanLimit.IsNegative() {
+		return ScoringRules{}, fmt.Errorf("bonus and pembulatan_limit must not be negative")
+	}
+	return rules, nil
+}
+
+// scoringInput adalah data konsumen yang dinilai oleh scoreCreditLimit.
+type scoringInput struct {
+	consumer      *domain.Consumer
+	transactions  []*domain.Transaction
+	offeredTenors []int
+	asOf          time.Time
+}
+
+// scoreCreditLimit menghitung rekomendasi plafon keseluruhan dan limit per tenor beserta alasannya.
+// Fungsi ini murni (tanpa akses database) sehingga hasilnya dapat diuji dan diulang untuk versi aturan yang sama.
+func scoreCreditLimit(rules ScoringRules, input scoringInput) (*CreditLimitRecommendation, error) {
+	consumer := input.consumer
+	output := &CreditLimitRecommendation{
+		ConsumerID:  consumer.ID,
+		VersiAturan: rules.Versi,
+		Gaji:        consumer.Gaji,
+		Limits:      make([]RecommendedTenorLimit, 0, len(input.offeredTenors)),
+	}
+	reject := func(kode, keterangan string) (*CreditLimitRecommendation, error) {
+		output.Reasons = append(output.Reasons, ScoringReason{Kode: kode, Keterangan: keterangan})
+		output.OverallCreditLimit = domain.Money{}
+		output.Limits = output.Limits[:0]
+		return output, nil
+	}
+
+	// 1. Gaji menjadi dasar plafon dan kapasitas angsuran
+	if !consumer.Gaji.IsPositive() {
+		return reject(ScoringReasonGaji, "salary is required to compute a credit limit")
+	}
+
+	// 2. Usia dihitung dari tanggal lahir dan dicocokkan dengan kelompok usia
+	if consumer.TanggalLahir == nil {
+		return reject(ScoringReasonUsia, "date of birth is required to compute a credit limit")
+	}
+	output.Usia = ageAt(time.Time(*consumer.TanggalLahir), input.asOf)
+	band, ok := rules.ageBand(output.Usia)
+	if !ok {
+		return reject(ScoringReasonUsia, "age is outside the accepted age bands")
+	}
+	// Alasan hanya menyebut kelompok usia agar usia dan gaji tidak terbaca dari alasan yang ditampilkan kepada
+	// operator maupun yang disimpan pada usulan peninjauan limit.
+	output.KelompokUsia = fmt.Sprintf("%d-%d", band.UsiaMin, band.UsiaMax)
+	output.addReason(ScoringReasonUsia, "age band %s with factor %v", output.KelompokUsia, band.Faktor)
+
+	// 3. Riwayat pembayaran: kolektibilitas terburuk, kontrak write-off, dan kontrak yang sudah lunas
+	kolektibilitas := consumer.Kolektibilitas
+	if kolektibilitas == "" {
+		kolektibilitas = domain.KolektibilitasLancar
+	}
+	kewajibanBulanan := domain.Money{}
+	kontrakLunas := 0
+	for _, trx := range input.transactions {
+		switch trx.StatusKontrak {
+		case domain.StatusKontrakWriteOff:
+			return reject(ScoringReasonWriteOff, fmt.Sprintf("contract %s was written off", trx.NomorKontrak))
+		case domain.StatusKontrakLunas:
+			kontrakLunas++
+		case domain.StatusKontrakPending, domain.StatusKontrakAktif, domain.StatusKontrakRestrukturisasi:
+			kewajibanBulanan = kewajibanBulanan.Add(trx.NilaiCicilanPerPeriode)
+			if trx.Kolektibilitas != "" && kolektibilitasRank(trx.Kolektibilitas) > kolektibilitasRank(kolektibilitas) {
+				kolektibilitas = trx.Kolektibilitas
+			}
+		}
+	}
+	faktorKolektibilitas := rules.FaktorKolektibilitas[kolektibilitas]
+	if faktorKolektibilitas <= 0 {
+		return reject(
+			ScoringReasonKolektibilitas,
+			fmt.Sprintf("kolektibilitas %s is not eligible for a credit limit", kolektibilitas),
+		)
+	}
+	output.addReason(
+		ScoringReasonKolektibilitas,
+		"worst kolektibilitas %s with factor %v", kolektibilitas, faktorKolektibilitas,
+	)
+
+	bonus := float64(kontrakLunas) * rules.BonusKontrakLunas
+	if bonus > rules.MaksimalBonusKontrakLunas {
+		bonus = rules.MaksimalBonusKontrakLunas
+	}
+	if kontrakLunas > 0 {
+		output.addReason(ScoringReasonRiwayatLunas, "%d contracts paid off, bonus %v", kontrakLunas, bonus)
+	}
+
+	// 4. Batas rasio utang terhadap pendapatan (debt-to-income)
+	output.KewajibanBulanan = kewajibanBulanan
+	output.RasioDTI = float64(kewajibanBulanan.Sen()) / float64(consumer.Gaji.Sen())
+	batasAngsuran, err := consumer.Gaji.MulRound(rules.MaksimalDTI, domain.SatuSen)
+	if err != nil {
+		return nil, err
+	}
+	output.KapasitasAngsuran = batasAngsuran.Sub(kewajibanBulanan)
+	if !output.KapasitasAngsuran.IsPositive() {
+		output.KapasitasAngsuran = domain.Money{}
+		return reject(
+			ScoringReasonDTI,
+			fmt.Sprintf(
+				"monthly obligations (%s) already reach the debt-to-income cap of %v",
+				kewajibanBulanan,
+				rules.MaksimalDTI,
+			),
+		)
+	}
+	output.addReason(
+		ScoringReasonDTI,
+		"monthly obligations of %s are within the debt-to-income cap of %v", kewajibanBulanan, rules.MaksimalDTI,
+	)
+
+	// 5. Plafon dasar dari gaji dan limit tiap tenor dari kapasitas angsuran
+	faktorRisiko := band.Faktor * faktorKolektibilitas
+	plafonGaji, err := consumer.Gaji.MulRound(rules.PengaliGaji*faktorRisiko*(1+bonus), domain.SatuSen)
+	if err != nil {
+		return nil, err
+	}
+	plafonDasar := rules.roundDown(plafonGaji)
+	output.addReason(
+		ScoringReasonPlafon,
+		"base plafon %s = salary x %v x factor %v", plafonDasar, rules.PengaliGaji, faktorRisiko*(1+bonus),
+	)
+
+	limitTerbesar := domain.Money{}
+	for _, tenor := range input.offeredTenors {
+		bulan := float64(tenor)
+		pokok, err := output.KapasitasAngsuran.MulRound(
+			bulan*faktorRisiko/(1+rules.AsumsiBungaTahunan*bulan/12), domain.SatuSen,
+		)
+		if err != nil {
+			return nil, err
+		}
+		limit := rules.roundDown(domain.MinMoney(pokok, plafonDasar))
+		output.Limits = append(output.Limits, RecommendedTenorLimit{TenorMonths: tenor, CreditLimit: limit})
+		if limit.GreaterThan(limitTerbesar) {
+			limitTerbesar = limit
+		}
+	}
+
+	output.OverallCreditLimit = plafonDasar
+	if limitTerbesar.LessThan(plafonDasar) {
+		output.OverallCreditLimit = limitTerbesar
+		output.addReason(
+			ScoringReasonKapasitas,
+			"overall plafon capped at %s by the longest tenor repayment capacity", limitTerbesar,
+		)
+	}
+	output.Eligible = output.OverallCreditLimit.IsPositive()
+	return output, nil
+}
+
+func (r *CreditLimitRecommendation) addReason(kode, format string, args ...interface{}) {
+	r.Reasons = append(r.Reasons, ScoringReason{Kode: kode, Keterangan: fmt.Sprintf(format, args...)})
+}
+
+// ageBand mengembalikan kelompok usia pertama yang memuat usia tersebut.
+func (rules ScoringRules) ageBand(usia int) (AgeBandRule, bool) {
+	for _, band := range rules.KelompokUsia {
+		if usia >= band.UsiaMin && usia <= band.UsiaMax {
+			return band, true
+		}
+	}
+	return AgeBandRule{}, false
+}
+
+// roundDown membulatkan limit ke bawah ke kelipatan PembulatanLimit.
+func (rules ScoringRules) roundDown(amount domain.Money) domain.Money {
+	step := rules.PembulatanLimit.Sen()
+	if step <= 0 || amount.IsNegative() {
+		return amount
+	}
+	return domain.NewMoneyFromSen(amount.Sen() / step * step)
+}
+
+// ageAt menghitung usia dalam tahun penuh per tanggal asOf.
+func ageAt(tanggalLahir, asOf time.Time) int {
+	usia := asOf.Year() - tanggalLahir.Year()
+	if asOf.Month() < tanggalLahir.Month() || (asOf.Month() == tanggalLahir.Month() && asOf.Day() < tanggalLahir.Day()) {
+		usia--
+	}
+	return usia
+}
+
+// kolektibilitasRank mengurutkan kolektibilitas dari yang terbaik (0) hingga terburuk, atau -1 jika tidak dikenal.
+func kolektibilitasRank(kolektibilitas string) int {
+	switch kolektibilitas {
+	case domain.KolektibilitasLancar:
+		return 0
+	case domain.KolektibilitasDPK:
+		return 1
+	case domain.KolektibilitasKurangLancar:
+		return 2
+	case domain.KolektibilitasDiragukan:
+		return 3
+	case domain.KolektibilitasMacet:
+		return 4
+	default:
+		return -1
+	}
+}
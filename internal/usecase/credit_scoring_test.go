@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// newScoringConsumer membuat konsumen bergaji 10 juta yang lahir pada tanggal lahir yang diberikan.
+func newScoringConsumer(tanggalLahir string) *domain.Consumer {
+	dob, _ := time.Parse("2006-01-02", tanggalLahir)
+	jsonDob := domain.JSONDate(dob)
+	return &domain.Consumer{
+		ID:             1,
+		TanggalLahir:   &jsonDob,
+		Gaji:           domain.NewMoney(10000000),
+		Kolektibilitas: domain.KolektibilitasLancar,
+	}
+}
+
+func TestScoreCreditLimit_CleanConsumer(t *testing.T) {
+	// Arrange
+	asOf := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	input := scoringInput{
+		consumer:      newScoringConsumer("1996-05-01"),
+		offeredTenors: []int{1, 2, 3, 6},
+		asOf:          asOf,
+	}
+
+	// Act
+	recommendation, err := scoreCreditLimit(DefaultScoringRules, input)
+	assert.NoError(t, err)
+
+	// Assert: kapasitas 30% x 10 juta = 3 juta per bulan; limit tenor = kapasitas x tenor / (1 + 24% x tenor/12)
+	assert.True(t, recommendation.Eligible)
+	assert.Equal(t, DefaultScoringRules.Versi, recommendation.VersiAturan)
+	assert.Equal(t, 30, recommendation.Usia)
+	assert.Equal(t, "25-50", recommendation.KelompokUsia)
+	assert.Equal(t, domain.NewMoney(3000000), recommendation.KapasitasAngsuran)
+	assert.Equal(
+		t, []RecommendedTenorLimit{
+			{TenorMonths: 1, CreditLimit: domain.NewMoney(2900000)},
+			{TenorMonths: 2, CreditLimit: domain.NewMoney(5700000)},
+			{TenorMonths: 3, CreditLimit: domain.NewMoney(8400000)},
+			{TenorMonths: 6, CreditLimit: domain.NewMoney(16000000)},
+		}, recommendation.Limits,
+	)
+	// Plafon dasar 30 juta dibatasi kapasitas tenor terpanjang
+	assert.Equal(t, domain.NewMoney(16000000), recommendation.OverallCreditLimit)
+	assert.Equal(t, ScoringReasonKapasitas, recommendation.Reasons[len(recommendation.Reasons)-1].Kode)
+	// Alasan ikut ditampilkan kepada operator sehingga tidak boleh memuat usia, gaji, maupun kapasitas angsuran.
+	for _, reason := range recommendation.Reasons {
+		assert.NotContains(t, reason.Keterangan, "age 30")
+		assert.NotRegexp(t, `(^|[^0-9])(10000000|3000000)\.00`, reason.Keterangan)
+	}
+}
+
+func TestScoreCreditLimit_ObligationsAndHistory(t *testing.T) {
+	// Arrange: usia 52 (faktor 0.8), kontrak berjalan DPK (faktor 0.5), dan dua kontrak lunas (bonus 10%)
+	asOf := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	input := scoringInput{
+		consumer: newScoringConsumer("1974-01-10"),
+		transactions: []*domain.Transaction{
+			{
+				StatusKontrak:          domain.StatusKontrakAktif,
+				NilaiCicilanPerPeriode: domain.NewMoney(1000000),
+				Kolektibilitas:         domain.KolektibilitasDPK,
+			},
+			{StatusKontrak: domain.StatusKontrakLunas},
+			{StatusKontrak: domain.StatusKontrakLunas},
+			{StatusKontrak: domain.StatusKontrakDibatalkan},
+		},
+		offeredTenors: []int{3, 6},
+		asOf:          asOf,
+	}
+
+	// Act
+	recommendation, err := scoreCreditLimit(DefaultScoringRules, input)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.True(t, recommendation.Eligible)
+	assert.Equal(t, domain.NewMoney(1000000), recommendation.KewajibanBulanan)
+	assert.InDelta(t, 0.1, recommendation.RasioDTI, 1e-9)
+	assert.Equal(t, domain.NewMoney(2000000), recommendation.KapasitasAngsuran)
+	assert.Equal(
+		t, []RecommendedTenorLimit{
+			{TenorMonths: 3, CreditLimit: domain.NewMoney(2200000)},
+			{TenorMonths: 6, CreditLimit: domain.NewMoney(4200000)},
+		}, recommendation.Limits,
+	)
+	assert.Equal(t, domain.NewMoney(4200000), recommendation.OverallCreditLimit)
+
+	kodes := make([]string, 0, len(recommendation.Reasons))
+	for _, reason := range recommendation.Reasons {
+		kodes = append(kodes, reason.Kode)
+	}
+	assert.Equal(
+		t,
+		[]string{
+			ScoringReasonUsia,
+			ScoringReasonKolektibilitas,
+			ScoringReasonRiwayatLunas,
+			ScoringReasonDTI,
+			ScoringReasonPlafon,
+			ScoringReasonKapasitas,
+		},
+		kodes,
+	)
+}
+
+func TestScoreCreditLimit_Rejections(t *testing.T) {
+	asOf := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name         string
+		tanggalLahir string
+		kolek        string
+		transactions []*domain.Transaction
+		kode         string
+	}{
+		{name: "usia di luar kelompok", tanggalLahir: "1960-01-01", kode: ScoringReasonUsia},
+		{name: "usia tepat di bawah batas", tanggalLahir: "2005-10-18", kode: ScoringReasonUsia},
+		{
+			name:         "kolektibilitas macet",
+			tanggalLahir: "1990-01-01",
+			kolek:        domain.KolektibilitasMacet,
+			kode:         ScoringReasonKolektibilitas,
+		},
+		{
+			name:         "pernah write-off",
+			tanggalLahir: "1990-01-01",
+			transactions: []*domain.Transaction{{StatusKontrak: domain.StatusKontrakWriteOff, NomorKontrak: "KP-1"}},
+			kode:         ScoringReasonWriteOff,
+		},
+		{
+			name:         "batas DTI tercapai",
+			tanggalLahir: "1990-01-01",
+			transactions: []*domain.Transaction{
+				{StatusKontrak: domain.StatusKontrakAktif, NilaiCicilanPerPeriode: domain.NewMoney(3000000)},
+			},
+			kode: ScoringReasonDTI,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				consumer := newScoringConsumer(tc.tanggalLahir)
+				if tc.kolek != "" {
+					consumer.Kolektibilitas = tc.kolek
+				}
+
+				// Act
+				recommendation, err := scoreCreditLimit(
+					DefaultScoringRules, scoringInput{
+						consumer:      consumer,
+						transactions:  tc.transactions,
+						offeredTenors: []int{1, 3, 6},
+						asOf:          asOf,
+					},
+				)
+				assert.NoError(t, err)
+
+				// Assert
+				assert.False(t, recommendation.Eligible)
+				assert.True(t, recommendation.OverallCreditLimit.IsZero())
+				assert.Empty(t, recommendation.Limits)
+				assert.Equal(t, tc.kode, recommendation.Reasons[len(recommendation.Reasons)-1].Kode)
+			},
+		)
+	}
+}
+
+func TestParseScoringRules(t *testing.T) {
+	// Aturan valid dengan versi baru
+	rules, err := ParseScoringRules(
+		[]byte(`{
+			"versi": "2026.11-1",
+			"pengali_gaji": 4,
+			"maksimal_dti": 0.35,
+			"kelompok_usia": [{"usia_min": 21, "usia_max": 55, "faktor": 1}],
+			"faktor_kolektibilitas": {"LANCAR": 1, "DPK": 0.25},
+			"pembulatan_limit": "50000"
+		}`),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026.11-1", rules.Versi)
+	assert.Equal(t, domain.NewMoney(50000), rules.PembulatanLimit)
+
+	invalid := []string{
+		`{"pengali_gaji": 3, "maksimal_dti": 0.3, "kelompok_usia": [{"usia_min": 21, "usia_max": 55, "faktor": 1}]}`,
+		`{"versi": "v1", "pengali_gaji": 3, "maksimal_dti": 1.5, "kelompok_usia": [{"usia_min": 21, "usia_max": 55, "faktor": 1}]}`,
+		`{"versi": "v1", "pengali_gaji": 3, "maksimal_dti": 0.3, "kelompok_usia": []}`,
+		`{"versi": "v1", "pengali_gaji": 3, "maksimal_dti": 0.3, "kelompok_usia": [{"usia_min": 55, "usia_max": 21, "faktor": 1}]}`,
+		`{"versi": "v1", "pengali_gaji": 3, "maksimal_dti": 0.3, "kelompok_usia": [{"usia_min": 21, "usia_max": 55, "faktor": 1}], "faktor_kolektibilitas": {"BAGUS": 1}}`,
+	}
+	for _, raw := range invalid {
+		_, err := ParseScoringRules([]byte(raw))
+		assert.Error(t, err, raw)
+	}
+
+	// Path kosong menghasilkan aturan bawaan
+	rules, err = LoadScoringRules("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultScoringRules.Versi, rules.Versi)
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// contractDPD mengembalikan hari keterlambatan kontrak per asOf, yaitu keterlambatan angsuran tertua
+// yang sudah jatuh tempo tetapi belum lunas.
+func contractDPD(installments []*domain.Installment, asOf time.Time) int {
+	hariIni := truncateToDate(asOf)
+	dpd := 0
+	for _, installment := range installments {
+		if installment.IsClosed() || !installment.SisaTagihan().IsPositive() {
+			continue
+		}
+		if days := daysBetween(truncateToDate(installment.TanggalJatuhTempo), hariIni); days > dpd {
+			dpd = days
+		}
+	}
+	return dpd
+}
+
+// assessDelinquency memperbarui hari keterlambatan dan kolektibilitas kontrak, lalu mengakru denda pada
+// angsuran yang lewat jatuh tempo: tagihan angsuran x denda harian x hari keterlambatan, dibatasi
+// maksimal denda. Denda dihitung ulang secara kumulatif sehingga aman dijalankan berulang kali pada
+// hari yang sama dan tidak pernah berkurang. Mengembalikan angsuran yang dendanya berubah.
+func assessDelinquency(
+	trx *domain.Transaction,
+	installments []*domain.Installment,
+	asOf time.Time,
+	policy PricingPolicy,
+) ([]*domain.Installment, error) {
+	hariIni := truncateToDate(asOf)
+	var changed []*domain.Installment
+
+	for _, installment := range installments {
+		if installment.IsClosed() {
+			continue
+		}
+		dpd := daysBetween(truncateToDate(installment.TanggalJatuhTempo), hariIni)
+		if dpd <= 0 {
+			continue
+		}
+
+		maksimal, err := installment.JumlahTagihan.MulRound(policy.MaksimalDenda, domain.SatuRupiah)
+		if err != nil {
+			return nil, err
+		}
+		denda, err := installment.JumlahTagihan.MulInt(int64(dpd)).MulRound(policy.DendaHarian, domain.SatuRupiah)
+		if err != nil {
+			return nil, err
+		}
+		denda = domain.MinMoney(denda, maksimal)
+		if denda.GreaterThan(installment.Denda) {
+			installment.Denda = denda
+			changed = append(changed, installment)
+		}
+	}
+
+	trx.HariKeterlambatan = contractDPD(installments, asOf)
+	trx.Kolektibilitas = domain.KolektibilitasFromDPD(trx.HariKeterlambatan)
+	return changed, nil
+}
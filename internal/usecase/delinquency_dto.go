@@ -0,0 +1,13 @@
+package usecase
+
+import "github.com/adty404/kredit-plus/internal/domain"
+
+// DelinquencyAssessmentOutput merangkum hasil satu kali penilaian keterlambatan harian.
+type DelinquencyAssessmentOutput struct {
+	TanggalPenilaian  domain.JSONDate `json:"tanggal_penilaian"`
+	KontrakDinilai    int             `json:"kontrak_dinilai"`
+	KonsumenTerlambat int             `json:"konsumen_terlambat"`
+	KonsumenDibekukan int             `json:"konsumen_dibekukan"`
+	TotalDendaBaru    domain.Money    `json:"total_denda_baru"`
+	Errors            []string        `json:"errors,omitempty"`
+}
@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type DelinquencyUsecase interface {
+	AssessDelinquency(asOf time.Time) (*DelinquencyAssessmentOutput, error)
+}
+
+type delinquencyUsecase struct {
+	db                *gorm.DB
+	transactionRepo   domain.TransactionRepository
+	installmentRepo   domain.InstallmentRepository
+	consumerRepo      domain.ConsumerRepository
+	freezeHistoryRepo domain.CreditFreezeHistoryRepository
+	pricingResolver   PricingPolicyResolver
+	freezeDPD         int
+}
+
+// NewDelinquencyUsecase membuat usecase penilaian keterlambatan. Konsumen yang hari keterlambatannya mencapai
+// freezeDPD otomatis dibekukan kreditnya; freezeDPD 0 menonaktifkan pembekuan otomatis.
+func NewDelinquencyUsecase(
+	db *gorm.DB,
+	transactionRepo domain.TransactionRepository,
+	installmentRepo domain.InstallmentRepository,
+	consumerRepo domain.ConsumerRepository,
+	freezeHistoryRepo domain.CreditFreezeHistoryRepository,
+	pricingResolver PricingPolicyResolver,
+	freezeDPD int,
+) DelinquencyUsecase {
+	return &delinquencyUsecase{
+		db:                db,
+		transactionRepo:   transactionRepo,
+		installmentRepo:   installmentRepo,
+		consumerRepo:      consumerRepo,
+		freezeHistoryRepo: freezeHistoryRepo,
+		pricingResolver:   pricingResolver,
+		freezeDPD:         freezeDPD,
+	}
+}
+
+// AssessDelinquency menilai seluruh kontrak berjalan per tanggal asOf: menghitung hari keterlambatan,
+// mengakru denda, dan memperbarui kolektibilitas kontrak serta konsumennya, termasuk konsumen yang masih
+// tercatat terlambat meskipun tidak lagi memiliki kontrak berjalan. Setiap kontrak diproses
+// dalam transaksi database tersendiri agar kegagalan satu kontrak tidak menggagalkan yang lain.
+func (uc *delinquencyUsecase) AssessDelinquency(asOf time.Time) (*DelinquencyAssessmentOutput, error) {
+	contracts, err := uc.transactionRepo.FindByStatuses(
+		[]string{domain.StatusKontrakAktif, domain.StatusKontrakRestrukturisasi},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &DelinquencyAssessmentOutput{TanggalPenilaian: domain.JSONDate(truncateToDate(asOf))}
+	consumerIDs := make(map[uint]bool)
+
+	for _, contract := range contracts {
+		dendaBaru, err := uc.assessContract(contract.ID, asOf)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("transaction %d: %v", contract.ID, err))
+			continue
+		}
+		output.KontrakDinilai++
+		output.TotalDendaBaru = output.TotalDendaBaru.Add(dendaBaru)
+		consumerIDs[contract.ConsumerID] = true
+	}
+
+	// Konsumen yang kontrak terlambat terakhirnya sudah lunas atau ditutup tidak lagi memiliki kontrak berjalan,
+	// tetapi keterlambatan dan kolektibilitasnya tetap harus dihitung ulang agar tidak tertahan.
+	delinquentIDs, err := uc.consumerRepo.FindDelinquentIDs()
+	if err != nil {
+		output.Errors = append(output.Errors, fmt.Sprintf("delinquent consumers: %v", err))
+	}
+	for _, consumerID := range delinquentIDs {
+		consumerIDs[consumerID] = true
+	}
+
+	for consumerID := range consumerIDs {
+		dpd, frozen, err := uc.refreshConsumer(consumerID)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Sprintf("consumer %d: %v", consumerID, err))
+			continue
+		}
+		if dpd > 0 {
+			output.KonsumenTerlambat++
+		}
+		if frozen {
+			output.KonsumenDibekukan++
+		}
+	}
+
+	return output, nil
+}
+
+// assessContract mengunci kontrak, menilai keterlambatannya, dan mengembalikan tambahan denda yang diakru.
+func (uc *delinquencyUsecase) assessContract(transactionID uint, asOf time.Time) (domain.Money, error) {
+	var dendaBaru domain.Money
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			transactionRepoTx := uc.transactionRepo.WithTx(tx)
+			installmentRepoTx := uc.installmentRepo.WithTx(tx)
+
+			trx, err := transactionRepoTx.FindByIDForUpdate(transactionID)
+			if err != nil {
+				return err
+			}
+			// Status bisa berubah sejak daftar kontrak diambil (misalnya dilunasi).
+			if !trx.AcceptsPayment() {
+				return nil
+			}
+
+			installments, err := installmentRepoTx.FindOutstandingByTransactionID(trx.ID)
+			if err != nil {
+				return err
+			}
+
+			// Kontrak tanpa kebijakan harga tetap dinilai keterlambatannya, hanya saja tanpa denda.
+			policy, err := uc.pricingResolver.Resolve(trx.JenisAsset, trx.TenorBulan)
+			if err != nil {
+				policy = PricingPolicy{}
+			}
+
+			dendaSebelum := make(map[uint]domain.Money, len(installments))
+			for _, installment := range installments {
+				dendaSebelum[installment.ID] = installment.Denda
+			}
+
+			changed, err := assessDelinquency(trx, installments, asOf, policy)
+			if err != nil {
+				return err
+			}
+			for _, installment := range changed {
+				if err := installmentRepoTx.Update(installment); err != nil {
+					return err
+				}
+				dendaBaru = dendaBaru.Add(installment.Denda.Sub(dendaSebelum[installment.ID]))
+			}
+
+			return transactionRepoTx.Update(trx)
+		},
+	)
+
+	return dendaBaru, err
+}
+
+// refreshConsumer menetapkan hari keterlambatan konsumen sebagai keterlambatan terburuk dari kontraknya.
+// Jika keterlambatan mencapai ambang pembekuan, kredit konsumen dibekukan (sekali saja) dan nilai kembalian
+// kedua bernilai true. Pencairan pembekuan tetap dilakukan oleh admin.
+func (uc *delinquencyUsecase) refreshConsumer(consumerID uint) (int, bool, error) {
+	contracts, err := uc.transactionRepo.FindActiveByConsumerID(consumerID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	dpd := 0
+	for _, contract := range contracts {
+		if contract.HariKeterlambatan > dpd {
+			dpd = contract.HariKeterlambatan
+		}
+	}
+
+	updates := map[string]interface{}{
+		"hari_keterlambatan": dpd,
+		"kolektibilitas":     domain.KolektibilitasFromDPD(dpd),
+	}
+	if uc.freezeDPD == 0 || dpd < uc.freezeDPD {
+		return dpd, false, uc.consumerRepo.Update(consumerID, updates)
+	}
+
+	frozen := false
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			consumerRepoTx := uc.consumerRepo.WithTx(tx)
+
+			consumer, err := consumerRepoTx.FindByIDForUpdate(consumerID)
+			if err != nil {
+				return err
+			}
+			if !consumer.Dibekukan {
+				catatan := fmt.Sprintf("Keterlambatan %d hari mencapai ambang pembekuan %d hari", dpd, uc.freezeDPD)
+				for column, value := range domain.FreezeUpdates(domain.KodePembekuanDPD, catatan, nil, time.Now()) {
+					updates[column] = value
+				}
+				err = uc.freezeHistoryRepo.WithTx(tx).Save(
+					&domain.CreditFreezeHistory{
+						ConsumerID:    consumerID,
+						Aksi:          domain.PembekuanAksiFreeze,
+						KodePembekuan: domain.KodePembekuanDPD,
+						Alasan:        catatan,
+					},
+				)
+				if err != nil {
+					return err
+				}
+				frozen = true
+			}
+
+			return consumerRepoTx.Update(consumerID, updates)
+		},
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return dpd, frozen, nil
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var delinquencyPricing = TenorPricingTable{
+	3: {MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24, DendaHarian: 0.001, MaksimalDenda: 0.05},
+}
+
+// newDelinquencyFixture menyiapkan kontrak 3 bulan yang angsuran pertamanya jatuh tempo 10 Februari 2026.
+func newDelinquencyFixture() (*domain.Transaction, []*domain.Installment) {
+	tanggalKontrak := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	transaction := &domain.Transaction{
+		ID:             7,
+		ConsumerID:     1,
+		TanggalKontrak: tanggalKontrak,
+		TenorBulan:     3,
+		StatusKontrak:  domain.StatusKontrakAktif,
+		Kolektibilitas: domain.KolektibilitasLancar,
+	}
+	installments := make([]*domain.Installment, 0, 3)
+	for i := 1; i <= 3; i++ {
+		installments = append(
+			installments, &domain.Installment{
+				ID:                uint(i),
+				AngsuranKe:        i,
+				TanggalJatuhTempo: addMonthsClamped(tanggalKontrak, i),
+				Pokok:             domain.NewMoney(1000000),
+				Bunga:             domain.NewMoney(60000),
+				JumlahTagihan:     domain.NewMoney(1060000),
+				Status:            domain.InstallmentStatusBelumBayar,
+			},
+		)
+	}
+	return transaction, installments
+}
+
+func TestAssessDelinquency_AccruesDailyPenalty(t *testing.T) {
+	// Arrange
+	transaction, installments := newDelinquencyFixture()
+	policy := delinquencyPricing[3]
+
+	// Act: 20 hari setelah jatuh tempo angsuran pertama
+	changed, err := assessDelinquency(transaction, installments, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), policy)
+	assert.NoError(t, err)
+
+	// Assert: 1.060.000 x 0,1% x 20 hari = 21.200
+	assert.Len(t, changed, 1)
+	assert.Equal(t, domain.NewMoney(21200), installments[0].Denda)
+	assert.True(t, installments[1].Denda.IsZero())
+	assert.Equal(t, 20, transaction.HariKeterlambatan)
+	assert.Equal(t, domain.KolektibilitasDPK, transaction.Kolektibilitas)
+}
+
+func TestAssessDelinquency_CapsPenaltyAndIsIdempotent(t *testing.T) {
+	// Arrange
+	transaction, installments := newDelinquencyFixture()
+	policy := delinquencyPricing[3]
+	asOf := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := assessDelinquency(transaction, installments, asOf, policy)
+	assert.NoError(t, err)
+	changedOnRerun, err := assessDelinquency(transaction, installments, asOf, policy)
+	assert.NoError(t, err)
+
+	// Assert: denda dibatasi 5% dari tagihan dan tidak bertambah saat dijalankan ulang
+	assert.Empty(t, changedOnRerun)
+	for _, installment := range installments {
+		assert.Equal(t, domain.NewMoney(53000), installment.Denda)
+	}
+	assert.Equal(t, 141, transaction.HariKeterlambatan)
+	assert.Equal(t, domain.KolektibilitasDiragukan, transaction.Kolektibilitas)
+}
+
+func TestKolektibilitasFromDPD(t *testing.T) {
+	assert.Equal(t, domain.KolektibilitasLancar, domain.KolektibilitasFromDPD(0))
+	assert.Equal(t, domain.KolektibilitasDPK, domain.KolektibilitasFromDPD(90))
+	assert.Equal(t, domain.KolektibilitasKurangLancar, domain.KolektibilitasFromDPD(91))
+	assert.Equal(t, domain.KolektibilitasDiragukan, domain.KolektibilitasFromDPD(180))
+	assert.Equal(t, domain.KolektibilitasMacet, domain.KolektibilitasFromDPD(181))
+}
+
+func TestAssessDelinquencyUsecase_UpdatesContractAndConsumer(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, _, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockConsumerRepo := new(MockConsumerRepository)
+	usecase := NewDelinquencyUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		mockConsumerRepo,
+		new(MockCreditFreezeHistoryRepository),
+		delinquencyPricing,
+		DefaultFreezeDPDThreshold,
+	)
+
+	transaction, installments := newDelinquencyFixture()
+	mockTransactionRepo.On(
+		"FindByStatuses",
+		[]string{domain.StatusKontrakAktif, domain.StatusKontrakRestrukturisasi},
+	).Return([]*domain.Transaction{transaction}, nil).Once()
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", installments[0]).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	mockConsumerRepo.On("FindDelinquentIDs").Return([]uint{}, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", uint(1)).Return([]*domain.Transaction{transaction}, nil).Once()
+	mockConsumerRepo.On(
+		"Update", uint(1), map[string]interface{}{
+			"hari_keterlambatan": 20,
+			"kolektibilitas":     domain.KolektibilitasDPK,
+		},
+	).Return(nil).Once()
+
+	// Act
+	output, err := usecase.AssessDelinquency(time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.KontrakDinilai)
+	assert.Equal(t, 1, output.KonsumenTerlambat)
+	assert.Equal(t, domain.NewMoney(21200), output.TotalDendaBaru)
+	assert.Empty(t, output.Errors)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockTransactionRepo.AssertExpectations(t)
+	mockInstallmentRepo.AssertExpectations(t)
+	mockConsumerRepo.AssertExpectations(t)
+	mockInstallmentRepo.AssertNotCalled(t, "Update", installments[1])
+}
+
+func TestAssessDelinquencyUsecase_ContractErrorDoesNotStopRun(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, _, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockConsumerRepo := new(MockConsumerRepository)
+	usecase := NewDelinquencyUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		mockConsumerRepo,
+		new(MockCreditFreezeHistoryRepository),
+		delinquencyPricing,
+		DefaultFreezeDPDThreshold,
+	)
+
+	mockTransactionRepo.On("FindByStatuses", mock.Anything).
+		Return([]*domain.Transaction{{ID: 9, ConsumerID: 2}}, nil).Once()
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(9)).Return(nil, assert.AnError).Once()
+	mockSQL.ExpectRollback()
+	mockConsumerRepo.On("FindDelinquentIDs").Return([]uint{}, nil).Once()
+
+	// Act
+	output, err := usecase.AssessDelinquency(time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, output.KontrakDinilai)
+	assert.Len(t, output.Errors, 1)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockConsumerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAssessDelinquencyUsecase_FreezesConsumerAtThreshold(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, _, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockConsumerRepo := new(MockConsumerRepository)
+	mockFreezeHistoryRepo := new(MockCreditFreezeHistoryRepository)
+	usecase := NewDelinquencyUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		mockConsumerRepo,
+		mockFreezeHistoryRepo,
+		delinquencyPricing,
+		15,
+	)
+
+	transaction, installments := newDelinquencyFixture()
+	mockTransactionRepo.On("FindByStatuses", mock.Anything).Return([]*domain.Transaction{transaction}, nil).Once()
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", installments[0]).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	mockConsumerRepo.On("FindDelinquentIDs").Return([]uint{}, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", uint(1)).Return([]*domain.Transaction{transaction}, nil).Once()
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(&domain.Consumer{ID: 1}, nil).Once()
+	mockFreezeHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.CreditFreezeHistory) bool {
+				return history.KodePembekuan == domain.KodePembekuanDPD && history.ChangedBy == nil
+			},
+		),
+	).Return(nil).Once()
+	mockConsumerRepo.On(
+		"Update", uint(1), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["hari_keterlambatan"] == 20 &&
+					updates["dibekukan"] == true &&
+					updates["kode_pembekuan"] == domain.KodePembekuanDPD
+			},
+		),
+	).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	output, err := usecase.AssessDelinquency(time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.KonsumenTerlambat)
+	assert.Equal(t, 1, output.KonsumenDibekukan)
+	assert.Empty(t, output.Errors)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockConsumerRepo.AssertExpectations(t)
+	mockFreezeHistoryRepo.AssertExpectations(t)
+}
+
+func TestAssessDelinquencyUsecase_ResetsConsumerWithoutRunningContracts(t *testing.T) {
+	// Arrange: kontrak terlambat terakhir konsumen 4 sudah lunas sehingga tidak ada kontrak yang dinilai
+	_, _, _, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockConsumerRepo := new(MockConsumerRepository)
+	usecase := NewDelinquencyUsecase(
+		nil,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		mockConsumerRepo,
+		new(MockCreditFreezeHistoryRepository),
+		delinquencyPricing,
+		DefaultFreezeDPDThreshold,
+	)
+
+	mockTransactionRepo.On("FindByStatuses", mock.Anything).Return([]*domain.Transaction{}, nil).Once()
+	mockConsumerRepo.On("FindDelinquentIDs").Return([]uint{4}, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", uint(4)).Return([]*domain.Transaction{}, nil).Once()
+	mockConsumerRepo.On(
+		"Update", uint(4), map[string]interface{}{
+			"hari_keterlambatan": 0,
+			"kolektibilitas":     domain.KolektibilitasLancar,
+		},
+	).Return(nil).Once()
+
+	// Act
+	output, err := usecase.AssessDelinquency(time.Date(2026, 3, 2, 0, 30, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, output.KontrakDinilai)
+	assert.Equal(t, 0, output.KonsumenTerlambat)
+	assert.Empty(t, output.Errors)
+	mockConsumerRepo.AssertExpectations(t)
+}
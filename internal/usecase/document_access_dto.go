@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"io"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// DocumentAccess menjelaskan siapa dan dari mana dokumen konsumen diakses, untuk dicatat pada audit log.
+type DocumentAccess struct {
+	UserID    *uint
+	IPAddress string
+	UserAgent string
+}
+
+// DocumentContent adalah isi dokumen konsumen beserta metadatanya. Pemanggil wajib menutup Isi.
+type DocumentContent struct {
+	Document *domain.ConsumerDocument
+	Isi      io.ReadCloser
+}
+
+// SignedDocumentQuery adalah parameter query signed URL dokumen.
+type SignedDocumentQuery struct {
+	Expires   int64  `form:"expires" binding:"required"`
+	IssuedTo  uint   `form:"issued_to" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+// SignedDocumentURL adalah URL berumur pendek untuk mengunduh dokumen konsumen tanpa token JWT.
+type SignedDocumentURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockDocumentAccessLogRepository adalah implementasi mock dari domain.DocumentAccessLogRepository.
+type MockDocumentAccessLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockDocumentAccessLogRepository) WithTx(tx *gorm.DB) domain.DocumentAccessLogRepository {
+	return m
+}
+
+func (m *MockDocumentAccessLogRepository) Save(log *domain.DocumentAccessLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+func (m *MockDocumentAccessLogRepository) FindByConsumerID(consumerID uint) ([]*domain.DocumentAccessLog, error) {
+	args := m.Called(consumerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DocumentAccessLog), args.Error(1)
+}
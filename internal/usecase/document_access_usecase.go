@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// DefaultDocumentURLTTL adalah masa berlaku signed URL dokumen konsumen.
+const DefaultDocumentURLTTL = 5 * time.Minute
+
+// minDocumentURLSecretLength adalah panjang minimal secret HMAC signed URL dokumen, dalam byte.
+const minDocumentURLSecretLength = 16
+
+// documentURLKeyLabel membedakan secret signed URL dokumen yang diturunkan dari JWT_SECRET dari secret JWT itu
+// sendiri.
+const documentURLKeyLabel = "kredit-plus/document-url/v1"
+
+// maxUserAgentLength adalah panjang maksimal User-Agent yang dicatat pada audit log akses dokumen.
+const maxUserAgentLength = 255
+
+// ErrInvalidDocumentSignature dikembalikan jika signed URL dokumen tidak valid atau sudah kedaluwarsa.
+var ErrInvalidDocumentSignature = errors.New("invalid or expired document signature")
+
+// DocumentURLConfig mengatur secret HMAC dan masa berlaku signed URL dokumen konsumen.
+type DocumentURLConfig struct {
+	Secret []byte
+	TTL    time.Duration
+}
+
+// ParseDocumentURLConfig mengurai secret dan masa berlaku signed URL (dalam menit) dari konfigurasi. Jika
+// documentSecret kosong, secret diturunkan dari jwtSecret dengan HMAC-SHA256 berlabel sehingga tidak pernah sama
+// dengan secret JWT; salah satunya wajib diisi. Masa berlaku kosong menghasilkan DefaultDocumentURLTTL.
+func ParseDocumentURLConfig(documentSecret, jwtSecret, ttlMinutes string) (DocumentURLConfig, error) {
+	var secret []byte
+	switch {
+	case documentSecret != "":
+		if len(documentSecret) < minDocumentURLSecretLength {
+			return DocumentURLConfig{}, fmt.Errorf(
+				"document URL secret must be at least %d characters", minDocumentURLSecretLength,
+			)
+		}
+		secret = []byte(documentSecret)
+	case jwtSecret != "":
+		if len(jwtSecret) < minDocumentURLSecretLength {
+			return DocumentURLConfig{}, fmt.Errorf(
+				"JWT secret must be at least %d characters to derive the document URL secret",
+				minDocumentURLSecretLength,
+			)
+		}
+		mac := hmac.New(sha256.New, []byte(jwtSecret))
+		mac.Write([]byte(documentURLKeyLabel))
+		secret = mac.Sum(nil)
+	default:
+		return DocumentURLConfig{}, errors.New("document URL secret or JWT secret must be set")
+	}
+	cfg := DocumentURLConfig{Secret: secret, TTL: DefaultDocumentURLTTL}
+	if strings.TrimSpace(ttlMinutes) == "" {
+		return cfg, nil
+	}
+	minutes, err := strconv.Atoi(strings.TrimSpace(ttlMinutes))
+	if err != nil || minutes <= 0 {
+		return DocumentURLConfig{}, fmt.Errorf(
+			"invalid document URL duration %q, expected a positive number of minutes", ttlMinutes,
+		)
+	}
+	cfg.TTL = time.Duration(minutes) * time.Minute
+	return cfg, nil
+}
+
+// DocumentAccessUsecase menyajikan dokumen identitas konsumen (foto KTP dan selfie) dan mencatat setiap
+// aksesnya. Otorisasi pemilik atau admin dilakukan oleh pemanggil; signed URL sendiri menjadi bukti otorisasi
+// bagi pemegangnya sampai kedaluwarsa.
+type DocumentAccessUsecase interface {
+	OpenDocument(consumerID uint, jenis string, access DocumentAccess) (*DocumentContent, error)
+	CreateSignedURL(consumerID uint, jenis string, access DocumentAccess) (*SignedDocumentURL, error)
+	OpenSignedDocument(documentID uint, query SignedDocumentQuery, access DocumentAccess) (*DocumentContent, error)
+	GetAccessLogs(consumerID uint) ([]*domain.DocumentAccessLog, error)
+}
+
+type documentAccessUsecase struct {
+	consumerRepo domain.ConsumerRepository
+	documentRepo domain.ConsumerDocumentRepository
+	logRepo      domain.DocumentAccessLogRepository
+	storage      domain.DocumentStorage
+	urlConfig    DocumentURLConfig
+}
+
+func NewDocumentAccessUsecase(
+	consumerRepo domain.ConsumerRepository,
+	documentRepo domain.ConsumerDocumentRepository,
+	logRepo domain.DocumentAccessLogRepository,
+	storage domain.DocumentStorage,
+	urlConfig DocumentURLConfig,
+) DocumentAccessUsecase {
+	return &documentAccessUsecase{
+		consumerRepo: consumerRepo,
+		documentRepo: documentRepo,
+		logRepo:      logRepo,
+		storage:      storage,
+		urlConfig:    urlConfig,
+	}
+}
+
+// OpenDocument membuka dokumen konsumen yang berlaku untuk jenis tertentu (ktp atau selfie).
+func (uc *documentAccessUsecase) OpenDocument(
+	consumerID uint,
+	jenis string,
+	access DocumentAccess,
+) (*DocumentContent, error) {
+	document, err := uc.currentDocument(consumerID, jenis)
+	if err != nil {
+		return nil, err
+	}
+	return uc.open(document, domain.AksiDokumenUnduh, access)
+}
+
+// CreateSignedURL membuat URL berumur pendek untuk dokumen konsumen yang berlaku, misalnya agar UI reviewer
+// dapat menampilkan gambar tanpa meneruskan token JWT. URL terikat pada dokumen dan pengguna penerimanya.
+func (uc *documentAccessUsecase) CreateSignedURL(
+	consumerID uint,
+	jenis string,
+	access DocumentAccess,
+) (*SignedDocumentURL, error) {
+	if access.UserID == nil {
+		return nil, fmt.Errorf("signed document URL requires an authenticated user")
+	}
+	document, err := uc.currentDocument(consumerID, jenis)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(uc.urlConfig.TTL).Truncate(time.Second)
+	if err := uc.logAccess(document, domain.AksiDokumenSignedURLDibuat, access); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("issued_to", strconv.FormatUint(uint64(*access.UserID), 10))
+	query.Set("signature", uc.sign(document.ID, *access.UserID, expiresAt.Unix()))
+	return &SignedDocumentURL{
+		URL:       fmt.Sprintf("/api/v1/documents/%d/content?%s", document.ID, query.Encode()),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// OpenSignedDocument membuka dokumen melalui signed URL. Akses dicatat atas nama pengguna penerima URL.
+func (uc *documentAccessUsecase) OpenSignedDocument(
+	documentID uint,
+	query SignedDocumentQuery,
+	access DocumentAccess,
+) (*DocumentContent, error) {
+	expected := uc.sign(documentID, query.IssuedTo, query.Expires)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(query.Signature))) {
+		return nil, ErrInvalidDocumentSignature
+	}
+	if time.Now().Unix() > query.Expires {
+		return nil, ErrInvalidDocumentSignature
+	}
+
+	document, err := uc.documentRepo.FindByID(documentID)
+	if err != nil {
+		return nil, domain.ErrDocumentNotFound
+	}
+	issuedTo := query.IssuedTo
+	access.UserID = &issuedTo
+	return uc.open(document, domain.AksiDokumenUnduhLewatSigned, access)
+}
+
+// GetAccessLogs mengambil audit log akses dokumen seorang konsumen, terbaru lebih dulu.
+func (uc *documentAccessUsecase) GetAccessLogs(consumerID uint) ([]*domain.DocumentAccessLog, error) {
+	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	return uc.logRepo.FindByConsumerID(consumerID)
+}
+
+// currentDocument mengambil dokumen konsumen yang berlaku untuk jenis ktp atau selfie.
+func (uc *documentAccessUsecase) currentDocument(consumerID uint, jenis string) (*domain.ConsumerDocument, error) {
+	var document *domain.ConsumerDocument
+	consumer, err := uc.consumerRepo.FindByID(consumerID)
+	if err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	switch jenis = strings.ToUpper(strings.TrimSpace(jenis)); jenis {
+	case domain.JenisDokumenKTP:
+		document = consumer.FotoKtp
+	case domain.JenisDokumenSelfie:
+		document = consumer.FotoSelfie
+	default:
+		return nil, fmt.Errorf(
+			"invalid document type: %s. allowed types are ktp, selfie", strings.ToLower(jenis),
+		)
+	}
+	if document == nil {
+		return nil, fmt.Errorf(
+			"consumer %d has no %s document: %w", consumerID, documentField(jenis), domain.ErrDocumentNotFound,
+		)
+	}
+	return document, nil
+}
+
+// open membuka isi dokumen dari DocumentStorage lalu mencatat aksesnya. Jika audit log gagal dicatat, isi
+// dokumen tidak diberikan.
+func (uc *documentAccessUsecase) open(
+	document *domain.ConsumerDocument,
+	aksi string,
+	access DocumentAccess,
+) (*DocumentContent, error) {
+	if document.StorageBackend != uc.storage.Backend() {
+		return nil, fmt.Errorf(
+			"document %d is stored on the %s backend, which is not configured",
+			document.ID, document.StorageBackend,
+		)
+	}
+	isi, err := uc.storage.Get(document.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.logAccess(document, aksi, access); err != nil {
+		_ = isi.Close()
+		return nil, err
+	}
+	return &DocumentContent{Document: document, Isi: isi}, nil
+}
+
+func (uc *documentAccessUsecase) logAccess(
+	document *domain.ConsumerDocument,
+	aksi string,
+	access DocumentAccess,
+) error {
+	// User-Agent dipotong agar muat di kolom audit log tanpa memutus karakter UTF-8.
+	userAgent := access.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	return uc.logRepo.Save(
+		&domain.DocumentAccessLog{
+			ConsumerDocumentID: document.ID,
+			ConsumerID:         document.ConsumerID,
+			Aksi:               aksi,
+			DiaksesOleh:        access.UserID,
+			IPAddress:          access.IPAddress,
+			UserAgent:          userAgent,
+		},
+	)
+}
+
+// sign menghitung HMAC-SHA256 atas dokumen, pengguna penerima, dan waktu kedaluwarsa signed URL.
+func (uc *documentAccessUsecase) sign(documentID, issuedTo uint, expires int64) string {
+	mac := hmac.New(sha256.New, uc.urlConfig.Secret)
+	fmt.Fprintf(mac, "%d:%d:%d", documentID, issuedTo, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
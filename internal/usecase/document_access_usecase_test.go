@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type documentAccessTestDeps struct {
+	mockConsumerRepo *MockConsumerRepository
+	mockDocumentRepo *MockConsumerDocumentRepository
+	mockLogRepo      *MockDocumentAccessLogRepository
+	document         *domain.ConsumerDocument
+}
+
+func setupDocumentAccessUsecase(t *testing.T) (DocumentAccessUsecase, documentAccessTestDeps) {
+	documentStorage, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	document := &domain.ConsumerDocument{
+		ID:             21,
+		ConsumerID:     1,
+		Jenis:          domain.JenisDokumenKTP,
+		StorageBackend: storage.BackendLocal,
+		StorageKey:     "consumers/1/ktp.jpg",
+		ContentType:    "image/jpeg",
+		Ukuran:         int64(len(jpegContent)),
+	}
+	assert.NoError(t, documentStorage.Put(document.StorageKey, jpegContent, document.ContentType))
+
+	deps := documentAccessTestDeps{
+		mockConsumerRepo: new(MockConsumerRepository),
+		mockDocumentRepo: new(MockConsumerDocumentRepository),
+		mockLogRepo:      new(MockDocumentAccessLogRepository),
+		document:         document,
+	}
+	usecase := NewDocumentAccessUsecase(
+		deps.mockConsumerRepo,
+		deps.mockDocumentRepo,
+		deps.mockLogRepo,
+		documentStorage,
+		DocumentURLConfig{Secret: []byte("document-url-secret-for-tests"), TTL: DefaultDocumentURLTTL},
+	)
+	return usecase, deps
+}
+
+func signedQuery(t *testing.T, signedURL *SignedDocumentURL) SignedDocumentQuery {
+	parsed, err := url.Parse(signedURL.URL)
+	assert.NoError(t, err)
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	assert.NoError(t, err)
+	issuedTo, err := strconv.ParseUint(parsed.Query().Get("issued_to"), 10, 32)
+	assert.NoError(t, err)
+	return SignedDocumentQuery{Expires: expires, IssuedTo: uint(issuedTo), Signature: parsed.Query().Get("signature")}
+}
+
+func TestDocumentAccess_OpenDocument_LogsAccess(t *testing.T) {
+	// Arrange
+	usecase, deps := setupDocumentAccessUsecase(t)
+	adminID := uint(2)
+	consumer := &domain.Consumer{ID: 1, FotoKtpID: &deps.document.ID, FotoKtp: deps.document}
+
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(consumer, nil).Once()
+	deps.mockLogRepo.On(
+		"Save", mock.MatchedBy(
+			func(log *domain.DocumentAccessLog) bool {
+				return log.ConsumerDocumentID == 21 &&
+					log.ConsumerID == 1 &&
+					log.Aksi == domain.AksiDokumenUnduh &&
+					*log.DiaksesOleh == adminID &&
+					log.IPAddress == "10.0.0.1"
+			},
+		),
+	).Return(nil).Once()
+
+	// Act
+	content, err := usecase.OpenDocument(1, "ktp", DocumentAccess{UserID: &adminID, IPAddress: "10.0.0.1"})
+
+	// Assert
+	assert.NoError(t, err)
+	isi, err := io.ReadAll(content.Isi)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Isi.Close())
+	assert.Equal(t, jpegContent, isi)
+	deps.mockLogRepo.AssertExpectations(t)
+}
+
+func TestDocumentAccess_OpenDocument_WithholdsContentWhenAuditLogFails(t *testing.T) {
+	// Arrange
+	usecase, deps := setupDocumentAccessUsecase(t)
+	adminID := uint(2)
+	consumer := &domain.Consumer{ID: 1, FotoKtpID: &deps.document.ID, FotoKtp: deps.document}
+
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(consumer, nil).Once()
+	deps.mockLogRepo.On("Save", mock.Anything).Return(errors.New("db down")).Once()
+
+	// Act
+	content, err := usecase.OpenDocument(1, "ktp", DocumentAccess{UserID: &adminID})
+
+	// Assert
+	assert.Nil(t, content)
+	assert.EqualError(t, err, "db down")
+}
+
+func TestDocumentAccess_OpenDocument_MissingDocument(t *testing.T) {
+	// Arrange
+	usecase, deps := setupDocumentAccessUsecase(t)
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(&domain.Consumer{ID: 1}, nil)
+
+	// Act
+	_, missingErr := usecase.OpenDocument(1, "selfie", DocumentAccess{})
+	_, invalidErr := usecase.OpenDocument(1, "npwp", DocumentAccess{})
+
+	// Assert
+	assert.ErrorIs(t, missingErr, domain.ErrDocumentNotFound)
+	assert.EqualError(t, invalidErr, "invalid document type: npwp. allowed types are ktp, selfie")
+	deps.mockLogRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestDocumentAccess_SignedURL_RoundTrip(t *testing.T) {
+	// Arrange
+	usecase, deps := setupDocumentAccessUsecase(t)
+	reviewerID := uint(2)
+	consumer := &domain.Consumer{ID: 1, FotoKtpID: &deps.document.ID, FotoKtp: deps.document}
+
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(consumer, nil).Once()
+	deps.mockDocumentRepo.On("FindByID", uint(21)).Return(deps.document, nil).Once()
+	deps.mockLogRepo.On(
+		"Save", mock.MatchedBy(
+			func(log *domain.DocumentAccessLog) bool {
+				return log.Aksi == domain.AksiDokumenSignedURLDibuat && *log.DiaksesOleh == reviewerID
+			},
+		),
+	).Return(nil).Once()
+	deps.mockLogRepo.On(
+		"Save", mock.MatchedBy(
+			func(log *domain.DocumentAccessLog) bool {
+				return log.Aksi == domain.AksiDokumenUnduhLewatSigned &&
+					*log.DiaksesOleh == reviewerID &&
+					log.UserAgent == "reviewer-ui"
+			},
+		),
+	).Return(nil).Once()
+
+	// Act
+	signedURL, err := usecase.CreateSignedURL(1, "KTP", DocumentAccess{UserID: &reviewerID})
+	assert.NoError(t, err)
+	query := signedQuery(t, signedURL)
+	content, err := usecase.OpenSignedDocument(21, query, DocumentAccess{UserAgent: "reviewer-ui"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, signedURL.URL, "/api/v1/documents/21/content?")
+	assert.WithinDuration(t, time.Now().Add(DefaultDocumentURLTTL), signedURL.ExpiresAt, 2*time.Second)
+	assert.NoError(t, content.Isi.Close())
+	deps.mockLogRepo.AssertExpectations(t)
+}
+
+func TestDocumentAccess_OpenSignedDocument_RejectsTamperedOrExpiredURL(t *testing.T) {
+	// Arrange
+	usecase, deps := setupDocumentAccessUsecase(t)
+	reviewerID := uint(2)
+	consumer := &domain.Consumer{ID: 1, FotoKtpID: &deps.document.ID, FotoKtp: deps.document}
+	deps.mockConsumerRepo.On("FindByID", uint(1)).Return(consumer, nil).Once()
+	deps.mockLogRepo.On("Save", mock.Anything).Return(nil).Once()
+
+	signedURL, err := usecase.CreateSignedURL(1, "ktp", DocumentAccess{UserID: &reviewerID})
+	assert.NoError(t, err)
+	valid := signedQuery(t, signedURL)
+
+	otherDocument := valid
+	otherUser := valid
+	otherUser.IssuedTo = 3
+	extended := valid
+	extended.Expires += 3600
+	expired := valid
+	expired.Expires = time.Now().Add(-time.Minute).Unix()
+
+	tests := []struct {
+		name       string
+		documentID uint
+		query      SignedDocumentQuery
+	}{
+		{name: "dokumen lain", documentID: 22, query: otherDocument},
+		{name: "pengguna lain", documentID: 21, query: otherUser},
+		{name: "masa berlaku diperpanjang", documentID: 21, query: extended},
+		{name: "kedaluwarsa", documentID: 21, query: expired},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Act
+				content, err := usecase.OpenSignedDocument(tt.documentID, tt.query, DocumentAccess{})
+
+				// Assert
+				assert.Nil(t, content)
+				assert.ErrorIs(t, err, ErrInvalidDocumentSignature)
+			},
+		)
+	}
+	deps.mockDocumentRepo.AssertNotCalled(t, "FindByID", mock.Anything)
+	deps.mockLogRepo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestParseDocumentURLConfig(t *testing.T) {
+	cfg, err := ParseDocumentURLConfig("document-url-secret-for-tests", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("document-url-secret-for-tests"), cfg.Secret)
+	assert.Equal(t, DefaultDocumentURLTTL, cfg.TTL)
+
+	cfg, err = ParseDocumentURLConfig("document-url-secret-for-tests", "", "15")
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, cfg.TTL)
+
+	_, err = ParseDocumentURLConfig("pendek", "jwt-secret-for-tests", "")
+	assert.Error(t, err)
+
+	_, err = ParseDocumentURLConfig("document-url-secret-for-tests", "", "-1")
+	assert.Error(t, err)
+}
+
+func TestParseDocumentURLConfig_DerivesSecretFromJWTSecret(t *testing.T) {
+	// Act
+	cfg, err := ParseDocumentURLConfig("", "jwt-secret-for-tests", "")
+	other, otherErr := ParseDocumentURLConfig("", "other-jwt-secret-for-tests", "")
+	_, missingErr := ParseDocumentURLConfig("", "", "")
+	_, shortErr := ParseDocumentURLConfig("", "pendek", "")
+
+	// Assert: secret turunan berbeda dari secret JWT dan bergantung padanya.
+	assert.NoError(t, err)
+	assert.NoError(t, otherErr)
+	assert.Len(t, cfg.Secret, 32)
+	assert.NotEqual(t, []byte("jwt-secret-for-tests"), cfg.Secret)
+	assert.NotEqual(t, other.Secret, cfg.Secret)
+	assert.EqualError(t, missingErr, "document URL secret or JWT secret must be set")
+	assert.Error(t, shortErr)
+}
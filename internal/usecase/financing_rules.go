@@ -0,0 +1,107 @@
+package usecase
+
+import "github.com/adty404/kredit-plus/internal/domain"
+
+// financingTerms adalah hasil validasi aturan pembiayaan produk untuk sebuah pengajuan.
+type financingTerms struct {
+	tenor           *domain.ProductTenor
+	adminFee        domain.Money
+	pokokPembiayaan domain.Money
+}
+
+// applyFinancingRules memvalidasi pengajuan terhadap aturan produk untuk jenis asetnya: tenor yang ditawarkan,
+// rentang OTR, uang muka minimal, biaya admin, rasio pembiayaan terhadap OTR (LTV), dan pembiayaan maksimal.
+// Pelanggaran dikembalikan sebagai *RuleViolationError dengan kode yang stabil.
+func applyFinancingRules(product *domain.Product, input CreateTransactionInput) (*financingTerms, error) {
+	// 1. Tenor harus ditawarkan produk
+	tenor, ok := product.Tenor(input.TenorMonths)
+	if !ok {
+		return nil, newRuleViolation(
+			RuleTenorNotOffered, "tenor_months",
+			"tenor %d months is not offered for product %s", input.TenorMonths, product.Kode,
+		)
+	}
+
+	// 2. OTR harus berada dalam rentang yang dapat dibiayai produk
+	if product.MinimalOtr.IsPositive() && input.Otr.LessThan(product.MinimalOtr) {
+		return nil, newRuleViolation(
+			RuleOtrBelowMinimum, "otr",
+			"otr (%s) is below the minimum for product %s (%s)", input.Otr, product.Kode, product.MinimalOtr,
+		)
+	}
+	if product.MaksimalOtr.IsPositive() && input.Otr.GreaterThan(product.MaksimalOtr) {
+		return nil, newRuleViolation(
+			RuleOtrAboveMaximum, "otr",
+			"otr (%s) exceeds the maximum for product %s (%s)", input.Otr, product.Kode, product.MaksimalOtr,
+		)
+	}
+
+	// 3. Uang muka harus lebih kecil dari OTR dan tidak kurang dari minimal produk
+	if input.UangMuka.Cmp(input.Otr) >= 0 {
+		return nil, newRuleViolation(
+			RuleDownPaymentNotBelowOtr, "uang_muka",
+			"down payment (%s) must be less than otr (%s)", input.UangMuka, input.Otr,
+		)
+	}
+	minimalUangMuka, err := input.Otr.MulRound(product.MinimalUangMukaPersen, domain.SatuRupiah)
+	if err != nil {
+		return nil, err
+	}
+	if input.UangMuka.LessThan(minimalUangMuka) {
+		return nil, newRuleViolation(
+			RuleDownPaymentBelowMinimum, "uang_muka",
+			"down payment (%s) is below the minimum for product %s (%s)", input.UangMuka, product.Kode, minimalUangMuka,
+		)
+	}
+
+	// 4. Biaya admin yang tidak diisi mengikuti produk. Jika produk memiliki rentang biaya admin, nilai yang
+	// dikirim klien harus berada di dalam rentang tersebut; jika tidak, harus sama dengan biaya admin produk.
+	adminFee := input.AdminFee
+	biayaAdmin, err := product.BiayaAdmin(input.Otr)
+	if err != nil {
+		return nil, err
+	}
+	if adminFee.IsZero() {
+		adminFee = biayaAdmin
+	}
+	if product.MinimalBiayaAdmin.IsPositive() || product.MaksimalBiayaAdmin.IsPositive() {
+		if adminFee.LessThan(product.MinimalBiayaAdmin) ||
+			(product.MaksimalBiayaAdmin.IsPositive() && adminFee.GreaterThan(product.MaksimalBiayaAdmin)) {
+			return nil, newRuleViolation(
+				RuleAdminFeeOutOfRange, "admin_fee",
+				"admin fee (%s) is outside the allowed range for product %s (%s - %s)",
+				adminFee, product.Kode, product.MinimalBiayaAdmin, product.MaksimalBiayaAdmin,
+			)
+		}
+	} else if biayaAdmin.IsPositive() && adminFee.Cmp(biayaAdmin) != 0 {
+		return nil, newRuleViolation(
+			RuleAdminFeeMismatch, "admin_fee",
+			"admin fee (%s) does not match the fee for product %s (%s)", adminFee, product.Kode, biayaAdmin,
+		)
+	}
+
+	// 5. Pokok pembiayaan dibatasi rasio terhadap OTR (LTV) dan pembiayaan maksimal produk
+	pokokPembiayaan := input.Otr.Sub(input.UangMuka).Add(adminFee)
+	if product.MaksimalLTV > 0 {
+		maksimalPokok, err := input.Otr.MulRound(product.MaksimalLTV, domain.SatuSen)
+		if err != nil {
+			return nil, err
+		}
+		if pokokPembiayaan.GreaterThan(maksimalPokok) {
+			return nil, newRuleViolation(
+				RuleLtvAboveMaximum, "uang_muka",
+				"loan amount (%s) exceeds maximum loan-to-value of %v for product %s (%s)",
+				pokokPembiayaan, product.MaksimalLTV, product.Kode, maksimalPokok,
+			)
+		}
+	}
+	if product.MaksimalPembiayaan.IsPositive() && pokokPembiayaan.GreaterThan(product.MaksimalPembiayaan) {
+		return nil, newRuleViolation(
+			RuleFinancingAboveMaximum, "otr",
+			"loan amount (%s) exceeds maximum financing for product %s (%s)",
+			pokokPembiayaan, product.Kode, product.MaksimalPembiayaan,
+		)
+	}
+
+	return &financingTerms{tenor: tenor, adminFee: adminFee, pokokPembiayaan: pokokPembiayaan}, nil
+}
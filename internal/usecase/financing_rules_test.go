@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRuleProduct membuat produk ELEKTRONIK dengan seluruh aturan pembiayaan terisi.
+func newRuleProduct() *domain.Product {
+	return &domain.Product{
+		Kode:                  "ELEKTRONIK",
+		MinimalUangMukaPersen: 0.1,
+		MaksimalPembiayaan:    domain.NewMoney(8000000),
+		MaksimalLTV:           0.85,
+		MinimalOtr:            domain.NewMoney(500000),
+		MaksimalOtr:           domain.NewMoney(10000000),
+		BiayaAdminTetap:       domain.NewMoney(100000),
+		MinimalBiayaAdmin:     domain.NewMoney(50000),
+		MaksimalBiayaAdmin:    domain.NewMoney(200000),
+		Aktif:                 true,
+		Tenors:                []domain.ProductTenor{{TenorBulan: 3, MetodeBunga: MetodeBungaFlat}},
+	}
+}
+
+func TestApplyFinancingRules_Violations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		otr      int64
+		uangMuka int64
+		adminFee int64
+		tenor    int
+		code     string
+		field    string
+	}{
+		{name: "tenor tidak ditawarkan", otr: 2000000, uangMuka: 400000, tenor: 6, code: RuleTenorNotOffered, field: "tenor_months"},
+		{name: "otr di bawah minimal", otr: 400000, uangMuka: 100000, tenor: 3, code: RuleOtrBelowMinimum, field: "otr"},
+		{name: "otr di atas maksimal", otr: 12000000, uangMuka: 4000000, tenor: 3, code: RuleOtrAboveMaximum, field: "otr"},
+		{name: "uang muka melebihi otr", otr: 2000000, uangMuka: 2500000, tenor: 3, code: RuleDownPaymentNotBelowOtr, field: "uang_muka"},
+		{name: "uang muka sama dengan otr", otr: 2000000, uangMuka: 2000000, tenor: 3, code: RuleDownPaymentNotBelowOtr, field: "uang_muka"},
+		{name: "uang muka di bawah minimal", otr: 2000000, uangMuka: 100000, tenor: 3, code: RuleDownPaymentBelowMinimum, field: "uang_muka"},
+		{name: "biaya admin terlalu kecil", otr: 2000000, uangMuka: 400000, adminFee: 10000, tenor: 3, code: RuleAdminFeeOutOfRange, field: "admin_fee"},
+		{name: "biaya admin terlalu besar", otr: 2000000, uangMuka: 400000, adminFee: 300000, tenor: 3, code: RuleAdminFeeOutOfRange, field: "admin_fee"},
+		{name: "ltv di atas maksimal", otr: 2000000, uangMuka: 250000, tenor: 3, code: RuleLtvAboveMaximum, field: "uang_muka"},
+		{name: "pembiayaan di atas maksimal", otr: 10000000, uangMuka: 1900000, tenor: 3, code: RuleFinancingAboveMaximum, field: "otr"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				input := CreateTransactionInput{
+					TenorMonths: tc.tenor,
+					Otr:         domain.NewMoney(tc.otr),
+					UangMuka:    domain.NewMoney(tc.uangMuka),
+					AdminFee:    domain.NewMoney(tc.adminFee),
+				}
+
+				// Act
+				terms, err := applyFinancingRules(newRuleProduct(), input)
+
+				// Assert
+				assert.Nil(t, terms)
+				violation := AsRuleViolation(err)
+				if assert.NotNil(t, violation) {
+					assert.Equal(t, tc.code, violation.Code)
+					assert.Equal(t, tc.field, violation.Field)
+				}
+			},
+		)
+	}
+}
+
+func TestApplyFinancingRules_AdminFeeWithinRange(t *testing.T) {
+	// Arrange: biaya admin kosong mengikuti produk, biaya admin lain diterima selama di dalam rentang
+	product := newRuleProduct()
+	input := CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(2000000), UangMuka: domain.NewMoney(500000)}
+
+	// Act
+	defaultTerms, err := applyFinancingRules(product, input)
+	if !assert.NoError(t, err) {
+		return
+	}
+	input.AdminFee = domain.NewMoney(150000)
+	customTerms, err := applyFinancingRules(product, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(100000), defaultTerms.adminFee)
+	assert.Equal(t, domain.NewMoney(1600000), defaultTerms.pokokPembiayaan)
+	assert.Equal(t, domain.NewMoney(150000), customTerms.adminFee)
+	assert.Equal(t, domain.NewMoney(1650000), customTerms.pokokPembiayaan)
+	assert.Equal(t, 3, customTerms.tenor.TenorBulan)
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// MaxIdempotencyKeyLength mengikuti panjang kolom idempotency_keys.kunci.
+const MaxIdempotencyKeyLength = 100
+
+// ErrIdempotencyKeyReused dikembalikan jika sebuah Idempotency-Key dipakai ulang dengan isi permintaan berbeda.
+var ErrIdempotencyKeyReused = errors.New("idempotency key has already been used with a different request")
+
+// idempotencyRequestHash menghitung sidik jari SHA-256 dari isi permintaan yang sudah di-parse,
+// sehingga perbedaan format JSON (spasi, urutan field) tidak dianggap sebagai permintaan berbeda.
+func idempotencyRequestHash(request interface{}) (string, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replayIdempotentResponse memuat respons tersimpan untuk kunci ke response. Mengembalikan false jika kunci
+// kosong atau belum pernah dipakai. Harus dipanggil setelah baris yang menjadi dasar permintaan dikunci,
+// agar permintaan ulang yang paralel menunggu permintaan pertama selesai lalu membaca hasilnya.
+func replayIdempotentResponse(
+	repo domain.IdempotencyKeyRepository,
+	scope, kunci, requestHash string,
+	response interface{},
+) (bool, error) {
+	if kunci == "" {
+		return false, nil
+	}
+
+	stored, err := repo.FindByScopeAndKey(scope, kunci)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if stored.RequestHash != requestHash {
+		return false, ErrIdempotencyKeyReused
+	}
+	return true, json.Unmarshal([]byte(stored.ResponseBody), response)
+}
+
+// storeIdempotentResponse menyimpan respons untuk kunci di dalam transaksi database yang sama dengan
+// data yang dibuat, sehingga kunci hanya tercatat jika permintaannya berhasil.
+func storeIdempotentResponse(
+	repo domain.IdempotencyKeyRepository,
+	scope, kunci, requestHash string,
+	response interface{},
+) error {
+	if kunci == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return repo.Save(
+		&domain.IdempotencyKey{
+			Scope:        scope,
+			Kunci:        kunci,
+			RequestHash:  requestHash,
+			ResponseBody: string(body),
+		},
+	)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockIdempotencyKeyRepository adalah implementasi mock dari domain.IdempotencyKeyRepository.
+type MockIdempotencyKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyKeyRepository) WithTx(tx *gorm.DB) domain.IdempotencyKeyRepository {
+	return m
+}
+
+func (m *MockIdempotencyKeyRepository) Save(key *domain.IdempotencyKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyKeyRepository) FindByScopeAndKey(scope, kunci string) (*domain.IdempotencyKey, error) {
+	args := m.Called(scope, kunci)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdempotencyKey), args.Error(1)
+}
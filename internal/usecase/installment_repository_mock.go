@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockInstallmentRepository adalah implementasi mock dari domain.InstallmentRepository.
+type MockInstallmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockInstallmentRepository) WithTx(tx *gorm.DB) domain.InstallmentRepository {
+	return m
+}
+
+func (m *MockInstallmentRepository) SaveAll(installments []*domain.Installment) error {
+	args := m.Called(installments)
+	return args.Error(0)
+}
+
+func (m *MockInstallmentRepository) FindByTransactionID(transactionID uint) ([]*domain.Installment, error) {
+	args := m.Called(transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Installment), args.Error(1)
+}
+
+func (m *MockInstallmentRepository) FindOutstandingByTransactionID(transactionID uint) ([]*domain.Installment, error) {
+	args := m.Called(transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Installment), args.Error(1)
+}
+
+func (m *MockInstallmentRepository) Update(installment *domain.Installment) error {
+	args := m.Called(installment)
+	return args.Error(0)
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// buildInstallmentSchedule membentuk jadwal angsuran untuk sebuah transaksi dari rincian pokok dan bunga
+// per periode yang dihasilkan InterestCalculator, sehingga jadwal selalu konsisten dengan TotalBunga.
+func buildInstallmentSchedule(trx *domain.Transaction, lines []InterestScheduleLine) []*domain.Installment {
+	installments := make([]*domain.Installment, 0, len(lines))
+	for i, line := range lines {
+		periode := i + 1
+		installments = append(
+			installments, &domain.Installment{
+				TransactionID:     trx.ID,
+				AngsuranKe:        periode,
+				TanggalJatuhTempo: addMonthsClamped(trx.TanggalKontrak, periode),
+				Pokok:             line.Pokok,
+				Bunga:             line.Bunga,
+				JumlahTagihan:     line.Pokok.Add(line.Bunga),
+				Status:            domain.InstallmentStatusBelumBayar,
+			},
+		)
+	}
+	return installments
+}
+
+// addMonthsClamped menambahkan sejumlah bulan ke sebuah tanggal tanpa "meluber" ke bulan berikutnya.
+// Contoh: 31 Januari + 1 bulan menjadi 28/29 Februari, bukan 3 Maret.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	firstOfTarget := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// Metode perhitungan bunga yang didukung.
+const (
+	MetodeBungaFlat    = "FLAT"
+	MetodeBungaAnuitas = "ANUITAS"
+	MetodeBungaEfektif = "EFEKTIF"
+)
+
+// InterestScheduleLine adalah porsi pokok dan bunga untuk satu periode angsuran.
+// Kedua porsi dibulatkan ke rupiah utuh, kecuali porsi pokok periode terakhir yang menampung sisa pembulatan.
+type InterestScheduleLine struct {
+	Pokok domain.Money
+	Bunga domain.Money
+}
+
+// InterestCalculation adalah hasil perhitungan bunga: total bunga dan rincian per periode.
+// Jumlah Pokok seluruh baris selalu sama dengan pokok pembiayaan.
+type InterestCalculation struct {
+	TotalBunga domain.Money
+	Lines      []InterestScheduleLine
+}
+
+// InterestCalculator menghitung bunga pembiayaan berdasarkan pokok, tenor, dan suku bunga tahunan.
+type InterestCalculator interface {
+	Metode() string
+	Calculate(pokok domain.Money, tenorMonths int, sukuBungaTahunan float64) (InterestCalculation, error)
+}
+
+// NewInterestCalculator mengembalikan kalkulator untuk metode bunga yang diminta.
+func NewInterestCalculator(metode string) (InterestCalculator, error) {
+	switch strings.ToUpper(metode) {
+	case MetodeBungaFlat:
+		return flatInterestCalculator{}, nil
+	case MetodeBungaAnuitas:
+		return annuityInterestCalculator{}, nil
+	case MetodeBungaEfektif:
+		return effectiveInterestCalculator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown interest method: %s", metode)
+	}
+}
+
+// flatInterestCalculator menghitung bunga dari pokok awal untuk setiap periode (bunga flat).
+type flatInterestCalculator struct{}
+
+func (flatInterestCalculator) Metode() string { return MetodeBungaFlat }
+
+func (flatInterestCalculator) Calculate(
+	pokok domain.Money,
+	tenorMonths int,
+	sukuBungaTahunan float64,
+) (InterestCalculation, error) {
+	bungaPerPeriode, err := pokok.MulRound(sukuBungaTahunan/12, domain.SatuRupiah)
+	if err != nil {
+		return InterestCalculation{}, err
+	}
+	pokokPerPeriode, err := pokok.DivRound(int64(tenorMonths), domain.SatuRupiah)
+	if err != nil {
+		return InterestCalculation{}, err
+	}
+	return buildCalculation(
+		pokok, tenorMonths, func(domain.Money) (domain.Money, domain.Money, error) {
+			return pokokPerPeriode, bungaPerPeriode, nil
+		},
+	)
+}
+
+// annuityInterestCalculator menghasilkan angsuran tetap dengan suku bunga efektif bulanan (anuitas).
+type annuityInterestCalculator struct{}
+
+func (annuityInterestCalculator) Metode() string { return MetodeBungaAnuitas }
+
+func (annuityInterestCalculator) Calculate(
+	pokok domain.Money,
+	tenorMonths int,
+	sukuBungaTahunan float64,
+) (InterestCalculation, error) {
+	rate := sukuBungaTahunan / 12
+	if rate == 0 {
+		return flatInterestCalculator{}.Calculate(pokok, tenorMonths, 0)
+	}
+	angsuran, err := pokok.MulRound(rate/(1-math.Pow(1+rate, -float64(tenorMonths))), domain.SatuRupiah)
+	if err != nil {
+		return InterestCalculation{}, err
+	}
+	return buildCalculation(
+		pokok, tenorMonths, func(sisaPokok domain.Money) (domain.Money, domain.Money, error) {
+			bunga, err := sisaPokok.MulRound(rate, domain.SatuRupiah)
+			return angsuran.Sub(bunga), bunga, err
+		},
+	)
+}
+
+// effectiveInterestCalculator membayar pokok sama rata dengan bunga dihitung dari sisa pokok (sliding/menurun).
+type effectiveInterestCalculator struct{}
+
+func (effectiveInterestCalculator) Metode() string { return MetodeBungaEfektif }
+
+func (effectiveInterestCalculator) Calculate(
+	pokok domain.Money,
+	tenorMonths int,
+	sukuBungaTahunan float64,
+) (InterestCalculation, error) {
+	rate := sukuBungaTahunan / 12
+	pokokPerPeriode, err := pokok.DivRound(int64(tenorMonths), domain.SatuRupiah)
+	if err != nil {
+		return InterestCalculation{}, err
+	}
+	return buildCalculation(
+		pokok, tenorMonths, func(sisaPokok domain.Money) (domain.Money, domain.Money, error) {
+			bunga, err := sisaPokok.MulRound(rate, domain.SatuRupiah)
+			return pokokPerPeriode, bunga, err
+		},
+	)
+}
+
+// buildCalculation menyusun baris jadwal dari fungsi per periode. Periode terakhir selalu melunasi
+// sisa pokok sehingga selisih pembulatan ke rupiah utuh tidak tertinggal.
+func buildCalculation(
+	pokok domain.Money,
+	tenorMonths int,
+	period func(sisaPokok domain.Money) (pokok domain.Money, bunga domain.Money, err error),
+) (InterestCalculation, error) {
+	calculation := InterestCalculation{Lines: make([]InterestScheduleLine, 0, tenorMonths)}
+	sisaPokok := pokok
+	for periode := 1; periode <= tenorMonths; periode++ {
+		porsiPokok, porsiBunga, err := period(sisaPokok)
+		if err != nil {
+			return InterestCalculation{}, err
+		}
+		if periode == tenorMonths || porsiPokok.GreaterThan(sisaPokok) {
+			porsiPokok = sisaPokok
+		}
+		sisaPokok = sisaPokok.Sub(porsiPokok)
+		calculation.TotalBunga = calculation.TotalBunga.Add(porsiBunga)
+		calculation.Lines = append(calculation.Lines, InterestScheduleLine{Pokok: porsiPokok, Bunga: porsiBunga})
+	}
+	return calculation, nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func sumPokok(calculation InterestCalculation) domain.Money {
+	var total domain.Money
+	for _, line := range calculation.Lines {
+		total = total.Add(line.Pokok)
+	}
+	return total
+}
+
+func TestInterestCalculator_Flat(t *testing.T) {
+	calculator, err := NewInterestCalculator(MetodeBungaFlat)
+	assert.NoError(t, err)
+
+	calculation, err := calculator.Calculate(domain.NewMoney(1200000), 12, 0.12)
+	assert.NoError(t, err)
+
+	assert.Len(t, calculation.Lines, 12)
+	assert.Equal(t, domain.NewMoney(144000), calculation.TotalBunga)
+	assert.Equal(t, domain.NewMoney(1200000), sumPokok(calculation))
+	for _, line := range calculation.Lines {
+		assert.Equal(t, domain.NewMoney(12000), line.Bunga)
+	}
+}
+
+func TestInterestCalculator_Annuity(t *testing.T) {
+	calculator, err := NewInterestCalculator(MetodeBungaAnuitas)
+	assert.NoError(t, err)
+
+	calculation, err := calculator.Calculate(domain.NewMoney(1200000), 12, 0.12)
+	assert.NoError(t, err)
+
+	assert.Len(t, calculation.Lines, 12)
+	assert.Equal(t, domain.NewMoney(1200000), sumPokok(calculation))
+	assert.InDelta(t, 79422, calculation.TotalBunga.Sen()/100, 10)
+	// Angsuran anuitas tetap (dibulatkan ke rupiah utuh), bunga menurun seiring berkurangnya pokok.
+	for _, line := range calculation.Lines[:11] {
+		assert.Equal(t, domain.NewMoney(106619), line.Pokok.Add(line.Bunga))
+	}
+	assert.True(t, calculation.Lines[0].Bunga.GreaterThan(calculation.Lines[11].Bunga))
+}
+
+func TestInterestCalculator_Effective(t *testing.T) {
+	calculator, err := NewInterestCalculator(MetodeBungaEfektif)
+	assert.NoError(t, err)
+
+	calculation, err := calculator.Calculate(domain.NewMoney(1200000), 12, 0.12)
+	assert.NoError(t, err)
+
+	assert.Len(t, calculation.Lines, 12)
+	assert.Equal(t, domain.NewMoney(78000), calculation.TotalBunga)
+	assert.Equal(t, domain.NewMoney(1200000), sumPokok(calculation))
+	assert.Equal(t, domain.NewMoney(12000), calculation.Lines[0].Bunga)
+	assert.Equal(t, domain.NewMoney(1000), calculation.Lines[11].Bunga)
+}
+
+func TestInterestCalculator_RoundsToRupiahWithRemainderOnLastPeriod(t *testing.T) {
+	calculator, err := NewInterestCalculator(MetodeBungaFlat)
+	assert.NoError(t, err)
+
+	calculation, err := calculator.Calculate(domain.NewMoneyFromSen(100000050), 3, 0.24)
+	assert.NoError(t, err)
+
+	// 1.000.000,50 / 3 = 333.333,50 dibulatkan ke atas menjadi 333.334; sisa pembulatan ada di periode terakhir.
+	assert.Equal(t, domain.NewMoney(333334), calculation.Lines[0].Pokok)
+	assert.Equal(t, domain.NewMoney(333334), calculation.Lines[1].Pokok)
+	assert.Equal(t, domain.NewMoneyFromSen(33333250), calculation.Lines[2].Pokok)
+	assert.Equal(t, domain.NewMoneyFromSen(100000050), sumPokok(calculation))
+	// 1.000.000,50 x 2% = 20.000,01 dibulatkan ke 20.000.
+	assert.Equal(t, domain.NewMoney(20000), calculation.Lines[0].Bunga)
+	assert.Equal(t, domain.NewMoney(60000), calculation.TotalBunga)
+}
+
+func TestNewInterestCalculator_Unknown(t *testing.T) {
+	_, err := NewInterestCalculator("BALLOON")
+	assert.Error(t, err)
+}
+
+func TestParseTenorPricingTable(t *testing.T) {
+	table, err := ParseTenorPricingTable("3:anuitas:0.26, 6:EFEKTIF:0.28")
+	assert.NoError(t, err)
+
+	policy, err := table.Resolve("MOTOR", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, PricingPolicy{MetodeBunga: MetodeBungaAnuitas, SukuBungaTahunan: 0.26}, policy)
+
+	_, err = table.Resolve("MOTOR", 1)
+	assert.Error(t, err)
+
+	_, err = ParseTenorPricingTable("3:anuitas")
+	assert.Error(t, err)
+
+	table, err = ParseTenorPricingTable("3:FLAT:0.24:0.02:0.001:0.5")
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		PricingPolicy{
+			MetodeBunga:              MetodeBungaFlat,
+			SukuBungaTahunan:         0.24,
+			BiayaPelunasanDipercepat: 0.02,
+			DendaHarian:              0.001,
+			MaksimalDenda:            0.5,
+		},
+		table[3],
+	)
+
+	_, err = ParseTenorPricingTable("3:FLAT:0.24:0.02:0.001")
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// checkKYCApproved menolak pemberian limit maupun penarikan pinjaman untuk konsumen yang KYC-nya belum disetujui.
+func checkKYCApproved(consumer *domain.Consumer) error {
+	if consumer.IsKYCApproved() {
+		return nil
+	}
+	violation := newRuleViolation(
+		RuleKYCNotApproved, "",
+		"consumer KYC is %s, credit is only available after KYC is approved", consumer.StatusKYC,
+	)
+	violation.cause = ErrKYCNotApproved
+	return violation
+}
+
+// prepareKYCDocuments memvalidasi foto KTP dan selfie yang diunggah; dokumen yang tidak dikirim bernilai nil.
+func prepareKYCDocuments(
+	documents DocumentStore,
+	fotoKtp, fotoSelfie *DocumentUpload,
+) (*PreparedDocument, *PreparedDocument, error) {
+	var preparedKtp, preparedSelfie *PreparedDocument
+	var err error
+	if fotoKtp != nil {
+		if preparedKtp, err = documents.Prepare(domain.JenisDokumenKTP, fotoKtp); err != nil {
+			return nil, nil, err
+		}
+	}
+	if fotoSelfie != nil {
+		if preparedSelfie, err = documents.Prepare(domain.JenisDokumenSelfie, fotoSelfie); err != nil {
+			return nil, nil, err
+		}
+	}
+	return preparedKtp, preparedSelfie, nil
+}
+
+// storeKYCDocuments menyimpan foto KTP dan selfie yang sudah divalidasi di dalam tx, menautkannya ke consumer,
+// dan mengembalikan kolom konsumen yang perlu diperbarui. Dokumen yang tersimpan ditambahkan ke stored agar
+// dapat dibuang jika transaksi gagal.
+func storeKYCDocuments(
+	tx *gorm.DB,
+	documents DocumentStore,
+	consumer *domain.Consumer,
+	uploadedBy *uint,
+	fotoKtp, fotoSelfie *PreparedDocument,
+	stored *[]*domain.ConsumerDocument,
+) (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+	if fotoKtp != nil {
+		document, err := documents.Store(tx, consumer.ID, uploadedBy, fotoKtp)
+		if err != nil {
+			return nil, err
+		}
+		*stored = append(*stored, document)
+		consumer.FotoKtpID = &document.ID
+		consumer.FotoKtp = document
+		updates["foto_ktp_id"] = document.ID
+	}
+	if fotoSelfie != nil {
+		document, err := documents.Store(tx, consumer.ID, uploadedBy, fotoSelfie)
+		if err != nil {
+			return nil, err
+		}
+		*stored = append(*stored, document)
+		consumer.FotoSelfieID = &document.ID
+		consumer.FotoSelfie = document
+		updates["foto_selfie_id"] = document.ID
+	}
+	return updates, nil
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import "mime/multipart"
+
+// SubmitKYCFormInput adalah form multipart pengajuan (ulang) dokumen KYC. Foto yang tidak dikirim memakai
+// dokumen yang sudah tersimpan sebelumnya.
+type SubmitKYCFormInput struct {
+	FotoKtp    *multipart.FileHeader `form:"foto_ktp" binding:"omitempty"`
+	FotoSelfie *multipart.FileHeader `form:"foto_selfie" binding:"omitempty"`
+}
+
+// SubmitKYCInput berisi dokumen KYC yang diunggah; dokumen nil berarti dokumen lama tidak diganti.
+type SubmitKYCInput struct {
+	FotoKtp    *DocumentUpload
+	FotoSelfie *DocumentUpload
+}
+
+type ApproveKYCInput struct {
+	Catatan string `json:"catatan"`
+}
+
+type RejectKYCInput struct {
+	Alasan string `json:"alasan" binding:"required,min=5"`
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockKYCHistoryRepository adalah implementasi mock dari domain.KYCHistoryRepository.
+type MockKYCHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockKYCHistoryRepository) WithTx(tx *gorm.DB) domain.KYCHistoryRepository {
+	return m
+}
+
+func (m *MockKYCHistoryRepository) Save(history *domain.KYCHistory) error {
+	args := m.Called(history)
+	return args.Error(0)
+}
+
+func (m *MockKYCHistoryRepository) FindByConsumerID(consumerID uint) ([]*domain.KYCHistory, error) {
+	args := m.Called(consumerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.KYCHistory), args.Error(1)
+}
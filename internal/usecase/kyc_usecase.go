@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// KYCUsecase mengelola verifikasi identitas konsumen: pengajuan dokumen oleh konsumen, antrean reviewer,
+// serta persetujuan dan penolakan beserta alasannya.
+type KYCUsecase interface {
+	SubmitKYC(consumerID, changedBy uint, input SubmitKYCInput) (*domain.Consumer, error)
+	StartKYCReview(consumerID, reviewerID uint) (*domain.Consumer, error)
+	ApproveKYC(consumerID, reviewerID uint, input ApproveKYCInput) (*domain.Consumer, error)
+	RejectKYC(consumerID, reviewerID uint, input RejectKYCInput) (*domain.Consumer, error)
+	GetKYCQueue(status string) ([]*domain.Consumer, error)
+	GetKYCHistory(consumerID uint) ([]*domain.KYCHistory, error)
+}
+
+type kycUsecase struct {
+	db           *gorm.DB
+	consumerRepo domain.ConsumerRepository
+	historyRepo  domain.KYCHistoryRepository
+	documents    DocumentStore
+}
+
+func NewKYCUsecase(
+	db *gorm.DB,
+	consumerRepo domain.ConsumerRepository,
+	historyRepo domain.KYCHistoryRepository,
+	documents DocumentStore,
+) KYCUsecase {
+	return &kycUsecase{
+		db:           db,
+		consumerRepo: consumerRepo,
+		historyRepo:  historyRepo,
+		documents:    documents,
+	}
+}
+
+// SubmitKYC mengajukan dokumen KYC konsumen untuk diverifikasi, termasuk pengajuan ulang setelah ditolak.
+// Dokumen baru menggantikan dokumen lama, dan foto KTP serta selfie wajib tersedia.
+func (uc *kycUsecase) SubmitKYC(consumerID, changedBy uint, input SubmitKYCInput) (*domain.Consumer, error) {
+	fotoKtp, fotoSelfie, err := prepareKYCDocuments(uc.documents, input.FotoKtp, input.FotoSelfie)
+	if err != nil {
+		return nil, err
+	}
+	var storedDocuments []*domain.ConsumerDocument
+
+	consumer, err := uc.changeKYCStatus(
+		consumerID, changedBy, domain.StatusKYCSubmitted, "",
+		func(tx *gorm.DB, consumer *domain.Consumer, now time.Time) (map[string]interface{}, error) {
+			updates, err := storeKYCDocuments(
+				tx, uc.documents, consumer, &changedBy, fotoKtp, fotoSelfie, &storedDocuments,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if consumer.FotoKtpID == nil || consumer.FotoSelfieID == nil {
+				return nil, fmt.Errorf("foto_ktp and foto_selfie are required to submit KYC")
+			}
+
+			consumer.KYCVerification = domain.KYCVerification{
+				StatusKYC:       domain.StatusKYCSubmitted,
+				KYCDiajukanPada: &now,
+			}
+			updates["kyc_diajukan_pada"] = now
+			updates["kyc_reviewer"] = nil
+			updates["kyc_diputuskan_pada"] = nil
+			updates["kyc_catatan"] = ""
+			return updates, nil
+		},
+	)
+	if err != nil {
+		if len(storedDocuments) > 0 {
+			uc.documents.Discard(storedDocuments)
+		}
+		return nil, err
+	}
+	return consumer, nil
+}
+
+// StartKYCReview mengambil pengajuan KYC dari antrean untuk ditinjau oleh reviewer.
+func (uc *kycUsecase) StartKYCReview(consumerID, reviewerID uint) (*domain.Consumer, error) {
+	return uc.changeKYCStatus(
+		consumerID, reviewerID, domain.StatusKYCInReview, "",
+		func(_ *gorm.DB, consumer *domain.Consumer, now time.Time) (map[string]interface{}, error) {
+			consumer.KYCReviewer = &reviewerID
+			return map[string]interface{}{"kyc_reviewer": reviewerID}, nil
+		},
+	)
+}
+
+// ApproveKYC menyetujui KYC yang sedang ditinjau sehingga konsumen dapat diberi limit dan menarik pinjaman.
+func (uc *kycUsecase) ApproveKYC(consumerID, reviewerID uint, input ApproveKYCInput) (*domain.Consumer, error) {
+	return uc.decideKYC(consumerID, reviewerID, domain.StatusKYCApproved, input.Catatan)
+}
+
+// RejectKYC menolak KYC yang sedang ditinjau. Alasan penolakan disimpan agar konsumen dapat memperbaiki
+// dokumennya sebelum mengajukan ulang.
+func (uc *kycUsecase) RejectKYC(consumerID, reviewerID uint, input RejectKYCInput) (*domain.Consumer, error) {
+	return uc.decideKYC(consumerID, reviewerID, domain.StatusKYCRejected, input.Alasan)
+}
+
+// GetKYCQueue mengambil konsumen dengan status KYC tertentu. Tanpa filter status, yang dikembalikan adalah
+// antrean reviewer: pengajuan yang belum ditinjau dan yang sedang ditinjau.
+func (uc *kycUsecase) GetKYCQueue(status string) ([]*domain.Consumer, error) {
+	statuses := domain.StatusKYCAntrean
+	if status != "" {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if !isValidKYCStatus(status) {
+			return nil, fmt.Errorf(
+				"invalid KYC status: %s. allowed statuses are DRAFT, SUBMITTED, IN_REVIEW, APPROVED, REJECTED",
+				status,
+			)
+		}
+		statuses = []string{status}
+	}
+	return uc.consumerRepo.FindByStatusKYC(statuses)
+}
+
+// GetKYCHistory mengambil riwayat perubahan status KYC seorang konsumen.
+func (uc *kycUsecase) GetKYCHistory(consumerID uint) ([]*domain.KYCHistory, error) {
+	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	return uc.historyRepo.FindByConsumerID(consumerID)
+}
+
+// decideKYC mencatat keputusan reviewer atas KYC yang sedang ditinjau.
+func (uc *kycUsecase) decideKYC(consumerID, reviewerID uint, toStatus, catatan string) (*domain.Consumer, error) {
+	return uc.changeKYCStatus(
+		consumerID, reviewerID, toStatus, catatan,
+		func(_ *gorm.DB, consumer *domain.Consumer, now time.Time) (map[string]interface{}, error) {
+			consumer.KYCReviewer = &reviewerID
+			consumer.KYCDiputuskanPada = &now
+			consumer.KYCCatatan = catatan
+			return map[string]interface{}{
+				"kyc_reviewer":        reviewerID,
+				"kyc_diputuskan_pada": now,
+				"kyc_catatan":         catatan,
+			}, nil
+		},
+	)
+}
+
+// changeKYCStatus memindahkan status KYC konsumen yang barisnya dikunci, menerapkan perubahan tambahan dari
+// apply, lalu mencatat riwayatnya. Perpindahan status yang tidak sesuai alur KYC ditolak.
+func (uc *kycUsecase) changeKYCStatus(
+	consumerID, changedBy uint,
+	toStatus, alasan string,
+	apply func(tx *gorm.DB, consumer *domain.Consumer, now time.Time) (map[string]interface{}, error),
+) (*domain.Consumer, error) {
+	var changed *domain.Consumer
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			consumerRepoTx := uc.consumerRepo.WithTx(tx)
+
+			consumer, err := consumerRepoTx.FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+			fromStatus := consumer.StatusKYC
+			if !consumer.CanTransitionKYCTo(toStatus) {
+				return fmt.Errorf("cannot change KYC status from %s to %s", fromStatus, toStatus)
+			}
+
+			updates, err := apply(tx, consumer, time.Now())
+			if err != nil {
+				return err
+			}
+			updates["status_kyc"] = toStatus
+			if err := consumerRepoTx.Update(consumerID, updates); err != nil {
+				return err
+			}
+			err = uc.historyRepo.WithTx(tx).Save(
+				&domain.KYCHistory{
+					ConsumerID: consumerID,
+					FromStatus: fromStatus,
+					ToStatus:   toStatus,
+					Alasan:     alasan,
+					ChangedBy:  &changedBy,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			consumer.StatusKYC = toStatus
+			changed = consumer
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+func isValidKYCStatus(status string) bool {
+	switch status {
+	case domain.StatusKYCDraft, domain.StatusKYCSubmitted, domain.StatusKYCInReview,
+		domain.StatusKYCApproved, domain.StatusKYCRejected:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"path/filepath"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// kycApproved dipakai oleh fixture konsumen yang boleh diberi limit dan menarik pinjaman.
+var kycApproved = domain.KYCVerification{StatusKYC: domain.StatusKYCApproved}
+
+type kycTestDeps struct {
+	mockSQL          sqlmock.Sqlmock
+	mockConsumerRepo *MockConsumerRepository
+	mockHistoryRepo  *MockKYCHistoryRepository
+	mockDocumentRepo *MockConsumerDocumentRepository
+	storageDir       string
+}
+
+func setupKYCUsecase(t *testing.T) (KYCUsecase, kycTestDeps) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	deps := kycTestDeps{
+		mockSQL:          mockSQL,
+		mockConsumerRepo: new(MockConsumerRepository),
+		mockHistoryRepo:  new(MockKYCHistoryRepository),
+		mockDocumentRepo: new(MockConsumerDocumentRepository),
+		storageDir:       t.TempDir(),
+	}
+	documentStorage, err := storage.NewLocalStorage(deps.storageDir)
+	assert.NoError(t, err)
+	documents := NewDocumentStore(deps.mockDocumentRepo, documentStorage, DefaultDocumentPolicy)
+	return NewKYCUsecase(gormDB, deps.mockConsumerRepo, deps.mockHistoryRepo, documents), deps
+}
+
+func TestSubmitKYC_ResubmitsAfterRejection(t *testing.T) {
+	// Arrange
+	usecase, deps := setupKYCUsecase(t)
+	reviewerID := uint(7)
+	fotoKtpLama, fotoSelfieLama := uint(10), uint(11)
+	consumer := &domain.Consumer{
+		ID:           1,
+		FotoKtpID:    &fotoKtpLama,
+		FotoSelfieID: &fotoSelfieLama,
+		KYCVerification: domain.KYCVerification{
+			StatusKYC:   domain.StatusKYCRejected,
+			KYCReviewer: &reviewerID,
+			KYCCatatan:  "Foto KTP buram",
+		},
+	}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockDocumentRepo.On(
+		"Save", mock.MatchedBy(
+			func(document *domain.ConsumerDocument) bool {
+				return document.ConsumerID == 1 &&
+					document.Jenis == domain.JenisDokumenKTP &&
+					*document.DiunggahOleh == 3
+			},
+		),
+	).Run(
+		func(args mock.Arguments) {
+			args.Get(0).(*domain.ConsumerDocument).ID = 12
+		},
+	).Return(nil).Once()
+	deps.mockConsumerRepo.On(
+		"Update", uint(1), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				_, gantiSelfie := updates["foto_selfie_id"]
+				return updates["status_kyc"] == domain.StatusKYCSubmitted &&
+					updates["foto_ktp_id"] == uint(12) &&
+					!gantiSelfie &&
+					updates["kyc_catatan"] == ""
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.KYCHistory) bool {
+				return history.FromStatus == domain.StatusKYCRejected &&
+					history.ToStatus == domain.StatusKYCSubmitted &&
+					*history.ChangedBy == 3
+			},
+		),
+	).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	submitted, err := usecase.SubmitKYC(1, 3, SubmitKYCInput{FotoKtp: jpegUpload("ktp-baru.jpg")})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusKYCSubmitted, submitted.StatusKYC)
+	assert.Equal(t, uint(12), *submitted.FotoKtpID)
+	assert.Equal(t, uint(11), *submitted.FotoSelfieID)
+	assert.NotNil(t, submitted.KYCDiajukanPada)
+	assert.Nil(t, submitted.KYCReviewer)
+	assert.Empty(t, submitted.KYCCatatan)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockConsumerRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+	deps.mockDocumentRepo.AssertExpectations(t)
+}
+
+func TestSubmitKYC_RequiresBothDocuments(t *testing.T) {
+	// Arrange
+	usecase, deps := setupKYCUsecase(t)
+	consumer := &domain.Consumer{ID: 1, KYCVerification: domain.KYCVerification{StatusKYC: domain.StatusKYCDraft}}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockDocumentRepo.On("Save", mock.AnythingOfType("*domain.ConsumerDocument")).Return(nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	submitted, err := usecase.SubmitKYC(1, 3, SubmitKYCInput{FotoKtp: jpegUpload("ktp.jpg")})
+
+	// Assert
+	assert.Nil(t, submitted)
+	assert.EqualError(t, err, "foto_ktp and foto_selfie are required to submit KYC")
+	// Foto KTP yang sempat tersimpan dibuang lagi karena transaksinya dibatalkan.
+	files, err := filepath.Glob(filepath.Join(deps.storageDir, "consumers", "1", "*"))
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	deps.mockConsumerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	deps.mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestApproveKYC_RequiresReviewInProgress(t *testing.T) {
+	// Arrange
+	usecase, deps := setupKYCUsecase(t)
+	consumer := &domain.Consumer{ID: 1, KYCVerification: domain.KYCVerification{StatusKYC: domain.StatusKYCSubmitted}}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	approved, err := usecase.ApproveKYC(1, 7, ApproveKYCInput{})
+
+	// Assert
+	assert.Nil(t, approved)
+	assert.EqualError(t, err, "cannot change KYC status from SUBMITTED to APPROVED")
+	deps.mockConsumerRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestRejectKYC_RecordsReason(t *testing.T) {
+	// Arrange
+	usecase, deps := setupKYCUsecase(t)
+	consumer := &domain.Consumer{ID: 1, KYCVerification: domain.KYCVerification{StatusKYC: domain.StatusKYCInReview}}
+	input := RejectKYCInput{Alasan: "Wajah pada selfie tidak sesuai KTP"}
+
+	deps.mockSQL.ExpectBegin()
+	deps.mockConsumerRepo.On("FindByIDForUpdate", uint(1)).Return(consumer, nil).Once()
+	deps.mockConsumerRepo.On(
+		"Update", uint(1), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["status_kyc"] == domain.StatusKYCRejected &&
+					updates["kyc_reviewer"] == uint(7) &&
+					updates["kyc_catatan"] == input.Alasan
+			},
+		),
+	).Return(nil).Once()
+	deps.mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(history *domain.KYCHistory) bool {
+				return history.FromStatus == domain.StatusKYCInReview &&
+					history.ToStatus == domain.StatusKYCRejected &&
+					history.Alasan == input.Alasan
+			},
+		),
+	).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	rejected, err := usecase.RejectKYC(1, 7, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusKYCRejected, rejected.StatusKYC)
+	assert.Equal(t, uint(7), *rejected.KYCReviewer)
+	assert.NotNil(t, rejected.KYCDiputuskanPada)
+	assert.True(t, rejected.AcceptsKYCDocuments())
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+	deps.mockConsumerRepo.AssertExpectations(t)
+	deps.mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestGetKYCQueue_DefaultsToPendingStatuses(t *testing.T) {
+	// Arrange
+	usecase, deps := setupKYCUsecase(t)
+	queue := []*domain.Consumer{{ID: 1}, {ID: 2}}
+	deps.mockConsumerRepo.On(
+		"FindByStatusKYC", []string{domain.StatusKYCSubmitted, domain.StatusKYCInReview},
+	).Return(queue, nil).Once()
+
+	// Act
+	consumers, err := usecase.GetKYCQueue("")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, consumers, 2)
+	deps.mockConsumerRepo.AssertExpectations(t)
+}
+
+func TestGetKYCQueue_InvalidStatus(t *testing.T) {
+	// Arrange
+	usecase, deps := setupKYCUsecase(t)
+
+	// Act
+	consumers, err := usecase.GetKYCQueue("verified")
+
+	// Assert
+	assert.Nil(t, consumers)
+	assert.EqualError(
+		t, err,
+		"invalid KYC status: VERIFIED. allowed statuses are DRAFT, SUBMITTED, IN_REVIEW, APPROVED, REJECTED",
+	)
+	deps.mockConsumerRepo.AssertNotCalled(t, "FindByStatusKYC", mock.Anything)
+}
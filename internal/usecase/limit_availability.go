@@ -0,0 +1,70 @@
+package usecase
+
+import "github.com/adty404/kredit-plus/internal/domain"
+
+// computeLimitAvailability menghitung pemakaian plafon konsumen secara keseluruhan dan per tenor.
+// Pemakaian dihitung dari sisa pokok kontrak aktif, sehingga pokok yang sudah dibayar kembali
+// otomatis membebaskan plafon, ditambah penahanan limit yang masih aktif.
+func computeLimitAvailability(
+	consumer *domain.Consumer,
+	limits []*domain.ConsumerCreditLimit,
+	activeTransactions []*domain.Transaction,
+	activeHolds []*domain.LimitHold,
+) *LimitAvailabilityOutput {
+	output := &LimitAvailabilityOutput{
+		ConsumerID: consumer.ID,
+		Overall:    LimitUsage{CreditLimit: consumer.OverallCreditLimit},
+		Tenors:     make([]TenorLimitUsage, 0, len(limits)),
+	}
+
+	for _, trx := range activeTransactions {
+		output.Overall.Used = output.Overall.Used.Add(trx.PokokPembiayaanAwal)
+		output.Overall.Outstanding = output.Overall.Outstanding.Add(trx.SisaPokok())
+	}
+	for _, hold := range activeHolds {
+		output.Overall.Held = output.Overall.Held.Add(hold.Jumlah)
+	}
+	output.Overall.finalize()
+
+	for _, limit := range limits {
+		tenor := TenorLimitUsage{
+			ConsumerCreditLimitID: limit.ID,
+			TenorMonths:           limit.TenorMonths,
+			LimitUsage:            LimitUsage{CreditLimit: limit.CreditLimit},
+		}
+		for _, trx := range activeTransactions {
+			if trx.ConsumerCreditLimitID != limit.ID {
+				continue
+			}
+			tenor.Used = tenor.Used.Add(trx.PokokPembiayaanAwal)
+			tenor.Outstanding = tenor.Outstanding.Add(trx.SisaPokok())
+		}
+		for _, hold := range activeHolds {
+			if hold.ConsumerCreditLimitID == limit.ID {
+				tenor.Held = tenor.Held.Add(hold.Jumlah)
+			}
+		}
+		tenor.finalize()
+		output.Tenors = append(output.Tenors, tenor)
+	}
+
+	return output
+}
+
+// finalize menghitung sisa limit yang masih bisa dipakai.
+func (u *LimitUsage) finalize() {
+	u.Remaining = u.CreditLimit.Sub(u.Outstanding).Sub(u.Held)
+	if u.Remaining.IsNegative() {
+		u.Remaining = domain.Money{}
+	}
+}
+
+// Tenor mengembalikan ketersediaan limit untuk tenor tertentu, atau nil jika konsumen tidak memilikinya.
+func (o *LimitAvailabilityOutput) Tenor(tenorMonths int) *TenorLimitUsage {
+	for i := range o.Tenors {
+		if o.Tenors[i].TenorMonths == tenorMonths {
+			return &o.Tenors[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// DefaultLimitHoldTTL adalah lama penahanan limit sejak dibuat sebelum otomatis kedaluwarsa.
+const DefaultLimitHoldTTL = 30 * time.Minute
+
+// ParseLimitHoldTTL mengurai lama penahanan limit dalam menit dari konfigurasi.
+// String kosong menghasilkan DefaultLimitHoldTTL.
+func ParseLimitHoldTTL(raw string) (time.Duration, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultLimitHoldTTL, nil
+	}
+	minutes, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || minutes <= 0 {
+		return 0, fmt.Errorf("invalid limit hold duration %q, expected a positive number of minutes", raw)
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
+
+// CreateLimitHold menahan plafon dan limit tenor konsumen untuk sebuah pengajuan selama holdTTL. Pengajuan
+// divalidasi dengan jalur yang sama dengan CreateTransaction, dan limit yang ditahan tidak dapat dipakai oleh
+// transaksi atau penahanan lain sampai dikonfirmasi, dilepas, atau kedaluwarsa.
+func (uc *transactionUsecase) CreateLimitHold(consumerID uint, input CreateTransactionInput) (
+	*domain.LimitHold,
+	error,
+) {
+	var newHold *domain.LimitHold
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			// Kunci baris konsumen agar penahanan dan transaksi yang dibuat bersamaan tidak melampaui limit.
+			consumer, err := uc.consumerRepo.WithTx(tx).FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+
+			draft, err := uc.prepareLockedTransaction(tx, consumer, input, 0)
+			if err != nil {
+				return err
+			}
+
+			hold := &domain.LimitHold{
+				ConsumerID:            consumerID,
+				ConsumerCreditLimitID: draft.transaction.ConsumerCreditLimitID,
+				TenorBulan:            input.TenorMonths,
+				Jumlah:                draft.transaction.PokokPembiayaanAwal,
+				Otr:                   input.Otr,
+				UangMuka:              input.UangMuka,
+				AdminFee:              draft.transaction.AdminFee,
+				NamaAsset:             input.NamaAsset,
+				JenisAsset:            input.JenisAsset,
+				SumberTransaksi:       input.SumberTransaksi,
+				Status:                domain.StatusHoldAktif,
+				KedaluwarsaPada:       time.Now().Add(uc.holdTTL),
+			}
+			if err := uc.holdRepo.WithTx(tx).Save(hold); err != nil {
+				return err
+			}
+
+			newHold = hold
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHold, nil
+}
+
+// ConfirmLimitHold mengubah penahanan limit yang masih aktif menjadi kontrak. Limit yang ditahan dipakai oleh
+// kontrak tersebut, sehingga konfirmasi tidak gagal karena penahanannya sendiri. Seperti kontrak lain, kontrak
+// hasil konfirmasi berstatus PENDING sampai diaktifkan admin.
+func (uc *transactionUsecase) ConfirmLimitHold(consumerID, holdID uint) (*domain.Transaction, error) {
+	var newTransaction *domain.Transaction
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			holdRepoTx := uc.holdRepo.WithTx(tx)
+
+			// Urutan penguncian sama dengan CreateTransaction: konsumen terlebih dahulu, lalu penahanannya.
+			consumer, err := uc.consumerRepo.WithTx(tx).FindByIDForUpdate(consumerID)
+			if err != nil {
+				return fmt.Errorf("consumer with id %d not found", consumerID)
+			}
+
+			hold, err := holdRepoTx.FindByIDForUpdate(holdID)
+			if err != nil || hold.ConsumerID != consumerID {
+				return fmt.Errorf("limit hold with id %d not found for this consumer", holdID)
+			}
+			if hold.Status != domain.StatusHoldAktif {
+				return fmt.Errorf("limit hold is already %s", hold.Status)
+			}
+			if !hold.IsActive(time.Now()) {
+				return fmt.Errorf("limit hold expired at %s", hold.KedaluwarsaPada.Format(time.RFC3339))
+			}
+
+			draft, err := uc.prepareLockedTransaction(
+				tx, consumer, CreateTransactionInput{
+					TenorMonths:     hold.TenorBulan,
+					Otr:             hold.Otr,
+					AdminFee:        hold.AdminFee,
+					UangMuka:        hold.UangMuka,
+					NamaAsset:       hold.NamaAsset,
+					JenisAsset:      hold.JenisAsset,
+					SumberTransaksi: hold.SumberTransaksi,
+				}, hold.ID,
+			)
+			if err != nil {
+				return err
+			}
+
+			transaction, err := uc.saveContract(tx, draft)
+			if err != nil {
+				return err
+			}
+
+			hold.Status = domain.StatusHoldDikonfirmasi
+			hold.TransactionID = &transaction.ID
+			if err := holdRepoTx.Update(hold); err != nil {
+				return err
+			}
+
+			newTransaction = transaction
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransaction, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockLimitHoldRepository adalah implementasi mock dari domain.LimitHoldRepository.
+type MockLimitHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockLimitHoldRepository) WithTx(tx *gorm.DB) domain.LimitHoldRepository {
+	return m
+}
+
+func (m *MockLimitHoldRepository) Save(hold *domain.LimitHold) error {
+	args := m.Called(hold)
+	return args.Error(0)
+}
+
+func (m *MockLimitHoldRepository) Update(hold *domain.LimitHold) error {
+	args := m.Called(hold)
+	return args.Error(0)
+}
+
+func (m *MockLimitHoldRepository) FindByIDForUpdate(id uint) (*domain.LimitHold, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LimitHold), args.Error(1)
+}
+
+func (m *MockLimitHoldRepository) FindByConsumerID(consumerID uint) ([]*domain.LimitHold, error) {
+	args := m.Called(consumerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LimitHold), args.Error(1)
+}
+
+func (m *MockLimitHoldRepository) FindActiveByConsumerID(consumerID uint, now time.Time) ([]*domain.LimitHold, error) {
+	args := m.Called(consumerID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LimitHold), args.Error(1)
+}
+
+func (m *MockLimitHoldRepository) ExpireBefore(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type limitHoldTestDeps struct {
+	mockSQL             sqlmock.Sqlmock
+	mockTransactionRepo *MockTransactionRepository
+	mockInstallmentRepo *MockInstallmentRepository
+	mockHoldRepo        *MockLimitHoldRepository
+	mockHistoryRepo     *MockTransactionStatusHistoryRepository
+}
+
+// setupLimitHoldTransactionUsecase menyiapkan TransactionUsecase untuk konsumen 1 berplafon 10.000.000 dengan
+// limit tenor 6 bulan sebesar 5.000.000 tanpa kontrak berjalan. Baris konsumen dikunci di dalam transaksi database.
+func setupLimitHoldTransactionUsecase(t *testing.T) (TransactionUsecase, limitHoldTestDeps) {
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	deps := limitHoldTestDeps{
+		mockSQL:             mockSQL,
+		mockTransactionRepo: mockTransactionRepo,
+		mockInstallmentRepo: mockInstallmentRepo,
+		mockHoldRepo:        new(MockLimitHoldRepository),
+		mockHistoryRepo:     new(MockTransactionStatusHistoryRepository),
+	}
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		deps.mockHistoryRepo,
+		new(MockIdempotencyKeyRepository),
+		deps.mockHoldRepo,
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumer := &domain.Consumer{ID: 1, KYCVerification: kycApproved, OverallCreditLimit: domain.NewMoney(10000000)}
+	creditLimit := &domain.ConsumerCreditLimit{
+		ID:          10,
+		ConsumerID:  1,
+		TenorMonths: 6,
+		CreditLimit: domain.NewMoney(5000000),
+	}
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumer.ID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumer.ID, 6).Return(creditLimit, nil).Maybe()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumer.ID).Return([]*domain.Transaction{}, nil).Maybe()
+
+	return usecase, deps
+}
+
+func newHoldInput() CreateTransactionInput {
+	return CreateTransactionInput{
+		TenorMonths:     6,
+		Otr:             domain.NewMoney(3000000),
+		AdminFee:        domain.NewMoney(100000),
+		UangMuka:        domain.NewMoney(500000),
+		NamaAsset:       "Kulkas",
+		SumberTransaksi: "MERCHANT",
+	}
+}
+
+func TestCreateLimitHold_Success(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitHoldTransactionUsecase(t)
+	deps.mockHoldRepo.On("FindActiveByConsumerID", uint(1), mock.AnythingOfType("time.Time")).
+		Return([]*domain.LimitHold{}, nil).Once()
+	deps.mockHoldRepo.On("Save", mock.AnythingOfType("*domain.LimitHold")).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	before := time.Now()
+	hold, err := usecase.CreateLimitHold(1, newHoldInput())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusHoldAktif, hold.Status)
+	assert.Equal(t, uint(10), hold.ConsumerCreditLimitID)
+	assert.Equal(t, domain.NewMoney(2600000), hold.Jumlah)
+	assert.WithinDuration(t, before.Add(DefaultLimitHoldTTL), hold.KedaluwarsaPada, time.Second)
+	deps.mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestCreateLimitHold_ActiveHoldsReduceAvailableLimit(t *testing.T) {
+	// Arrange: penahanan lain sebesar 3.000.000 menyisakan limit tenor 2.000.000
+	usecase, deps := setupLimitHoldTransactionUsecase(t)
+	otherHold := &domain.LimitHold{
+		ID:                    7,
+		ConsumerCreditLimitID: 10,
+		Jumlah:                domain.NewMoney(3000000),
+		Status:                domain.StatusHoldAktif,
+	}
+	deps.mockHoldRepo.On("FindActiveByConsumerID", uint(1), mock.AnythingOfType("time.Time")).
+		Return([]*domain.LimitHold{otherHold}, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	hold, err := usecase.CreateLimitHold(1, newHoldInput())
+
+	// Assert
+	assert.Nil(t, hold)
+	assert.EqualError(t, err, "loan amount (2600000.00) exceeds available tenor credit limit (2000000.00)")
+	assert.Equal(t, RuleTenorLimitInsufficient, AsRuleViolation(err).Code)
+	deps.mockHoldRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_CountsActiveHolds(t *testing.T) {
+	// Arrange
+	usecase, deps := setupLimitHoldTransactionUsecase(t)
+	deps.mockHoldRepo.On("FindActiveByConsumerID", uint(1), mock.AnythingOfType("time.Time")).
+		Return([]*domain.LimitHold{{ID: 7, ConsumerCreditLimitID: 10, Jumlah: domain.NewMoney(3000000)}}, nil).Once()
+	deps.mockSQL.ExpectRollback()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(1, newHoldInput())
+
+	// Assert
+	assert.Nil(t, transaction)
+	assert.Equal(t, RuleTenorLimitInsufficient, AsRuleViolation(err).Code)
+	deps.mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestConfirmLimitHold_CreatesContractFromHold(t *testing.T) {
+	// Arrange: penahanan yang dikonfirmasi tidak boleh mengurangi limitnya sendiri
+	usecase, deps := setupLimitHoldTransactionUsecase(t)
+	input := newHoldInput()
+	hold := &domain.LimitHold{
+		ID:                    7,
+		ConsumerID:            1,
+		ConsumerCreditLimitID: 10,
+		TenorBulan:            input.TenorMonths,
+		Jumlah:                domain.NewMoney(2600000),
+		Otr:                   input.Otr,
+		UangMuka:              input.UangMuka,
+		AdminFee:              input.AdminFee,
+		NamaAsset:             input.NamaAsset,
+		SumberTransaksi:       input.SumberTransaksi,
+		Status:                domain.StatusHoldAktif,
+		KedaluwarsaPada:       time.Now().Add(10 * time.Minute),
+	}
+	otherHold := &domain.LimitHold{ID: 8, ConsumerCreditLimitID: 10, Jumlah: domain.NewMoney(2000000)}
+	deps.mockHoldRepo.On("FindByIDForUpdate", uint(7)).Return(hold, nil).Once()
+	deps.mockHoldRepo.On("FindActiveByConsumerID", uint(1), mock.AnythingOfType("time.Time")).
+		Return([]*domain.LimitHold{hold, otherHold}, nil).Once()
+	deps.mockTransactionRepo.On("Save", mock.AnythingOfType("*domain.Transaction")).
+		Run(func(args mock.Arguments) { args.Get(0).(*domain.Transaction).ID = 99 }).
+		Return(nil).Once()
+	deps.mockInstallmentRepo.On("SaveAll", mock.AnythingOfType("[]*domain.Installment")).Return(nil).Once()
+	deps.mockHoldRepo.On("Update", hold).Return(nil).Once()
+	deps.mockSQL.ExpectCommit()
+
+	// Act
+	transaction, err := usecase.ConfirmLimitHold(1, 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusKontrakPending, transaction.StatusKontrak)
+	assert.Equal(t, domain.NewMoney(2600000), transaction.PokokPembiayaanAwal)
+	assert.Equal(t, "Kulkas", transaction.NamaAsset)
+	assert.Equal(t, domain.StatusHoldDikonfirmasi, hold.Status)
+	if assert.NotNil(t, hold.TransactionID) {
+		assert.Equal(t, uint(99), *hold.TransactionID)
+	}
+	// Konfirmasi tidak mengaktifkan kontrak; aktivasi hanya dilakukan admin.
+	deps.mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+	deps.mockTransactionRepo.AssertNotCalled(t, "Update", mock.Anything)
+	assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+}
+
+func TestConfirmLimitHold_Rejected(t *testing.T) {
+	testCases := []struct {
+		name    string
+		hold    *domain.LimitHold
+		errText string
+	}{
+		{
+			name:    "milik konsumen lain",
+			hold:    &domain.LimitHold{ID: 7, ConsumerID: 2, Status: domain.StatusHoldAktif},
+			errText: "limit hold with id 7 not found for this consumer",
+		},
+		{
+			name:    "sudah dilepas",
+			hold:    &domain.LimitHold{ID: 7, ConsumerID: 1, Status: domain.StatusHoldDilepas},
+			errText: "limit hold is already DILEPAS",
+		},
+		{
+			name: "sudah lewat waktu",
+			hold: &domain.LimitHold{
+				ID:              7,
+				ConsumerID:      1,
+				Status:          domain.StatusHoldAktif,
+				KedaluwarsaPada: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+			},
+			errText: "limit hold expired at 2026-10-01T10:00:00Z",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				usecase, deps := setupLimitHoldTransactionUsecase(t)
+				deps.mockHoldRepo.On("FindByIDForUpdate", uint(7)).Return(tc.hold, nil).Once()
+				deps.mockSQL.ExpectRollback()
+
+				// Act
+				transaction, err := usecase.ConfirmLimitHold(1, 7)
+
+				// Assert
+				assert.Nil(t, transaction)
+				assert.EqualError(t, err, tc.errText)
+				deps.mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+				deps.mockHoldRepo.AssertNotCalled(t, "Update", mock.Anything)
+				assert.NoError(t, deps.mockSQL.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestParseLimitHoldTTL(t *testing.T) {
+	ttl, err := ParseLimitHoldTTL("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultLimitHoldTTL, ttl)
+
+	ttl, err = ParseLimitHoldTTL(" 15 ")
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, ttl)
+
+	_, err = ParseLimitHoldTTL("0")
+	assert.Error(t, err)
+	_, err = ParseLimitHoldTTL("15m")
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// LimitHoldUsecase mengelola siklus hidup penahanan limit yang tidak membentuk kontrak: melihat, melepas,
+// dan mengakhiri penahanan yang kedaluwarsa. Pembuatan dan konfirmasi penahanan ada di TransactionUsecase.
+type LimitHoldUsecase interface {
+	GetLimitHolds(consumerID uint) ([]*domain.LimitHold, error)
+	ReleaseLimitHold(consumerID, holdID uint) (*domain.LimitHold, error)
+	ExpireLimitHolds(now time.Time) (int64, error)
+}
+
+type limitHoldUsecase struct {
+	db           *gorm.DB
+	holdRepo     domain.LimitHoldRepository
+	consumerRepo domain.ConsumerRepository
+}
+
+func NewLimitHoldUsecase(
+	db *gorm.DB,
+	holdRepo domain.LimitHoldRepository,
+	consumerRepo domain.ConsumerRepository,
+) LimitHoldUsecase {
+	return &limitHoldUsecase{
+		db:           db,
+		holdRepo:     holdRepo,
+		consumerRepo: consumerRepo,
+	}
+}
+
+// GetLimitHolds mengambil seluruh penahanan limit konsumen, terbaru lebih dulu.
+func (uc *limitHoldUsecase) GetLimitHolds(consumerID uint) ([]*domain.LimitHold, error) {
+	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+	return uc.holdRepo.FindByConsumerID(consumerID)
+}
+
+// ReleaseLimitHold melepas penahanan yang masih aktif sehingga limitnya langsung dapat dipakai kembali.
+func (uc *limitHoldUsecase) ReleaseLimitHold(consumerID, holdID uint) (*domain.LimitHold, error) {
+	var released *domain.LimitHold
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			holdRepoTx := uc.holdRepo.WithTx(tx)
+
+			hold, err := holdRepoTx.FindByIDForUpdate(holdID)
+			if err != nil || hold.ConsumerID != consumerID {
+				return fmt.Errorf("limit hold with id %d not found for this consumer", holdID)
+			}
+			if hold.Status != domain.StatusHoldAktif {
+				return fmt.Errorf("limit hold is already %s", hold.Status)
+			}
+
+			hold.Status = domain.StatusHoldDilepas
+			if err := holdRepoTx.Update(hold); err != nil {
+				return err
+			}
+
+			released = hold
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return released, nil
+}
+
+// ExpireLimitHolds menandai penahanan aktif yang sudah melewati batas waktunya per now sebagai KEDALUWARSA
+// dan mengembalikan jumlah penahanan yang diakhiri.
+func (uc *limitHoldUsecase) ExpireLimitHolds(now time.Time) (int64, error) {
+	return uc.holdRepo.ExpireBefore(now)
+}
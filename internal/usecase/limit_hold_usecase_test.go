@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupLimitHoldUsecase(t *testing.T) (LimitHoldUsecase, sqlmock.Sqlmock, *MockLimitHoldRepository) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	mockHoldRepo := new(MockLimitHoldRepository)
+	return NewLimitHoldUsecase(gormDB, mockHoldRepo, new(MockConsumerRepository)), mockSQL, mockHoldRepo
+}
+
+func TestReleaseLimitHold_Success(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockHoldRepo := setupLimitHoldUsecase(t)
+	hold := &domain.LimitHold{ID: 7, ConsumerID: 1, Status: domain.StatusHoldAktif}
+
+	mockSQL.ExpectBegin()
+	mockHoldRepo.On("FindByIDForUpdate", uint(7)).Return(hold, nil).Once()
+	mockHoldRepo.On("Update", hold).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	released, err := usecase.ReleaseLimitHold(1, 7)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusHoldDilepas, released.Status)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestReleaseLimitHold_AlreadyConfirmed(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockHoldRepo := setupLimitHoldUsecase(t)
+	hold := &domain.LimitHold{ID: 7, ConsumerID: 1, Status: domain.StatusHoldDikonfirmasi}
+
+	mockSQL.ExpectBegin()
+	mockHoldRepo.On("FindByIDForUpdate", uint(7)).Return(hold, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	released, err := usecase.ReleaseLimitHold(1, 7)
+
+	// Assert
+	assert.Nil(t, released)
+	assert.EqualError(t, err, "limit hold is already DIKONFIRMASI")
+	mockHoldRepo.AssertNotCalled(t, "Update", mock.Anything)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestExpireLimitHolds(t *testing.T) {
+	// Arrange
+	usecase, _, mockHoldRepo := setupLimitHoldUsecase(t)
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	mockHoldRepo.On("ExpireBefore", now).Return(int64(3), nil).Once()
+
+	// Act
+	expired, err := usecase.ExpireLimitHolds(now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), expired)
+	mockHoldRepo.AssertExpectations(t)
+}
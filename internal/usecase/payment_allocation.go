@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// Komponen tagihan yang dapat menerima alokasi pembayaran.
+const (
+	KomponenDenda = "denda"
+	KomponenBunga = "bunga"
+	KomponenPokok = "pokok"
+)
+
+// AllocationOrder menentukan urutan komponen tagihan yang dilunasi lebih dulu pada setiap angsuran.
+type AllocationOrder []string
+
+// DefaultAllocationOrder adalah urutan alokasi standar: denda, lalu bunga, lalu pokok.
+var DefaultAllocationOrder = AllocationOrder{KomponenDenda, KomponenBunga, KomponenPokok}
+
+// ParseAllocationOrder mengurai urutan alokasi dari string seperti "denda,bunga,pokok".
+// String kosong menghasilkan DefaultAllocationOrder.
+func ParseAllocationOrder(raw string) (AllocationOrder, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultAllocationOrder, nil
+	}
+
+	seen := make(map[string]bool)
+	order := make(AllocationOrder, 0, len(DefaultAllocationOrder))
+	for _, part := range strings.Split(raw, ",") {
+		komponen := strings.ToLower(strings.TrimSpace(part))
+		switch komponen {
+		case KomponenDenda, KomponenBunga, KomponenPokok:
+		default:
+			return nil, fmt.Errorf("unknown allocation component: %q", part)
+		}
+		if seen[komponen] {
+			return nil, fmt.Errorf("duplicate allocation component: %q", komponen)
+		}
+		seen[komponen] = true
+		order = append(order, komponen)
+	}
+
+	if len(order) != len(DefaultAllocationOrder) {
+		return nil, fmt.Errorf("allocation order must contain denda, bunga and pokok exactly once")
+	}
+	return order, nil
+}
+
+// allocatePayment menerapkan sejumlah dana ke angsuran yang belum lunas, dimulai dari periode tertua.
+// Di setiap angsuran, dana dialokasikan mengikuti urutan komponen pada order. Angsuran yang diberikan
+// akan dimutasi; fungsi mengembalikan rincian alokasi dan sisa dana yang tidak terpakai (kelebihan bayar).
+func allocatePayment(
+	installments []*domain.Installment,
+	amount domain.Money,
+	order AllocationOrder,
+	paidAt time.Time,
+) ([]domain.PaymentAllocation, domain.Money) {
+	var allocations []domain.PaymentAllocation
+	remaining := amount
+
+	for _, installment := range installments {
+		if !remaining.IsPositive() {
+			break
+		}
+		if installment.IsClosed() {
+			continue
+		}
+
+		allocation := domain.PaymentAllocation{
+			InstallmentID: installment.ID,
+			AngsuranKe:    installment.AngsuranKe,
+		}
+		for _, komponen := range order {
+			switch komponen {
+			case KomponenDenda:
+				allocation.Denda = takeAmount(&remaining, installment.SisaDenda())
+				installment.DendaDibayar = installment.DendaDibayar.Add(allocation.Denda)
+			case KomponenBunga:
+				allocation.Bunga = takeAmount(&remaining, installment.SisaBunga())
+				installment.BungaDibayar = installment.BungaDibayar.Add(allocation.Bunga)
+			case KomponenPokok:
+				allocation.Pokok = takeAmount(&remaining, installment.SisaPokok())
+				installment.PokokDibayar = installment.PokokDibayar.Add(allocation.Pokok)
+			}
+		}
+
+		applied := allocation.Denda.Add(allocation.Bunga).Add(allocation.Pokok)
+		if applied.IsZero() {
+			continue
+		}
+		installment.JumlahDibayar = installment.JumlahDibayar.Add(applied)
+
+		if !installment.SisaTagihan().IsPositive() {
+			installment.Status = domain.InstallmentStatusLunas
+			lunasPada := paidAt
+			installment.TanggalLunas = &lunasPada
+		} else {
+			installment.Status = domain.InstallmentStatusSebagian
+		}
+		allocations = append(allocations, allocation)
+	}
+
+	return allocations, remaining
+}
+
+// takeAmount mengambil maksimal `due` dari `remaining` dan mengembalikan jumlah yang diambil.
+func takeAmount(remaining *domain.Money, due domain.Money) domain.Money {
+	if !due.IsPositive() || !remaining.IsPositive() {
+		return domain.Money{}
+	}
+	taken := domain.MinMoney(due, *remaining)
+	*remaining = remaining.Sub(taken)
+	return taken
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import "github.com/adty404/kredit-plus/internal/domain"
+
+type CreatePaymentInput struct {
+	Jumlah           domain.Money `json:"jumlah" binding:"required,gt=0"`
+	TanggalBayar     string       `json:"tanggal_bayar" binding:"omitempty,datetime=2006-01-02"`
+	MetodePembayaran string       `json:"metode_pembayaran" binding:"required"`
+	Referensi        string       `json:"referensi"`
+
+	// IdempotencyKey diisi dari header Idempotency-Key, bukan dari body.
+	IdempotencyKey string `json:"-"`
+}
+
+// PayoffInstallmentLine adalah rincian kewajiban pelunasan untuk satu angsuran.
+type PayoffInstallmentLine struct {
+	InstallmentID uint         `json:"installment_id"`
+	AngsuranKe    int          `json:"angsuran_ke"`
+	Pokok         domain.Money `json:"pokok"`
+	Bunga         domain.Money `json:"bunga"`
+	Denda         domain.Money `json:"denda"`
+}
+
+type PayoffQuoteOutput struct {
+	TransactionID    uint                    `json:"transaction_id"`
+	TanggalPelunasan domain.JSONDate         `json:"tanggal_pelunasan"`
+	SisaPokok        domain.Money            `json:"sisa_pokok"`
+	BungaBerjalan    domain.Money            `json:"bunga_berjalan"`
+	DendaTertunggak  domain.Money            `json:"denda_tertunggak"`
+	BiayaPelunasan   domain.Money            `json:"biaya_pelunasan"`
+	TotalPelunasan   domain.Money            `json:"total_pelunasan"`
+	Rincian          []PayoffInstallmentLine `json:"rincian"`
+}
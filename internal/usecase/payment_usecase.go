@@ -0,0 +1,459 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type PaymentUsecase interface {
+	CreatePayment(transactionID uint, input CreatePaymentInput) (*domain.Payment, error)
+	GetPayoffQuote(consumerID, transactionID uint, tanggal string) (*PayoffQuoteOutput, error)
+	PayoffTransaction(transactionID uint, input CreatePaymentInput) (*domain.Payment, error)
+}
+
+type paymentUsecase struct {
+	db              *gorm.DB
+	paymentRepo     domain.PaymentRepository
+	transactionRepo domain.TransactionRepository
+	installmentRepo domain.InstallmentRepository
+	historyRepo     domain.TransactionStatusHistoryRepository
+	idempotencyRepo domain.IdempotencyKeyRepository
+	pricingResolver PricingPolicyResolver
+	allocationOrder AllocationOrder
+}
+
+func NewPaymentUsecase(
+	db *gorm.DB,
+	paymentRepo domain.PaymentRepository,
+	transactionRepo domain.TransactionRepository,
+	installmentRepo domain.InstallmentRepository,
+	historyRepo domain.TransactionStatusHistoryRepository,
+	idempotencyRepo domain.IdempotencyKeyRepository,
+	pricingResolver PricingPolicyResolver,
+	allocationOrder AllocationOrder,
+) PaymentUsecase {
+	if len(allocationOrder) == 0 {
+		allocationOrder = DefaultAllocationOrder
+	}
+	return &paymentUsecase{
+		db:              db,
+		paymentRepo:     paymentRepo,
+		transactionRepo: transactionRepo,
+		installmentRepo: installmentRepo,
+		historyRepo:     historyRepo,
+		idempotencyRepo: idempotencyRepo,
+		pricingResolver: pricingResolver,
+		allocationOrder: allocationOrder,
+	}
+}
+
+// CreatePayment mencatat pembayaran dan mengalokasikannya ke angsuran tertua yang belum lunas.
+// Kontrak otomatis berubah menjadi LUNAS setelah seluruh angsuran terbayar.
+func (uc *paymentUsecase) CreatePayment(transactionID uint, input CreatePaymentInput) (*domain.Payment, error) {
+	tanggalBayar, err := parsePaymentDate(input.TanggalBayar, "tanggal_bayar")
+	if err != nil {
+		return nil, err
+	}
+
+	requestHash, err := idempotencyRequestHash(
+		struct {
+			TransactionID uint
+			Input         CreatePaymentInput
+		}{transactionID, input},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var newPayment *domain.Payment
+
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			transactionRepoTx := uc.transactionRepo.WithTx(tx)
+			installmentRepoTx := uc.installmentRepo.WithTx(tx)
+			paymentRepoTx := uc.paymentRepo.WithTx(tx)
+			idempotencyRepoTx := uc.idempotencyRepo.WithTx(tx)
+
+			// 1. KUNCI baris transaksi agar pembayaran paralel tidak mengalokasikan angsuran yang sama.
+			transaction, err := transactionRepoTx.FindByIDForUpdate(transactionID)
+			if err != nil {
+				return fmt.Errorf("transaction with id %d not found", transactionID)
+			}
+
+			// Permintaan ulang dengan Idempotency-Key yang sama mendapatkan pembayaran yang sudah dicatat.
+			var replayed domain.Payment
+			found, err := replayIdempotentResponse(
+				idempotencyRepoTx,
+				domain.IdempotencyScopeCreatePayment,
+				input.IdempotencyKey,
+				requestHash,
+				&replayed,
+			)
+			if err != nil {
+				return err
+			}
+			if found {
+				newPayment = &replayed
+				return nil
+			}
+
+			if !transaction.AcceptsPayment() {
+				return fmt.Errorf(
+					"cannot record payment for transaction with status %s",
+					transaction.StatusKontrak,
+				)
+			}
+			if err := validatePaymentDate(transaction, tanggalBayar, "tanggal_bayar"); err != nil {
+				return err
+			}
+
+			// 2. Ambil angsuran yang belum lunas, dari periode tertua.
+			installments, err := installmentRepoTx.FindOutstandingByTransactionID(transactionID)
+			if err != nil {
+				return err
+			}
+			if len(installments) == 0 {
+				return fmt.Errorf("transaction with id %d has no outstanding installments", transactionID)
+			}
+
+			// 3. Alokasikan dana mengikuti urutan komponen yang dikonfigurasi.
+			allocations, kelebihan := allocatePayment(installments, input.Jumlah, uc.allocationOrder, tanggalBayar)
+
+			payment := &domain.Payment{
+				TransactionID:    transactionID,
+				TanggalBayar:     tanggalBayar,
+				Jumlah:           input.Jumlah,
+				KelebihanBayar:   kelebihan,
+				JenisPembayaran:  domain.JenisPembayaranAngsuran,
+				MetodePembayaran: input.MetodePembayaran,
+				Referensi:        input.Referensi,
+				Allocations:      allocations,
+			}
+			for _, allocation := range allocations {
+				payment.DialokasikanDenda = payment.DialokasikanDenda.Add(allocation.Denda)
+				payment.DialokasikanBunga = payment.DialokasikanBunga.Add(allocation.Bunga)
+				payment.DialokasikanPokok = payment.DialokasikanPokok.Add(allocation.Pokok)
+			}
+
+			// 4. Simpan perubahan angsuran yang menerima alokasi.
+			allocated := make(map[uint]bool, len(allocations))
+			for _, allocation := range allocations {
+				allocated[allocation.InstallmentID] = true
+			}
+			allSettled := true
+			for _, installment := range installments {
+				if !installment.IsClosed() {
+					allSettled = false
+				}
+				if !allocated[installment.ID] {
+					continue
+				}
+				if err := installmentRepoTx.Update(installment); err != nil {
+					return err
+				}
+			}
+
+			// 5. Simpan pembayaran beserta rincian alokasinya.
+			if err := paymentRepoTx.Save(payment); err != nil {
+				return err
+			}
+
+			// 6. Catat pokok yang kembali (membebaskan plafon), perbarui hari keterlambatan,
+			// dan tutup kontrak jika seluruh angsuran lunas. Pembayaran dialokasikan per tanggal bayar,
+			// tetapi hari keterlambatan dan kolektibilitas yang disimpan selalu per hari ini agar
+			// pembayaran yang dicatat mundur tidak menyembunyikan angsuran yang jatuh tempo setelahnya.
+			transaction.PokokTerbayar = transaction.PokokTerbayar.Add(payment.DialokasikanPokok)
+			transaction.HariKeterlambatan = contractDPD(installments, time.Now())
+			transaction.Kolektibilitas = domain.KolektibilitasFromDPD(transaction.HariKeterlambatan)
+			if allSettled {
+				err := changeContractStatus(
+					transaction,
+					domain.StatusKontrakLunas,
+					"Seluruh angsuran telah lunas",
+					nil,
+					uc.historyRepo.WithTx(tx),
+				)
+				if err != nil {
+					return err
+				}
+			}
+			if err := transactionRepoTx.Update(transaction); err != nil {
+				return err
+			}
+
+			err = storeIdempotentResponse(
+				idempotencyRepoTx,
+				domain.IdempotencyScopeCreatePayment,
+				input.IdempotencyKey,
+				requestHash,
+				payment,
+			)
+			if err != nil {
+				return err
+			}
+
+			newPayment = payment
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newPayment, nil
+}
+
+// GetPayoffQuote menghitung nilai pelunasan dipercepat sebuah kontrak milik konsumen pada tanggal tertentu
+// (default hari ini, tidak boleh di masa depan maupun sebelum tanggal kontrak) tanpa mengubah data apa pun.
+func (uc *paymentUsecase) GetPayoffQuote(consumerID, transactionID uint, tanggal string) (*PayoffQuoteOutput, error) {
+	payoffDate, err := parsePaymentDate(tanggal, "tanggal")
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := uc.transactionRepo.FindByID(transactionID)
+	if err != nil || transaction.ConsumerID != consumerID {
+		return nil, fmt.Errorf("transaction with id %d not found for this consumer", transactionID)
+	}
+	if err := validatePaymentDate(transaction, payoffDate, "tanggal"); err != nil {
+		return nil, err
+	}
+
+	quote, _, err := uc.quotePayoff(transaction, uc.installmentRepo, payoffDate)
+	return quote, err
+}
+
+// PayoffTransaction melunasi kontrak lebih awal: menerima dana sebesar minimal nilai pelunasan,
+// menutup seluruh angsuran tersisa, menandai kontrak LUNAS, dan membebaskan plafonnya.
+func (uc *paymentUsecase) PayoffTransaction(transactionID uint, input CreatePaymentInput) (*domain.Payment, error) {
+	tanggalBayar, err := parsePaymentDate(input.TanggalBayar, "tanggal_bayar")
+	if err != nil {
+		return nil, err
+	}
+
+	requestHash, err := idempotencyRequestHash(
+		struct {
+			TransactionID uint
+			Input         CreatePaymentInput
+		}{transactionID, input},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var newPayment *domain.Payment
+
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			transactionRepoTx := uc.transactionRepo.WithTx(tx)
+			installmentRepoTx := uc.installmentRepo.WithTx(tx)
+			idempotencyRepoTx := uc.idempotencyRepo.WithTx(tx)
+
+			// 1. KUNCI baris transaksi agar pelunasan tidak bersamaan dengan pembayaran lain.
+			transaction, err := transactionRepoTx.FindByIDForUpdate(transactionID)
+			if err != nil {
+				return fmt.Errorf("transaction with id %d not found", transactionID)
+			}
+
+			// Permintaan ulang dengan Idempotency-Key yang sama mendapatkan pembayaran pelunasan yang sudah dicatat.
+			var replayed domain.Payment
+			found, err := replayIdempotentResponse(
+				idempotencyRepoTx,
+				domain.IdempotencyScopePayoff,
+				input.IdempotencyKey,
+				requestHash,
+				&replayed,
+			)
+			if err != nil {
+				return err
+			}
+			if found {
+				newPayment = &replayed
+				return nil
+			}
+
+			// 2. Hitung nilai pelunasan dan pastikan dana mencukupi. Bunga berjalan dihitung sampai tanggal bayar,
+			// sehingga tanggal tersebut tidak boleh di masa depan maupun sebelum kontrak dimulai.
+			if err := validatePaymentDate(transaction, tanggalBayar, "tanggal_bayar"); err != nil {
+				return err
+			}
+			quote, installments, err := uc.quotePayoff(transaction, installmentRepoTx, tanggalBayar)
+			if err != nil {
+				return err
+			}
+			if input.Jumlah.LessThan(quote.TotalPelunasan) {
+				return fmt.Errorf(
+					"payment amount (%s) is less than payoff amount (%s)",
+					input.Jumlah,
+					quote.TotalPelunasan,
+				)
+			}
+
+			payment := &domain.Payment{
+				TransactionID:     transactionID,
+				TanggalBayar:      tanggalBayar,
+				Jumlah:            input.Jumlah,
+				DialokasikanDenda: quote.DendaTertunggak,
+				DialokasikanBunga: quote.BungaBerjalan,
+				DialokasikanPokok: quote.SisaPokok,
+				BiayaPelunasan:    quote.BiayaPelunasan,
+				KelebihanBayar:    input.Jumlah.Sub(quote.TotalPelunasan),
+				JenisPembayaran:   domain.JenisPembayaranPelunasan,
+				MetodePembayaran:  input.MetodePembayaran,
+				Referensi:         input.Referensi,
+			}
+
+			// 3. Tutup seluruh angsuran tersisa sesuai rincian pelunasan.
+			lines := make(map[uint]PayoffInstallmentLine, len(quote.Rincian))
+			for _, line := range quote.Rincian {
+				lines[line.InstallmentID] = line
+			}
+			for _, installment := range installments {
+				line := lines[installment.ID]
+				applied := line.Denda.Add(line.Bunga).Add(line.Pokok)
+
+				installment.DendaDibayar = installment.DendaDibayar.Add(line.Denda)
+				installment.BungaDibayar = installment.BungaDibayar.Add(line.Bunga)
+				installment.PokokDibayar = installment.PokokDibayar.Add(line.Pokok)
+				installment.JumlahDibayar = installment.JumlahDibayar.Add(applied)
+				installment.Status = domain.InstallmentStatusDitutup
+				if !installment.SisaTagihan().IsPositive() {
+					installment.Status = domain.InstallmentStatusLunas
+				}
+				lunasPada := tanggalBayar
+				installment.TanggalLunas = &lunasPada
+
+				if err := installmentRepoTx.Update(installment); err != nil {
+					return err
+				}
+				if !applied.IsZero() {
+					payment.Allocations = append(
+						payment.Allocations, domain.PaymentAllocation{
+							InstallmentID: installment.ID,
+							AngsuranKe:    installment.AngsuranKe,
+							Denda:         line.Denda,
+							Bunga:         line.Bunga,
+							Pokok:         line.Pokok,
+						},
+					)
+				}
+			}
+
+			// 4. Simpan pembayaran pelunasan beserta rinciannya.
+			if err := uc.paymentRepo.WithTx(tx).Save(payment); err != nil {
+				return err
+			}
+
+			// 5. Tandai kontrak LUNAS; sisa pokok menjadi nol sehingga plafon kembali tersedia.
+			transaction.PokokTerbayar = transaction.PokokTerbayar.Add(quote.SisaPokok)
+			transaction.HariKeterlambatan = 0
+			transaction.Kolektibilitas = domain.KolektibilitasLancar
+			err = changeContractStatus(
+				transaction,
+				domain.StatusKontrakLunas,
+				"Pelunasan dipercepat",
+				nil,
+				uc.historyRepo.WithTx(tx),
+			)
+			if err != nil {
+				return err
+			}
+			if err := transactionRepoTx.Update(transaction); err != nil {
+				return err
+			}
+
+			err = storeIdempotentResponse(
+				idempotencyRepoTx,
+				domain.IdempotencyScopePayoff,
+				input.IdempotencyKey,
+				requestHash,
+				payment,
+			)
+			if err != nil {
+				return err
+			}
+
+			newPayment = payment
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newPayment, nil
+}
+
+// quotePayoff memvalidasi status kontrak lalu menghitung nilai pelunasan dari angsuran yang belum lunas.
+// Angsuran yang menjadi dasar perhitungan ikut dikembalikan agar dapat langsung ditutup oleh pemanggil.
+func (uc *paymentUsecase) quotePayoff(
+	transaction *domain.Transaction,
+	installmentRepo domain.InstallmentRepository,
+	payoffDate time.Time,
+) (*PayoffQuoteOutput, []*domain.Installment, error) {
+	if !transaction.AcceptsPayment() {
+		return nil, nil, fmt.Errorf("cannot pay off transaction with status %s", transaction.StatusKontrak)
+	}
+
+	installments, err := installmentRepo.FindOutstandingByTransactionID(transaction.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(installments) == 0 {
+		return nil, nil, fmt.Errorf("transaction with id %d has no outstanding installments", transaction.ID)
+	}
+
+	policy, err := uc.pricingResolver.Resolve(transaction.JenisAsset, transaction.TenorBulan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quote, err := computePayoffQuote(transaction, installments, payoffDate, policy.BiayaPelunasanDipercepat)
+	if err != nil {
+		return nil, nil, err
+	}
+	return quote, installments, nil
+}
+
+// parsePaymentDate mengurai tanggal berformat yyyy-MM-dd; string kosong berarti hari ini.
+func parsePaymentDate(raw, field string) (time.Time, error) {
+	if raw == "" {
+		return time.Now(), nil
+	}
+	parsed, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date format for %s, please use yyyy-MM-dd", field)
+	}
+	return parsed, nil
+}
+
+// validatePaymentDate menolak tanggal pembayaran di masa depan atau sebelum tanggal kontrak. Tanggal yang
+// mundur sebelum kontrak dimulai akan mengacaukan urutan alokasi serta perhitungan denda dan hari keterlambatan.
+func validatePaymentDate(transaction *domain.Transaction, tanggal time.Time, field string) error {
+	hari := calendarDate(tanggal)
+	if hari.After(calendarDate(time.Now())) {
+		return newRuleViolation(
+			RulePaymentDateInFuture, field, "%s (%s) must not be in the future", field, hari.Format("2006-01-02"),
+		)
+	}
+	if tanggalKontrak := calendarDate(transaction.TanggalKontrak); hari.Before(tanggalKontrak) {
+		return newRuleViolation(
+			RulePaymentDateBeforeKontrak, field,
+			"%s (%s) must not be before the contract date (%s)",
+			field, hari.Format("2006-01-02"), tanggalKontrak.Format("2006-01-02"),
+		)
+	}
+	return nil
+}
+
+// calendarDate mengembalikan tanggal kalender t tanpa jam dan zona waktu, sehingga tanggal dari zona waktu
+// berbeda dapat dibandingkan langsung.
+func calendarDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,683 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMocksForPaymentTest(t *testing.T) (
+	*gorm.DB,
+	sqlmock.Sqlmock,
+	*MockPaymentRepository,
+	*MockTransactionRepository,
+	*MockInstallmentRepository,
+) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(
+		postgres.New(
+			postgres.Config{
+				Conn: sqlDB,
+			},
+		), &gorm.Config{},
+	)
+	assert.NoError(t, err)
+
+	return gormDB, mockSQL, new(MockPaymentRepository), new(MockTransactionRepository), new(MockInstallmentRepository)
+}
+
+func newOutstandingInstallments() []*domain.Installment {
+	return []*domain.Installment{
+		{ID: 1, AngsuranKe: 1, Pokok: domain.NewMoney(1000000), Bunga: domain.NewMoney(100000), JumlahTagihan: domain.NewMoney(1100000), Denda: domain.NewMoney(50000)},
+		{ID: 2, AngsuranKe: 2, Pokok: domain.NewMoney(1000000), Bunga: domain.NewMoney(100000), JumlahTagihan: domain.NewMoney(1100000)},
+	}
+}
+
+func TestCreatePayment_PartialPaymentFollowsWaterfall(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		DefaultTenorPricing,
+		nil,
+	)
+
+	transaction := &domain.Transaction{ID: 7, StatusKontrak: domain.StatusKontrakAktif}
+	installments := newOutstandingInstallments()
+	input := CreatePaymentInput{Jumlah: domain.NewMoney(500000), MetodePembayaran: "TRANSFER"}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", installments[0]).Return(nil).Once()
+	mockPaymentRepo.On("Save", mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(50000), payment.DialokasikanDenda)
+	assert.Equal(t, domain.NewMoney(100000), payment.DialokasikanBunga)
+	assert.Equal(t, domain.NewMoney(350000), payment.DialokasikanPokok)
+	assert.Equal(t, domain.NewMoney(0), payment.KelebihanBayar)
+	assert.Equal(t, domain.InstallmentStatusSebagian, installments[0].Status)
+	assert.Equal(t, domain.NewMoney(650000), installments[0].SisaPokok())
+	assert.Equal(t, domain.StatusKontrakAktif, transaction.StatusKontrak)
+	assert.Equal(t, domain.NewMoney(350000), transaction.PokokTerbayar)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCreatePayment_CustomOrderPaysPrincipalFirst(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	order, err := ParseAllocationOrder("pokok,bunga,denda")
+	assert.NoError(t, err)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		DefaultTenorPricing,
+		order,
+	)
+
+	transaction := &domain.Transaction{ID: 7, StatusKontrak: domain.StatusKontrakAktif}
+	installments := newOutstandingInstallments()
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", installments[0]).Return(nil).Once()
+	mockPaymentRepo.On("Save", mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, CreatePaymentInput{Jumlah: domain.NewMoney(1050000), MetodePembayaran: "TRANSFER"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(1000000), payment.DialokasikanPokok)
+	assert.Equal(t, domain.NewMoney(50000), payment.DialokasikanBunga)
+	assert.Equal(t, domain.NewMoney(0), payment.DialokasikanDenda)
+	assert.Equal(t, domain.NewMoney(50000), installments[0].SisaDenda())
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestCreatePayment_OverpaymentSettlesContract(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockHistoryRepo := new(MockTransactionStatusHistoryRepository)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		mockHistoryRepo,
+		new(MockIdempotencyKeyRepository),
+		DefaultTenorPricing,
+		nil,
+	)
+
+	transaction := &domain.Transaction{ID: 7, StatusKontrak: domain.StatusKontrakAktif}
+	installments := newOutstandingInstallments()
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", mock.AnythingOfType("*domain.Installment")).Return(nil).Twice()
+	mockPaymentRepo.On("Save", mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+	mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(h *domain.TransactionStatusHistory) bool {
+				return h.FromStatus == domain.StatusKontrakAktif && h.ToStatus == domain.StatusKontrakLunas && h.ChangedBy == nil
+			},
+		),
+	).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, CreatePaymentInput{Jumlah: domain.NewMoney(2300000), MetodePembayaran: "TRANSFER"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, payment.Allocations, 2)
+	assert.Equal(t, domain.NewMoney(50000), payment.KelebihanBayar)
+	for _, installment := range installments {
+		assert.Equal(t, domain.InstallmentStatusLunas, installment.Status)
+		assert.NotNil(t, installment.TanggalLunas)
+	}
+	assert.Equal(t, domain.StatusKontrakLunas, transaction.StatusKontrak)
+	assert.Equal(t, domain.NewMoney(2000000), transaction.PokokTerbayar)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestCreatePayment_TransactionNotActive(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		DefaultTenorPricing,
+		nil,
+	)
+
+	transaction := &domain.Transaction{ID: 7, StatusKontrak: domain.StatusKontrakLunas}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, CreatePaymentInput{Jumlah: domain.NewMoney(100000), MetodePembayaran: "TRANSFER"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, payment)
+	assert.Contains(t, err.Error(), "cannot record payment for transaction with status LUNAS")
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockPaymentRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCreatePayment_RejectsInvalidPaymentDate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tanggalBayar string
+		code         string
+	}{
+		{
+			name:         "di masa depan",
+			tanggalBayar: time.Now().AddDate(0, 0, 1).Format("2006-01-02"),
+			code:         RulePaymentDateInFuture,
+		},
+		{name: "sebelum tanggal kontrak", tanggalBayar: "2026-01-09", code: RulePaymentDateBeforeKontrak},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+				usecase := NewPaymentUsecase(
+					gormDB,
+					mockPaymentRepo,
+					mockTransactionRepo,
+					mockInstallmentRepo,
+					new(MockTransactionStatusHistoryRepository),
+					new(MockIdempotencyKeyRepository),
+					DefaultTenorPricing,
+					nil,
+				)
+
+				transaction := &domain.Transaction{
+					ID:             7,
+					TanggalKontrak: time.Date(2026, 1, 10, 14, 30, 0, 0, time.UTC),
+					StatusKontrak:  domain.StatusKontrakAktif,
+				}
+				input := CreatePaymentInput{
+					Jumlah:           domain.NewMoney(500000),
+					TanggalBayar:     tc.tanggalBayar,
+					MetodePembayaran: "TRANSFER",
+				}
+
+				mockSQL.ExpectBegin()
+				mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+				mockSQL.ExpectRollback()
+
+				// Act
+				payment, err := usecase.CreatePayment(7, input)
+
+				// Assert
+				assert.Nil(t, payment)
+				violation := AsRuleViolation(err)
+				if assert.NotNil(t, violation) {
+					assert.Equal(t, tc.code, violation.Code)
+					assert.Equal(t, "tanggal_bayar", violation.Field)
+				}
+				assert.NoError(t, mockSQL.ExpectationsWereMet())
+				mockInstallmentRepo.AssertNotCalled(t, "FindOutstandingByTransactionID", mock.Anything)
+				mockPaymentRepo.AssertNotCalled(t, "Save", mock.Anything)
+			},
+		)
+	}
+}
+
+func TestCreatePayment_AcceptsPaymentOnContractDate(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		DefaultTenorPricing,
+		nil,
+	)
+
+	// Kontrak dibuat siang hari; pembayaran pada tanggal yang sama tetap diterima.
+	transaction := &domain.Transaction{
+		ID:             7,
+		TanggalKontrak: time.Date(2026, 1, 10, 14, 30, 0, 0, time.UTC),
+		StatusKontrak:  domain.StatusKontrakAktif,
+	}
+	installments := newOutstandingInstallments()
+	input := CreatePaymentInput{Jumlah: domain.NewMoney(500000), TanggalBayar: "2026-01-10", MetodePembayaran: "TRANSFER"}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", installments[0]).Return(nil).Once()
+	mockPaymentRepo.On("Save", mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-01-10", payment.TanggalBayar.Format("2006-01-02"))
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestCreatePayment_BackdatedPaymentStoresCurrentDelinquency(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		DefaultTenorPricing,
+		nil,
+	)
+
+	// Angsuran kedua jatuh tempo setelah tanggal bayar yang dicatat mundur dan sampai hari ini belum dibayar.
+	today := calendarDate(time.Now())
+	transaction := &domain.Transaction{
+		ID:             7,
+		TanggalKontrak: today.AddDate(0, 0, -70),
+		StatusKontrak:  domain.StatusKontrakAktif,
+	}
+	installments := newOutstandingInstallments()
+	installments[0].TanggalJatuhTempo = today.AddDate(0, 0, -40)
+	installments[1].TanggalJatuhTempo = today.AddDate(0, 0, -10)
+	input := CreatePaymentInput{
+		Jumlah:           domain.NewMoney(1150000),
+		TanggalBayar:     today.AddDate(0, 0, -35).Format("2006-01-02"),
+		MetodePembayaran: "TRANSFER",
+	}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", installments[0]).Return(nil).Once()
+	mockPaymentRepo.On("Save", mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, input)
+
+	// Assert: alokasi mengikuti tanggal bayar, sedangkan keterlambatan dihitung per hari ini.
+	assert.NoError(t, err)
+	assert.Equal(t, input.TanggalBayar, payment.TanggalBayar.Format("2006-01-02"))
+	assert.Equal(t, domain.InstallmentStatusLunas, installments[0].Status)
+	assert.Equal(t, 10, transaction.HariKeterlambatan)
+	assert.Equal(t, domain.KolektibilitasDPK, transaction.Kolektibilitas)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestParseAllocationOrder_Invalid(t *testing.T) {
+	_, err := ParseAllocationOrder("bunga,pokok")
+	assert.Error(t, err)
+
+	_, err = ParseAllocationOrder("bunga,bunga,pokok")
+	assert.Error(t, err)
+
+	order, err := ParseAllocationOrder("")
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultAllocationOrder, order)
+}
+
+// newPayoffFixture menyiapkan kontrak 3 bulan (pokok 1.000.000 dan bunga 60.000 per bulan)
+// yang angsuran pertamanya sudah lunas dan angsuran kedua memiliki denda 10.000.
+func newPayoffFixture() (*domain.Transaction, []*domain.Installment) {
+	tanggalKontrak := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	transaction := &domain.Transaction{
+		ID:                  7,
+		ConsumerID:          1,
+		TanggalKontrak:      tanggalKontrak,
+		TenorBulan:          3,
+		PokokPembiayaanAwal: domain.NewMoney(3000000),
+		PokokTerbayar:       domain.NewMoney(1000000),
+		StatusKontrak:       domain.StatusKontrakAktif,
+	}
+	installments := []*domain.Installment{
+		{
+			ID:                2,
+			AngsuranKe:        2,
+			TanggalJatuhTempo: addMonthsClamped(tanggalKontrak, 2),
+			Pokok:             domain.NewMoney(1000000),
+			Bunga:             domain.NewMoney(60000),
+			JumlahTagihan:     domain.NewMoney(1060000),
+			Denda:             domain.NewMoney(10000),
+			Status:            domain.InstallmentStatusBelumBayar,
+		},
+		{
+			ID:                3,
+			AngsuranKe:        3,
+			TanggalJatuhTempo: addMonthsClamped(tanggalKontrak, 3),
+			Pokok:             domain.NewMoney(1000000),
+			Bunga:             domain.NewMoney(60000),
+			JumlahTagihan:     domain.NewMoney(1060000),
+			Status:            domain.InstallmentStatusBelumBayar,
+		},
+	}
+	return transaction, installments
+}
+
+var payoffPricing = TenorPricingTable{
+	3: {MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24, BiayaPelunasanDipercepat: 0.03},
+}
+
+func TestGetPayoffQuote_ProratesCurrentPeriodInterest(t *testing.T) {
+	// Arrange
+	gormDB, _, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		payoffPricing,
+		nil,
+	)
+
+	transaction, installments := newPayoffFixture()
+	mockTransactionRepo.On("FindByID", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+
+	// Act
+	quote, err := usecase.GetPayoffQuote(1, 7, "2026-02-25")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(2000000), quote.SisaPokok)
+	// Periode 10 Feb - 10 Mar (28 hari), berjalan 15 hari: 60.000 x 15 / 28 = 32.142,86 -> 32.143.
+	assert.Equal(t, domain.NewMoney(32143), quote.BungaBerjalan)
+	assert.Equal(t, domain.NewMoney(10000), quote.DendaTertunggak)
+	assert.Equal(t, domain.NewMoney(60000), quote.BiayaPelunasan)
+	assert.Equal(t, domain.NewMoney(2102143), quote.TotalPelunasan)
+	assert.Equal(t, domain.Money{}, quote.Rincian[1].Bunga)
+}
+
+func TestGetPayoffQuote_RejectsInvalidDate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tanggal string
+		code    string
+	}{
+		{name: "di masa depan", tanggal: time.Now().AddDate(0, 0, 1).Format("2006-01-02"), code: RulePaymentDateInFuture},
+		{name: "sebelum tanggal kontrak", tanggal: "2026-01-09", code: RulePaymentDateBeforeKontrak},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				gormDB, _, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+				usecase := NewPaymentUsecase(
+					gormDB,
+					mockPaymentRepo,
+					mockTransactionRepo,
+					mockInstallmentRepo,
+					new(MockTransactionStatusHistoryRepository),
+					new(MockIdempotencyKeyRepository),
+					payoffPricing,
+					nil,
+				)
+
+				transaction, _ := newPayoffFixture()
+				mockTransactionRepo.On("FindByID", uint(7)).Return(transaction, nil).Once()
+
+				// Act
+				quote, err := usecase.GetPayoffQuote(1, 7, tc.tanggal)
+
+				// Assert
+				assert.Nil(t, quote)
+				violation := AsRuleViolation(err)
+				if assert.NotNil(t, violation) {
+					assert.Equal(t, tc.code, violation.Code)
+					assert.Equal(t, "tanggal", violation.Field)
+				}
+				mockInstallmentRepo.AssertNotCalled(t, "FindOutstandingByTransactionID", mock.Anything)
+			},
+		)
+	}
+}
+
+func TestPayoffTransaction_ClosesInstallmentsAndSettlesContract(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockHistoryRepo := new(MockTransactionStatusHistoryRepository)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		mockHistoryRepo,
+		new(MockIdempotencyKeyRepository),
+		payoffPricing,
+		nil,
+	)
+
+	transaction, installments := newPayoffFixture()
+	input := CreatePaymentInput{Jumlah: domain.NewMoney(2102143), TanggalBayar: "2026-02-25", MetodePembayaran: "TRANSFER"}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", mock.AnythingOfType("*domain.Installment")).Return(nil).Twice()
+	mockPaymentRepo.On("Save", mock.AnythingOfType("*domain.Payment")).Return(nil).Once()
+	mockHistoryRepo.On("Save", mock.AnythingOfType("*domain.TransactionStatusHistory")).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.PayoffTransaction(7, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.JenisPembayaranPelunasan, payment.JenisPembayaran)
+	assert.Equal(t, domain.NewMoney(60000), payment.BiayaPelunasan)
+	assert.Equal(t, domain.Money{}, payment.KelebihanBayar)
+	assert.Len(t, payment.Allocations, 2)
+	for _, installment := range installments {
+		assert.Equal(t, domain.InstallmentStatusDitutup, installment.Status)
+		assert.True(t, installment.SisaPokok().IsZero())
+		assert.NotNil(t, installment.TanggalLunas)
+	}
+	assert.Equal(t, domain.StatusKontrakLunas, transaction.StatusKontrak)
+	assert.Equal(t, "Pelunasan dipercepat", transaction.Catatan)
+	assert.True(t, transaction.SisaPokok().IsZero())
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestPayoffTransaction_InsufficientAmount(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		payoffPricing,
+		nil,
+	)
+
+	transaction, installments := newPayoffFixture()
+	input := CreatePaymentInput{Jumlah: domain.NewMoney(2000000), TanggalBayar: "2026-02-25", MetodePembayaran: "TRANSFER"}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindOutstandingByTransactionID", uint(7)).Return(installments, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	payment, err := usecase.PayoffTransaction(7, input)
+
+	// Assert
+	assert.Nil(t, payment)
+	assert.EqualError(t, err, "payment amount (2000000.00) is less than payoff amount (2102143.00)")
+	assert.Equal(t, domain.StatusKontrakAktif, transaction.StatusKontrak)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockPaymentRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestPayoffTransaction_RejectsInvalidPaymentDate(t *testing.T) {
+	testCases := []struct {
+		name         string
+		tanggalBayar string
+		code         string
+	}{
+		{
+			name:         "di masa depan",
+			tanggalBayar: time.Now().AddDate(0, 0, 1).Format("2006-01-02"),
+			code:         RulePaymentDateInFuture,
+		},
+		{name: "sebelum tanggal kontrak", tanggalBayar: "2026-01-09", code: RulePaymentDateBeforeKontrak},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+				usecase := NewPaymentUsecase(
+					gormDB,
+					mockPaymentRepo,
+					mockTransactionRepo,
+					mockInstallmentRepo,
+					new(MockTransactionStatusHistoryRepository),
+					new(MockIdempotencyKeyRepository),
+					payoffPricing,
+					nil,
+				)
+
+				transaction, _ := newPayoffFixture()
+				input := CreatePaymentInput{
+					Jumlah:           domain.NewMoney(3000000),
+					TanggalBayar:     tc.tanggalBayar,
+					MetodePembayaran: "TRANSFER",
+				}
+
+				mockSQL.ExpectBegin()
+				mockTransactionRepo.On("FindByIDForUpdate", uint(7)).Return(transaction, nil).Once()
+				mockSQL.ExpectRollback()
+
+				// Act
+				payment, err := usecase.PayoffTransaction(7, input)
+
+				// Assert
+				assert.Nil(t, payment)
+				violation := AsRuleViolation(err)
+				if assert.NotNil(t, violation) {
+					assert.Equal(t, tc.code, violation.Code)
+					assert.Equal(t, "tanggal_bayar", violation.Field)
+				}
+				assert.Equal(t, domain.StatusKontrakAktif, transaction.StatusKontrak)
+				assert.NoError(t, mockSQL.ExpectationsWereMet())
+				mockInstallmentRepo.AssertNotCalled(t, "FindOutstandingByTransactionID", mock.Anything)
+			},
+		)
+	}
+}
+
+func TestCreatePayment_ReplaysIdempotentRequest(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockPaymentRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksForPaymentTest(t)
+	mockIdempotencyRepo := new(MockIdempotencyKeyRepository)
+	usecase := NewPaymentUsecase(
+		gormDB,
+		mockPaymentRepo,
+		mockTransactionRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		mockIdempotencyRepo,
+		DefaultTenorPricing,
+		nil,
+	)
+
+	input := CreatePaymentInput{Jumlah: domain.NewMoney(500000), MetodePembayaran: "TRANSFER", IdempotencyKey: "pay-1"}
+	requestHash, err := idempotencyRequestHash(
+		struct {
+			TransactionID uint
+			Input         CreatePaymentInput
+		}{7, input},
+	)
+	assert.NoError(t, err)
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(7)).
+		Return(&domain.Transaction{ID: 7, StatusKontrak: domain.StatusKontrakAktif}, nil).Once()
+	mockIdempotencyRepo.On("FindByScopeAndKey", domain.IdempotencyScopeCreatePayment, "pay-1").Return(
+		&domain.IdempotencyKey{
+			Scope:        domain.IdempotencyScopeCreatePayment,
+			Kunci:        "pay-1",
+			RequestHash:  requestHash,
+			ResponseBody: `{"ID":5,"TransactionID":7,"Jumlah":500000.00}`,
+		}, nil,
+	).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	payment, err := usecase.CreatePayment(7, input)
+
+	// Assert: pembayaran tidak dicatat dua kali
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), payment.ID)
+	assert.Equal(t, domain.NewMoney(500000), payment.Jumlah)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockPaymentRepo.AssertNotCalled(t, "Save", mock.Anything)
+	mockInstallmentRepo.AssertNotCalled(t, "FindOutstandingByTransactionID", mock.Anything)
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// computePayoffQuote menghitung kewajiban pelunasan dipercepat per tanggal pelunasan:
+//   - seluruh sisa pokok dan denda yang belum dibayar,
+//   - bunga penuh untuk angsuran yang sudah jatuh tempo,
+//   - bunga berjalan pro-rata harian untuk periode yang sedang berjalan,
+//   - tanpa bunga untuk periode setelahnya,
+//   - ditambah biaya pelunasan dipercepat sebesar persentase dari sisa pokok.
+func computePayoffQuote(
+	trx *domain.Transaction,
+	installments []*domain.Installment,
+	payoffDate time.Time,
+	biayaPelunasanRate float64,
+) (*PayoffQuoteOutput, error) {
+	payoffDay := truncateToDate(payoffDate)
+	quote := &PayoffQuoteOutput{
+		TransactionID:    trx.ID,
+		TanggalPelunasan: domain.JSONDate(payoffDay),
+		Rincian:          make([]PayoffInstallmentLine, 0, len(installments)),
+	}
+
+	for _, installment := range installments {
+		if installment.IsClosed() {
+			continue
+		}
+
+		bunga, err := accruedInterest(trx, installment, payoffDay)
+		if err != nil {
+			return nil, err
+		}
+		line := PayoffInstallmentLine{
+			InstallmentID: installment.ID,
+			AngsuranKe:    installment.AngsuranKe,
+			Pokok:         installment.SisaPokok(),
+			Denda:         installment.SisaDenda(),
+			Bunga:         bunga,
+		}
+
+		quote.SisaPokok = quote.SisaPokok.Add(line.Pokok)
+		quote.BungaBerjalan = quote.BungaBerjalan.Add(line.Bunga)
+		quote.DendaTertunggak = quote.DendaTertunggak.Add(line.Denda)
+		quote.Rincian = append(quote.Rincian, line)
+	}
+
+	biayaPelunasan, err := quote.SisaPokok.MulRound(biayaPelunasanRate, domain.SatuRupiah)
+	if err != nil {
+		return nil, err
+	}
+	quote.BiayaPelunasan = biayaPelunasan
+	quote.TotalPelunasan = quote.SisaPokok.
+		Add(quote.BungaBerjalan).
+		Add(quote.DendaTertunggak).
+		Add(quote.BiayaPelunasan)
+
+	return quote, nil
+}
+
+// accruedInterest mengembalikan bunga sebuah angsuran yang masih harus dibayar jika kontrak dilunasi pada payoffDay.
+func accruedInterest(
+	trx *domain.Transaction,
+	installment *domain.Installment,
+	payoffDay time.Time,
+) (domain.Money, error) {
+	jatuhTempo := truncateToDate(installment.TanggalJatuhTempo)
+	if !payoffDay.Before(jatuhTempo) {
+		return installment.SisaBunga(), nil
+	}
+
+	awalPeriode := truncateToDate(addMonthsClamped(trx.TanggalKontrak, installment.AngsuranKe-1))
+	if !payoffDay.After(awalPeriode) {
+		return domain.Money{}, nil
+	}
+
+	hariBerjalan := daysBetween(awalPeriode, payoffDay)
+	hariPeriode := daysBetween(awalPeriode, jatuhTempo)
+	bungaBerjalan, err := installment.Bunga.MulInt(int64(hariBerjalan)).DivRound(int64(hariPeriode), domain.SatuRupiah)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	sisa := bungaBerjalan.Sub(installment.BungaDibayar)
+	if sisa.IsNegative() {
+		return domain.Money{}, nil
+	}
+	return sisa, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours()/24 + 0.5)
+}
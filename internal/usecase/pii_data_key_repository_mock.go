@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockPIIDataKeyRepository adalah implementasi mock dari domain.PIIDataKeyRepository.
+type MockPIIDataKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPIIDataKeyRepository) WithTx(tx *gorm.DB) domain.PIIDataKeyRepository {
+	return m
+}
+
+func (m *MockPIIDataKeyRepository) FindActive() (*domain.PIIDataKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PIIDataKey), args.Error(1)
+}
+
+func (m *MockPIIDataKeyRepository) FindByID(id uint) (*domain.PIIDataKey, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PIIDataKey), args.Error(1)
+}
+
+func (m *MockPIIDataKeyRepository) FindAll() ([]*domain.PIIDataKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PIIDataKey), args.Error(1)
+}
+
+func (m *MockPIIDataKeyRepository) ReplaceActive(key *domain.PIIDataKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockPIIDataKeyRepository) Update(id uint, updates map[string]interface{}) error {
+	args := m.Called(id, updates)
+	return args.Error(0)
+}
+
+// MockPIIKeyManager adalah implementasi mock dari domain.PIIKeyManager.
+type MockPIIKeyManager struct {
+	mock.Mock
+}
+
+func (m *MockPIIKeyManager) RotateDataKey() (*domain.PIIDataKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PIIDataKey), args.Error(1)
+}
+
+func (m *MockPIIKeyManager) RewrapDataKeys() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
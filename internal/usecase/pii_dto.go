@@ -0,0 +1,8 @@
+package usecase
+
+// ReencryptPIIOutput merangkum hasil enkripsi ulang data pribadi konsumen setelah rotasi kunci.
+type ReencryptPIIOutput struct {
+	DataKeyDibungkusUlang int      `json:"data_key_dibungkus_ulang"`
+	KonsumenDienkripsi    int      `json:"konsumen_dienkripsi"`
+	Errors                []string `json:"errors,omitempty"`
+}
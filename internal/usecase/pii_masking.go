@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// nikVisibleDigits adalah jumlah digit NIK yang tetap terlihat di awal dan akhir NIK yang disamarkan.
+const nikVisibleDigits = 4
+
+// CanViewFullPII menentukan apakah pemanggil dengan peran role boleh melihat data pribadi konsumen (NIK, gaji,
+// dan tanggal lahir) secara lengkap. Compliance dan konsumen pemilik data melihat data lengkap; operator (admin)
+// dan peran lain hanya melihat data yang disamarkan.
+func CanViewFullPII(role string, isOwner bool) bool {
+	return role == domain.RoleCompliance || isOwner
+}
+
+// MaskNIK menyamarkan NIK dengan hanya menampilkan empat digit pertama (kode wilayah) dan empat digit terakhir,
+// misalnya 3201********0001. NIK yang terlalu pendek disamarkan seluruhnya.
+func MaskNIK(nik string) string {
+	if len(nik) <= 2*nikVisibleDigits {
+		return strings.Repeat("*", len(nik))
+	}
+	return nik[:nikVisibleDigits] +
+		strings.Repeat("*", len(nik)-2*nikVisibleDigits) +
+		nik[len(nik)-nikVisibleDigits:]
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskNIK(t *testing.T) {
+	assert.Equal(t, "3201********0001", MaskNIK("3201011505900001"))
+	assert.Equal(t, "********", MaskNIK("32010115"))
+	assert.Equal(t, "", MaskNIK(""))
+}
+
+func TestCanViewFullPII(t *testing.T) {
+	assert.True(t, CanViewFullPII(domain.RoleCompliance, false))
+	assert.True(t, CanViewFullPII(domain.RoleConsumer, true))
+	assert.False(t, CanViewFullPII(domain.RoleAdmin, false))
+	assert.False(t, CanViewFullPII(domain.RoleConsumer, false))
+}
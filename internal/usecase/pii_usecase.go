@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+)
+
+// piiReencryptBatchSize adalah jumlah konsumen yang dienkripsi ulang per batch.
+const piiReencryptBatchSize = 100
+
+// PIIUsecase mengelola rotasi kunci enkripsi data pribadi konsumen (UU PDP).
+type PIIUsecase interface {
+	RotateDataKey() (*domain.PIIDataKey, error)
+	ReencryptConsumers() (*ReencryptPIIOutput, error)
+}
+
+type piiUsecase struct {
+	keyManager   domain.PIIKeyManager
+	consumerRepo domain.ConsumerRepository
+}
+
+func NewPIIUsecase(keyManager domain.PIIKeyManager, consumerRepo domain.ConsumerRepository) PIIUsecase {
+	return &piiUsecase{
+		keyManager:   keyManager,
+		consumerRepo: consumerRepo,
+	}
+}
+
+// RotateDataKey membuat DEK baru sebagai DEK aktif. Data yang sudah ada tetap terbaca dengan DEK lama sampai
+// ReencryptConsumers dijalankan.
+func (uc *piiUsecase) RotateDataKey() (*domain.PIIDataKey, error) {
+	return uc.keyManager.RotateDataKey()
+}
+
+// ReencryptConsumers membungkus ulang semua DEK dengan KEK aktif, lalu menulis ulang data pribadi setiap
+// konsumen dengan DEK aktif dan menghitung ulang blind index NIK-nya. Baris lama yang masih plaintext atau
+// ciphertext lama yang belum terikat pada ID baris ikut dienkripsi ulang. Kegagalan pada satu konsumen dicatat
+// ke Errors tanpa menghentikan proses.
+func (uc *piiUsecase) ReencryptConsumers() (*ReencryptPIIOutput, error) {
+	output := &ReencryptPIIOutput{}
+	rewrapped, err := uc.keyManager.RewrapDataKeys()
+	if err != nil {
+		return nil, fmt.Errorf("could not rewrap PII data keys: %w", err)
+	}
+	output.DataKeyDibungkusUlang = rewrapped
+
+	var lastID uint
+	for {
+		consumers, err := uc.consumerRepo.FindAfterID(lastID, piiReencryptBatchSize)
+		if err != nil {
+			return output, fmt.Errorf("could not load consumers after id %d: %w", lastID, err)
+		}
+		for _, consumer := range consumers {
+			lastID = consumer.ID
+			if err := uc.consumerRepo.UpdatePII(consumer); err != nil {
+				output.Errors = append(output.Errors, fmt.Sprintf("consumer %d: %v", consumer.ID, err))
+				continue
+			}
+			output.KonsumenDienkripsi++
+		}
+		if len(consumers) < piiReencryptBatchSize {
+			return output, nil
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package usecase
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/adty404/kredit-plus/internal/platform/pii"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var testBlindIndexKey = bytes.Repeat([]byte{9}, 32)
+
+var (
+	testNikLocation      = pii.Location{Table: "consumers", Column: "nik", RowID: 1}
+	testFullNameLocation = pii.Location{Table: "consumers", Column: "full_name", RowID: 1}
+	testGajiLocation     = pii.Location{Table: "consumers", Column: "gaji", RowID: 1}
+)
+
+func testPIIKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func newTestKeyProvider(t *testing.T, activeID string, keys map[string]string) pii.KeyProvider {
+	provider, err := pii.NewKeyProvider(activeID, keys)
+	assert.NoError(t, err)
+	return provider
+}
+
+// expectNewDataKey menyiapkan mock agar DEK baru tersimpan dengan ID id; salinannya dikembalikan untuk dipakai
+// ulang oleh Cipher lain.
+func expectNewDataKey(repo *MockPIIDataKeyRepository, id uint) *domain.PIIDataKey {
+	stored := &domain.PIIDataKey{}
+	repo.On("ReplaceActive", mock.Anything).Run(
+		func(args mock.Arguments) {
+			key := args.Get(0).(*domain.PIIDataKey)
+			key.ID = id
+			key.Aktif = true
+			*stored = *key
+		},
+	).Return(nil).Once()
+	return stored
+}
+
+func TestPIICipher_EncryptDecrypt(t *testing.T) {
+	// Arrange
+	repo := new(MockPIIDataKeyRepository)
+	keys := newTestKeyProvider(t, "", map[string]string{"2026-01": testPIIKey(1)})
+	cipher := pii.NewCipher(keys, repo, testBlindIndexKey)
+	repo.On("FindActive").Return(nil, gorm.ErrRecordNotFound).Once()
+	dataKey := expectNewDataKey(repo, 1)
+
+	// Act
+	ciphertext, err := cipher.Encrypt(testNikLocation, []byte("3271011505900001"))
+	assert.NoError(t, err)
+	plaintext, decryptErr := cipher.Decrypt(testNikLocation, ciphertext)
+	_, otherColumnErr := cipher.Decrypt(pii.Location{Table: "consumers", Column: "legal_name", RowID: 1}, ciphertext)
+	_, otherRowErr := cipher.Decrypt(pii.Location{Table: "consumers", Column: "nik", RowID: 2}, ciphertext)
+	_, otherTableErr := cipher.Decrypt(pii.Location{Table: "users", Column: "nik", RowID: 1}, ciphertext)
+	_, withoutRowErr := cipher.Encrypt(pii.Location{Table: "consumers", Column: "nik"}, []byte("3271011505900001"))
+	legacy, legacyErr := cipher.Decrypt(testNikLocation, "3271011505900001")
+
+	// Assert
+	assert.True(t, pii.IsEncrypted(ciphertext))
+	assert.NotContains(t, ciphertext, "3271011505900001")
+	assert.Equal(t, "2026-01", dataKey.KEKID)
+	assert.NoError(t, decryptErr)
+	assert.Equal(t, "3271011505900001", string(plaintext))
+	assert.Error(t, otherColumnErr, "ciphertext tidak boleh dapat dipindahkan ke kolom lain")
+	assert.Error(t, otherRowErr, "ciphertext tidak boleh dapat dipindahkan ke baris lain")
+	assert.Error(t, otherTableErr, "ciphertext tidak boleh dapat dipindahkan ke tabel lain")
+	assert.EqualError(t, withoutRowErr, "row id is required to encrypt column consumers.nik")
+	assert.NoError(t, legacyErr)
+	assert.Equal(t, "3271011505900001", string(legacy))
+	repo.AssertExpectations(t)
+}
+
+// sealForTest mengenkripsi plaintext dengan AES-GCM seperti Cipher, untuk menyiapkan data lama di pengujian.
+func sealForTest(t *testing.T, key, plaintext, associatedData []byte) []byte {
+	block, err := aes.NewCipher(key)
+	assert.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	assert.NoError(t, err)
+	nonce := bytes.Repeat([]byte{3}, aead.NonceSize())
+	return aead.Seal(nonce, nonce, plaintext, associatedData)
+}
+
+func TestPIICipher_DecryptsLegacyCiphertext(t *testing.T) {
+	// Arrange: ciphertext pii:v1 lama hanya terikat pada nama kolom.
+	repo := new(MockPIIDataKeyRepository)
+	keys := newTestKeyProvider(t, "", map[string]string{"2026-01": testPIIKey(1)})
+	cipher := pii.NewCipher(keys, repo, testBlindIndexKey)
+	dek := bytes.Repeat([]byte{5}, 32)
+	wrapped := sealForTest(t, bytes.Repeat([]byte{1}, 32), dek, []byte("pii-data-key:2026-01"))
+	repo.On("FindByID", uint(1)).Return(&domain.PIIDataKey{ID: 1, KEKID: "2026-01", WrappedKey: wrapped}, nil).Once()
+	legacy := "pii:v1:1:" + base64.StdEncoding.EncodeToString(
+		sealForTest(t, dek, []byte("3271011505900001"), []byte("nik")),
+	)
+
+	// Act
+	plaintext, err := cipher.Decrypt(testNikLocation, legacy)
+	_, otherColumnErr := cipher.Decrypt(pii.Location{Table: "consumers", Column: "legal_name", RowID: 1}, legacy)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "3271011505900001", string(plaintext))
+	assert.Error(t, otherColumnErr)
+	repo.AssertExpectations(t)
+}
+
+func TestPIICipher_BlindIndex(t *testing.T) {
+	cipher := pii.NewCipher(nil, nil, testBlindIndexKey)
+	otherCipher := pii.NewCipher(nil, nil, bytes.Repeat([]byte{8}, 32))
+
+	assert.Equal(t, cipher.BlindIndex("3271011505900001"), cipher.BlindIndex("3271011505900001"))
+	assert.NotEqual(t, cipher.BlindIndex("3271011505900001"), cipher.BlindIndex("3271011505900002"))
+	assert.NotEqual(t, cipher.BlindIndex("3271011505900001"), otherCipher.BlindIndex("3271011505900001"))
+	assert.Len(t, cipher.BlindIndex("3271011505900001"), 64)
+}
+
+func TestPIICipher_RotateDataKey_KeepsOldDataReadable(t *testing.T) {
+	// Arrange
+	repo := new(MockPIIDataKeyRepository)
+	keys := newTestKeyProvider(t, "", map[string]string{"2026-01": testPIIKey(1)})
+	cipher := pii.NewCipher(keys, repo, testBlindIndexKey)
+	repo.On("FindActive").Return(nil, gorm.ErrRecordNotFound).Once()
+	expectNewDataKey(repo, 1)
+	oldCiphertext, err := cipher.Encrypt(testFullNameLocation, []byte("Budi Santoso"))
+	assert.NoError(t, err)
+	expectNewDataKey(repo, 2)
+
+	// Act
+	dataKey, rotateErr := cipher.RotateDataKey()
+	newCiphertext, encryptErr := cipher.Encrypt(testFullNameLocation, []byte("Budi Santoso"))
+	oldPlaintext, decryptErr := cipher.Decrypt(testFullNameLocation, oldCiphertext)
+
+	// Assert
+	assert.NoError(t, rotateErr)
+	assert.NoError(t, encryptErr)
+	assert.NoError(t, decryptErr)
+	assert.Equal(t, uint(2), dataKey.ID)
+	assert.True(t, strings.HasPrefix(oldCiphertext, "pii:v2:1:"))
+	assert.True(t, strings.HasPrefix(newCiphertext, "pii:v2:2:"))
+	assert.Equal(t, "Budi Santoso", string(oldPlaintext))
+	repo.AssertExpectations(t)
+}
+
+func TestPIICipher_RewrapDataKeys_AfterKEKRotation(t *testing.T) {
+	// Arrange: data dienkripsi dengan DEK yang dibungkus KEK lama.
+	oldRepo := new(MockPIIDataKeyRepository)
+	oldKeys := newTestKeyProvider(t, "", map[string]string{"2026-01": testPIIKey(1)})
+	oldCipher := pii.NewCipher(oldKeys, oldRepo, testBlindIndexKey)
+	oldRepo.On("FindActive").Return(nil, gorm.ErrRecordNotFound).Once()
+	dataKey := expectNewDataKey(oldRepo, 1)
+	ciphertext, err := oldCipher.Encrypt(testNikLocation, []byte("3271011505900001"))
+	assert.NoError(t, err)
+
+	rotatedRepo := new(MockPIIDataKeyRepository)
+	rotatedKeys := newTestKeyProvider(
+		t, "2026-10", map[string]string{"2026-01": testPIIKey(1), "2026-10": testPIIKey(2)},
+	)
+	rotatedCipher := pii.NewCipher(rotatedKeys, rotatedRepo, testBlindIndexKey)
+	rewrapped := *dataKey
+	rotatedRepo.On("FindAll").Return([]*domain.PIIDataKey{dataKey}, nil).Once()
+	rotatedRepo.On(
+		"Update", uint(1), mock.MatchedBy(
+			func(updates map[string]interface{}) bool {
+				return updates["kek_id"] == "2026-10"
+			},
+		),
+	).Run(
+		func(args mock.Arguments) {
+			updates := args.Get(1).(map[string]interface{})
+			rewrapped.KEKID = updates["kek_id"].(string)
+			rewrapped.WrappedKey = updates["wrapped_key"].([]byte)
+		},
+	).Return(nil).Once()
+
+	// Act
+	count, rewrapErr := rotatedCipher.RewrapDataKeys()
+
+	// Assert: KEK lama dapat dihapus dari konfigurasi dan data tetap terbaca.
+	assert.NoError(t, rewrapErr)
+	assert.Equal(t, 1, count)
+	rotatedRepo.AssertExpectations(t)
+
+	newRepo := new(MockPIIDataKeyRepository)
+	newKeys := newTestKeyProvider(t, "", map[string]string{"2026-10": testPIIKey(2)})
+	newCipher := pii.NewCipher(newKeys, newRepo, testBlindIndexKey)
+	newRepo.On("FindByID", uint(1)).Return(&rewrapped, nil).Once()
+	plaintext, err := newCipher.Decrypt(testNikLocation, ciphertext)
+	assert.NoError(t, err)
+	assert.Equal(t, "3271011505900001", string(plaintext))
+
+	newRepo.On("FindAll").Return([]*domain.PIIDataKey{&rewrapped}, nil).Once()
+	count, err = newCipher.RewrapDataKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count, "DEK yang sudah dibungkus KEK aktif tidak dibungkus ulang")
+}
+
+func TestPIISerializer_ConsumerColumns(t *testing.T) {
+	// Arrange
+	repo := new(MockPIIDataKeyRepository)
+	keys := newTestKeyProvider(t, "", map[string]string{"2026-01": testPIIKey(1)})
+	cipher := pii.NewCipher(keys, repo, testBlindIndexKey)
+	repo.On("FindActive").Return(nil, gorm.ErrRecordNotFound).Once()
+	expectNewDataKey(repo, 1)
+	pii.Use(cipher)
+	t.Cleanup(func() { pii.Use(nil) })
+
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	encryptedName, err := cipher.Encrypt(testFullNameLocation, []byte("Budi Santoso"))
+	assert.NoError(t, err)
+	encryptedGaji, err := cipher.Encrypt(testGajiLocation, []byte("8000000.00"))
+	assert.NoError(t, err)
+	legacyDob := time.Date(1990, 5, 15, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "nik", "full_name", "tanggal_lahir", "gaji", "tempat_lahir"}).
+		AddRow(1, "3271011505900001", encryptedName, legacyDob, encryptedGaji, "Bandung")
+	mockSQL.ExpectQuery(`SELECT \* FROM "consumers"`).WillReturnRows(rows)
+
+	// Act
+	var consumer domain.Consumer
+	findErr := db.First(&consumer, 1).Error
+	updates, encryptErr := pii.EncryptUpdates(
+		db, &domain.Consumer{ID: 1}, map[string]interface{}{
+			"full_name":    "Budi Santoso Putra",
+			"gaji":         domain.NewMoney(9000000),
+			"tempat_lahir": "Jakarta",
+		},
+	)
+
+	// Assert: kolom terenkripsi dan data lama yang masih plaintext sama-sama terbaca.
+	assert.NoError(t, findErr)
+	assert.Equal(t, "3271011505900001", consumer.Nik)
+	assert.Equal(t, "Budi Santoso", consumer.FullName)
+	assert.Equal(t, "1990-05-15", time.Time(*consumer.TanggalLahir).Format("2006-01-02"))
+	assert.Equal(t, domain.NewMoney(8000000), consumer.Gaji)
+	assert.Equal(t, "Bandung", consumer.TempatLahir)
+
+	assert.NoError(t, encryptErr)
+	assert.Equal(t, "Jakarta", updates["tempat_lahir"])
+	name, err := cipher.Decrypt(testFullNameLocation, updates["full_name"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "Budi Santoso Putra", string(name))
+	gaji, err := cipher.Decrypt(testGajiLocation, updates["gaji"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(9000000).String(), string(gaji))
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+// ciphertextRecorder mencatat argumen query yang berupa ciphertext.
+type ciphertextRecorder struct {
+	ciphertexts []string
+}
+
+func (r *ciphertextRecorder) ConvertValue(v interface{}) (driver.Value, error) {
+	value, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if ciphertext, ok := value.(string); ok && pii.IsEncrypted(ciphertext) {
+		r.ciphertexts = append(r.ciphertexts, ciphertext)
+	}
+	return value, err
+}
+
+func TestPIISerializer_AssignsRowIDBeforeInsert(t *testing.T) {
+	// Arrange
+	repo := new(MockPIIDataKeyRepository)
+	keys := newTestKeyProvider(t, "", map[string]string{"2026-01": testPIIKey(1)})
+	cipher := pii.NewCipher(keys, repo, testBlindIndexKey)
+	repo.On("FindActive").Return(nil, gorm.ErrRecordNotFound).Once()
+	expectNewDataKey(repo, 1)
+	pii.Use(cipher)
+	t.Cleanup(func() { pii.Use(nil) })
+
+	recorder := &ciphertextRecorder{}
+	sqlDB, mockSQL, err := sqlmock.New(sqlmock.ValueConverterOption(recorder))
+	assert.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, pii.RegisterCallbacks(db))
+
+	mockSQL.ExpectBegin()
+	mockSQL.ExpectQuery(`SELECT nextval\(pg_get_serial_sequence\(\$1, \$2\)\)`).WithArgs("consumers", "id").
+		WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(7))
+	mockSQL.ExpectQuery(`INSERT INTO "consumers"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mockSQL.ExpectCommit()
+
+	// Act
+	consumer := &domain.Consumer{UserID: 3, Nik: "3271011505900001", FullName: "Budi Santoso"}
+	createErr := db.Create(consumer).Error
+
+	// Assert: ciphertext terikat pada ID baris yang diambil dari sequence sebelum INSERT.
+	assert.NoError(t, createErr)
+	assert.Equal(t, uint(7), consumer.ID)
+	assert.Len(t, recorder.ciphertexts, 4, "nik, full_name, legal_name, dan gaji")
+	nik, err := cipher.Decrypt(pii.Location{Table: "consumers", Column: "nik", RowID: 7}, recorder.ciphertexts[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "3271011505900001", string(nik))
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestPIIUsecase_ReencryptConsumers(t *testing.T) {
+	// Arrange
+	keyManager := new(MockPIIKeyManager)
+	consumerRepo := new(MockConsumerRepository)
+	usecase := NewPIIUsecase(keyManager, consumerRepo)
+
+	firstBatch := make([]*domain.Consumer, piiReencryptBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = &domain.Consumer{ID: uint(i + 1)}
+	}
+	lastConsumer := &domain.Consumer{ID: uint(piiReencryptBatchSize + 1)}
+
+	keyManager.On("RewrapDataKeys").Return(1, nil).Once()
+	consumerRepo.On("FindAfterID", uint(0), piiReencryptBatchSize).Return(firstBatch, nil).Once()
+	consumerRepo.On("FindAfterID", uint(piiReencryptBatchSize), piiReencryptBatchSize).
+		Return([]*domain.Consumer{lastConsumer}, nil).Once()
+	consumerRepo.On("UpdatePII", firstBatch[49]).Return(errors.New("db down")).Once()
+	consumerRepo.On("UpdatePII", mock.Anything).Return(nil)
+
+	// Act
+	output, err := usecase.ReencryptConsumers()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.DataKeyDibungkusUlang)
+	assert.Equal(t, piiReencryptBatchSize, output.KonsumenDienkripsi)
+	assert.Equal(t, []string{"consumer 50: db down"}, output.Errors)
+	consumerRepo.AssertNumberOfCalls(t, "UpdatePII", piiReencryptBatchSize+1)
+	keyManager.AssertExpectations(t)
+}
+
+func TestPIIUsecase_ReencryptConsumers_StopsWhenRewrapFails(t *testing.T) {
+	// Arrange
+	keyManager := new(MockPIIKeyManager)
+	consumerRepo := new(MockConsumerRepository)
+	usecase := NewPIIUsecase(keyManager, consumerRepo)
+	keyManager.On("RewrapDataKeys").Return(0, errors.New(`key encryption key "2026-01" is not configured`)).Once()
+
+	// Act
+	output, err := usecase.ReencryptConsumers()
+
+	// Assert
+	assert.Nil(t, output)
+	assert.EqualError(t, err, `could not rewrap PII data keys: key encryption key "2026-01" is not configured`)
+	consumerRepo.AssertNotCalled(t, "FindAfterID", mock.Anything, mock.Anything)
+}
+
+func TestPIILoadConfig(t *testing.T) {
+	env := map[string]string{
+		"PII_KEKS":            "2026-01:" + testPIIKey(1) + ",2026-10:" + testPIIKey(2),
+		"PII_ACTIVE_KEK":      "2026-10",
+		"PII_BLIND_INDEX_KEY": testPIIKey(3),
+	}
+	cfg, err := pii.LoadConfig(func(key string) string { return env[key] })
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10", cfg.Keys.ActiveKeyID())
+
+	env["PII_ACTIVE_KEK"] = ""
+	_, err = pii.LoadConfig(func(key string) string { return env[key] })
+	assert.Error(t, err, "KEK aktif wajib dipilih jika ada lebih dari satu KEK")
+
+	env["PII_ACTIVE_KEK"] = "2026-10"
+	env["PII_BLIND_INDEX_KEY"] = base64.StdEncoding.EncodeToString([]byte("pendek"))
+	_, err = pii.LoadConfig(func(key string) string { return env[key] })
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PricingPolicy menentukan cara bunga dihitung untuk sebuah tenor/produk.
+// BiayaPelunasanDipercepat adalah persentase dari sisa pokok yang dikenakan saat kontrak dilunasi lebih awal.
+// DendaHarian adalah persentase tagihan angsuran per hari keterlambatan, dibatasi MaksimalDenda
+// (persentase dari tagihan angsuran).
+type PricingPolicy struct {
+	MetodeBunga              string
+	SukuBungaTahunan         float64
+	BiayaPelunasanDipercepat float64
+	DendaHarian              float64
+	MaksimalDenda            float64
+}
+
+// PricingPolicyResolver memilih PricingPolicy yang berlaku untuk sebuah pengajuan pembiayaan.
+type PricingPolicyResolver interface {
+	Resolve(jenisAsset string, tenorMonths int) (PricingPolicy, error)
+}
+
+// TenorPricingTable adalah PricingPolicyResolver sederhana yang menentukan kebijakan berdasarkan tenor.
+type TenorPricingTable map[int]PricingPolicy
+
+// DefaultTenorPricing dipakai jika INTEREST_PRICING tidak dikonfigurasi.
+var DefaultTenorPricing = TenorPricingTable{
+	1: {MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24, DendaHarian: 0.001, MaksimalDenda: 0.5},
+	2: {MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24, DendaHarian: 0.001, MaksimalDenda: 0.5},
+	3: {MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24, DendaHarian: 0.001, MaksimalDenda: 0.5},
+	6: {MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24, DendaHarian: 0.001, MaksimalDenda: 0.5},
+}
+
+func (t TenorPricingTable) Resolve(_ string, tenorMonths int) (PricingPolicy, error) {
+	policy, ok := t[tenorMonths]
+	if !ok {
+		return PricingPolicy{}, fmt.Errorf("no pricing policy configured for tenor %d", tenorMonths)
+	}
+	return policy, nil
+}
+
+// ParseTenorPricingTable mengurai konfigurasi seperti "1:FLAT:0.24,3:ANUITAS:0.26,6:EFEKTIF:0.28:0.03:0.001:0.5"
+// (tenor:metode:suku bunga tahunan[:biaya pelunasan dipercepat[:denda harian:maksimal denda]]).
+// Komponen opsional yang tidak diisi bernilai nol. String kosong menghasilkan DefaultTenorPricing.
+func ParseTenorPricingTable(raw string) (TenorPricingTable, error) {
+	if strings.TrimSpace(raw) == "" {
+		return DefaultTenorPricing, nil
+	}
+
+	table := make(TenorPricingTable)
+	for _, entry := range strings.Split(raw, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 && len(parts) != 4 && len(parts) != 6 {
+			return nil, fmt.Errorf(
+				"invalid pricing entry %q, expected tenor:metode:rate[:payoff_fee[:daily_late_fee:max_late_fee]]",
+				entry,
+			)
+		}
+
+		tenor, err := strconv.Atoi(parts[0])
+		if err != nil || tenor <= 0 {
+			return nil, fmt.Errorf("invalid tenor in pricing entry %q", entry)
+		}
+		calculator, err := NewInterestCalculator(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		rate, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate in pricing entry %q", entry)
+		}
+
+		// Komponen opsional: biaya pelunasan, denda harian, dan maksimal denda.
+		optional := make([]float64, 3)
+		for i, raw := range parts[3:] {
+			optional[i], err = strconv.ParseFloat(raw, 64)
+			if err != nil || optional[i] < 0 {
+				return nil, fmt.Errorf("invalid fee in pricing entry %q", entry)
+			}
+		}
+
+		table[tenor] = PricingPolicy{
+			MetodeBunga:              calculator.Metode(),
+			SukuBungaTahunan:         rate,
+			BiayaPelunasanDipercepat: optional[0],
+			DendaHarian:              optional[1],
+			MaksimalDenda:            optional[2],
+		}
+	}
+	return table, nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+// ProductResolver menentukan produk pembiayaan untuk sebuah jenis aset dan tenor yang boleh dipakai
+// untuk limit kredit, selain kebijakan harga per tenor.
+type ProductResolver interface {
+	PricingPolicyResolver
+	ResolveProduct(jenisAsset string) (*domain.Product, error)
+	OfferedTenors() ([]int, error)
+}
+
+// ResolveProduct membentuk produk tanpa aturan uang muka, pembiayaan maksimal, maupun biaya admin dari tabel
+// harga per tenor, sehingga TenorPricingTable tetap dapat dipakai tanpa katalog produk di database.
+func (t TenorPricingTable) ResolveProduct(jenisAsset string) (*domain.Product, error) {
+	product := &domain.Product{
+		Kode:  domain.NormalizeProductCode(jenisAsset),
+		Nama:  jenisAsset,
+		Aktif: true,
+	}
+	tenors, _ := t.OfferedTenors()
+	for _, tenor := range tenors {
+		policy := t[tenor]
+		product.Tenors = append(
+			product.Tenors, domain.ProductTenor{
+				TenorBulan:               tenor,
+				MetodeBunga:              policy.MetodeBunga,
+				SukuBungaTahunan:         policy.SukuBungaTahunan,
+				BiayaPelunasanDipercepat: policy.BiayaPelunasanDipercepat,
+				DendaHarian:              policy.DendaHarian,
+				MaksimalDenda:            policy.MaksimalDenda,
+			},
+		)
+	}
+	return product, nil
+}
+
+// OfferedTenors mengembalikan seluruh tenor pada tabel, terurut dari yang terpendek.
+func (t TenorPricingTable) OfferedTenors() ([]int, error) {
+	tenors := make([]int, 0, len(t))
+	for tenor := range t {
+		tenors = append(tenors, tenor)
+	}
+	sort.Ints(tenors)
+	return tenors, nil
+}
+
+// pricingPolicyFromTenor mengubah konfigurasi tenor produk menjadi PricingPolicy.
+func pricingPolicyFromTenor(tenor *domain.ProductTenor) PricingPolicy {
+	return PricingPolicy{
+		MetodeBunga:              tenor.MetodeBunga,
+		SukuBungaTahunan:         tenor.SukuBungaTahunan,
+		BiayaPelunasanDipercepat: tenor.BiayaPelunasanDipercepat,
+		DendaHarian:              tenor.DendaHarian,
+		MaksimalDenda:            tenor.MaksimalDenda,
+	}
+}
+
+// productCatalog adalah ProductResolver yang membaca katalog produk dari database.
+type productCatalog struct {
+	productRepo domain.ProductRepository
+	fallback    PricingPolicyResolver
+}
+
+// NewProductCatalog membuat ProductResolver berbasis tabel products. fallback dipakai untuk menentukan
+// kebijakan harga kontrak lama yang jenis asetnya tidak terdaftar sebagai produk (misalnya untuk
+// pelunasan dan denda); pengajuan baru selalu wajib memakai produk yang terdaftar dan aktif.
+func NewProductCatalog(productRepo domain.ProductRepository, fallback PricingPolicyResolver) ProductResolver {
+	return &productCatalog{productRepo: productRepo, fallback: fallback}
+}
+
+func (c *productCatalog) ResolveProduct(jenisAsset string) (*domain.Product, error) {
+	product, err := c.productRepo.FindByKode(domain.NormalizeProductCode(jenisAsset))
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !product.Aktif) {
+		return nil, newRuleViolation(RuleProductNotAvailable, "jenis_asset", "product %q is not available", jenisAsset)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (c *productCatalog) Resolve(jenisAsset string, tenorMonths int) (PricingPolicy, error) {
+	product, err := c.productRepo.FindByKode(domain.NormalizeProductCode(jenisAsset))
+	if errors.Is(err, gorm.ErrRecordNotFound) && c.fallback != nil {
+		return c.fallback.Resolve(jenisAsset, tenorMonths)
+	}
+	if err != nil {
+		return PricingPolicy{}, err
+	}
+
+	tenor, ok := product.Tenor(tenorMonths)
+	if !ok {
+		return PricingPolicy{}, fmt.Errorf("tenor %d months is not offered for product %s", tenorMonths, product.Kode)
+	}
+	return pricingPolicyFromTenor(tenor), nil
+}
+
+// OfferedTenors mengembalikan gabungan tenor dari seluruh produk aktif.
+func (c *productCatalog) OfferedTenors() ([]int, error) {
+	products, err := c.productRepo.FindAllActive()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var tenors []int
+	for _, product := range products {
+		for _, tenor := range product.Tenors {
+			if !seen[tenor.TenorBulan] {
+				seen[tenor.TenorBulan] = true
+				tenors = append(tenors, tenor.TenorBulan)
+			}
+		}
+	}
+	sort.Ints(tenors)
+	return tenors, nil
+}
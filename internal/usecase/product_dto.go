@@ -0,0 +1,46 @@
+package usecase
+
+import "github.com/adty404/kredit-plus/internal/domain"
+
+// ProductTenorInput adalah konfigurasi satu tenor produk. Persentase ditulis sebagai pecahan (0.24 = 24%).
+type ProductTenorInput struct {
+	TenorBulan               int     `json:"tenor_bulan" binding:"required,gt=0"`
+	MetodeBunga              string  `json:"metode_bunga" binding:"required"`
+	SukuBungaTahunan         float64 `json:"suku_bunga_tahunan" binding:"gte=0,lte=1"`
+	BiayaPelunasanDipercepat float64 `json:"biaya_pelunasan_dipercepat" binding:"gte=0,lte=1"`
+	DendaHarian              float64 `json:"denda_harian" binding:"gte=0,lte=1"`
+	MaksimalDenda            float64 `json:"maksimal_denda" binding:"gte=0,lte=1"`
+}
+
+type CreateProductInput struct {
+	Kode                  string              `json:"kode" binding:"required,max=50"`
+	Nama                  string              `json:"nama" binding:"required,min=2,max=100"`
+	MinimalUangMukaPersen float64             `json:"minimal_uang_muka_persen" binding:"gte=0,lt=1"`
+	MaksimalPembiayaan    domain.Money        `json:"maksimal_pembiayaan" binding:"gte=0"`
+	MaksimalLTV           float64             `json:"maksimal_ltv" binding:"gte=0,lte=1"`
+	MinimalOtr            domain.Money        `json:"minimal_otr" binding:"gte=0"`
+	MaksimalOtr           domain.Money        `json:"maksimal_otr" binding:"gte=0"`
+	BiayaAdminTetap       domain.Money        `json:"biaya_admin_tetap" binding:"gte=0"`
+	BiayaAdminPersen      float64             `json:"biaya_admin_persen" binding:"gte=0,lte=1"`
+	MinimalBiayaAdmin     domain.Money        `json:"minimal_biaya_admin" binding:"gte=0"`
+	MaksimalBiayaAdmin    domain.Money        `json:"maksimal_biaya_admin" binding:"gte=0"`
+	Aktif                 *bool               `json:"aktif"`
+	Tenors                []ProductTenorInput `json:"tenors" binding:"required,min=1,dive"`
+}
+
+// UpdateProductInput mengganti seluruh konfigurasi produk, termasuk daftar tenornya. Kode produk tidak dapat diubah
+// karena sudah tercatat sebagai JenisAsset pada kontrak yang ada.
+type UpdateProductInput struct {
+	Nama                  string              `json:"nama" binding:"required,min=2,max=100"`
+	MinimalUangMukaPersen float64             `json:"minimal_uang_muka_persen" binding:"gte=0,lt=1"`
+	MaksimalPembiayaan    domain.Money        `json:"maksimal_pembiayaan" binding:"gte=0"`
+	MaksimalLTV           float64             `json:"maksimal_ltv" binding:"gte=0,lte=1"`
+	MinimalOtr            domain.Money        `json:"minimal_otr" binding:"gte=0"`
+	MaksimalOtr           domain.Money        `json:"maksimal_otr" binding:"gte=0"`
+	BiayaAdminTetap       domain.Money        `json:"biaya_admin_tetap" binding:"gte=0"`
+	BiayaAdminPersen      float64             `json:"biaya_admin_persen" binding:"gte=0,lte=1"`
+	MinimalBiayaAdmin     domain.Money        `json:"minimal_biaya_admin" binding:"gte=0"`
+	MaksimalBiayaAdmin    domain.Money        `json:"maksimal_biaya_admin" binding:"gte=0"`
+	Aktif                 bool                `json:"aktif"`
+	Tenors                []ProductTenorInput `json:"tenors" binding:"required,min=1,dive"`
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockProductRepository adalah implementasi mock dari domain.ProductRepository.
+type MockProductRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductRepository) WithTx(tx *gorm.DB) domain.ProductRepository {
+	return m
+}
+
+func (m *MockProductRepository) Save(product *domain.Product) error {
+	args := m.Called(product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Update(product *domain.Product) error {
+	args := m.Called(product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) ReplaceTenors(productID uint, tenors []domain.ProductTenor) error {
+	args := m.Called(productID, tenors)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) FindByID(id uint) (*domain.Product, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) FindByKode(kode string) (*domain.Product, error) {
+	args := m.Called(kode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) FindAll() ([]*domain.Product, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) FindAllActive() ([]*domain.Product, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Product), args.Error(1)
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ProductUsecase interface {
+	CreateProduct(input CreateProductInput) (*domain.Product, error)
+	GetAllProducts() ([]*domain.Product, error)
+	GetProductByID(id uint) (*domain.Product, error)
+	UpdateProduct(id uint, input UpdateProductInput) (*domain.Product, error)
+	DeleteProduct(id uint) error
+}
+
+type productUsecase struct {
+	db   *gorm.DB
+	repo domain.ProductRepository
+}
+
+func NewProductUsecase(db *gorm.DB, repo domain.ProductRepository) ProductUsecase {
+	return &productUsecase{
+		db:   db,
+		repo: repo,
+	}
+}
+
+func (uc *productUsecase) CreateProduct(input CreateProductInput) (*domain.Product, error) {
+	kode := domain.NormalizeProductCode(input.Kode)
+	if kode == "" {
+		return nil, fmt.Errorf("product code must not be empty")
+	}
+
+	// Validasi: Kode produk harus unik
+	_, err := uc.repo.FindByKode(kode)
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("product with code %s already exists", kode)
+	}
+
+	tenors, err := buildProductTenors(input.Tenors)
+	if err != nil {
+		return nil, err
+	}
+
+	product := &domain.Product{
+		Kode:                  kode,
+		Nama:                  input.Nama,
+		MinimalUangMukaPersen: input.MinimalUangMukaPersen,
+		MaksimalPembiayaan:    input.MaksimalPembiayaan,
+		MaksimalLTV:           input.MaksimalLTV,
+		MinimalOtr:            input.MinimalOtr,
+		MaksimalOtr:           input.MaksimalOtr,
+		BiayaAdminTetap:       input.BiayaAdminTetap,
+		BiayaAdminPersen:      input.BiayaAdminPersen,
+		MinimalBiayaAdmin:     input.MinimalBiayaAdmin,
+		MaksimalBiayaAdmin:    input.MaksimalBiayaAdmin,
+		Aktif:                 true,
+		Tenors:                tenors,
+	}
+	if input.Aktif != nil {
+		product.Aktif = *input.Aktif
+	}
+	if err := validateProductRules(product); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Save(product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+// GetAllProducts mengambil semua produk beserta tenornya, termasuk produk nonaktif.
+func (uc *productUsecase) GetAllProducts() ([]*domain.Product, error) {
+	return uc.repo.FindAll()
+}
+
+// GetProductByID mengambil satu produk berdasarkan ID.
+func (uc *productUsecase) GetProductByID(id uint) (*domain.Product, error) {
+	return uc.repo.FindByID(id)
+}
+
+// UpdateProduct mengganti konfigurasi produk dan daftar tenornya dalam satu transaksi database.
+// Kontrak yang sudah berjalan tidak terpengaruh karena suku bunga dan metode bunga tersimpan di kontrak.
+func (uc *productUsecase) UpdateProduct(id uint, input UpdateProductInput) (*domain.Product, error) {
+	tenors, err := buildProductTenors(input.Tenors)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProduct *domain.Product
+	err = uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			repoTx := uc.repo.WithTx(tx)
+
+			product, err := repoTx.FindByID(id)
+			if err != nil {
+				return err
+			}
+
+			product.Nama = input.Nama
+			product.MinimalUangMukaPersen = input.MinimalUangMukaPersen
+			product.MaksimalPembiayaan = input.MaksimalPembiayaan
+			product.MaksimalLTV = input.MaksimalLTV
+			product.MinimalOtr = input.MinimalOtr
+			product.MaksimalOtr = input.MaksimalOtr
+			product.BiayaAdminTetap = input.BiayaAdminTetap
+			product.BiayaAdminPersen = input.BiayaAdminPersen
+			product.MinimalBiayaAdmin = input.MinimalBiayaAdmin
+			product.MaksimalBiayaAdmin = input.MaksimalBiayaAdmin
+			product.Aktif = input.Aktif
+			if err := validateProductRules(product); err != nil {
+				return err
+			}
+			if err := repoTx.Update(product); err != nil {
+				return err
+			}
+			if err := repoTx.ReplaceTenors(product.ID, tenors); err != nil {
+				return err
+			}
+
+			product.Tenors = tenors
+			updatedProduct = product
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedProduct, nil
+}
+
+// DeleteProduct menghapus produk beserta tenornya. Kontrak lama dengan jenis aset produk ini tetap memakai
+// kebijakan harga bawaan untuk pelunasan dan denda.
+func (uc *productUsecase) DeleteProduct(id uint) error {
+	return uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			repoTx := uc.repo.WithTx(tx)
+			if _, err := repoTx.FindByID(id); err != nil {
+				return err // Mengembalikan error jika tidak ditemukan
+			}
+			return repoTx.Delete(id)
+		},
+	)
+}
+
+// buildProductTenors memvalidasi daftar tenor produk: tenor tidak boleh ganda, metode bunga harus dikenal,
+// dan maksimal denda wajib diisi jika denda harian dikenakan.
+func buildProductTenors(inputs []ProductTenorInput) ([]domain.ProductTenor, error) {
+	seen := make(map[int]bool, len(inputs))
+	tenors := make([]domain.ProductTenor, 0, len(inputs))
+	for _, input := range inputs {
+		if input.TenorBulan <= 0 {
+			return nil, fmt.Errorf("invalid tenor: %d", input.TenorBulan)
+		}
+		if seen[input.TenorBulan] {
+			return nil, fmt.Errorf("tenor %d months is listed more than once", input.TenorBulan)
+		}
+		seen[input.TenorBulan] = true
+
+		calculator, err := NewInterestCalculator(input.MetodeBunga)
+		if err != nil {
+			return nil, err
+		}
+		if input.DendaHarian > 0 && input.MaksimalDenda <= 0 {
+			return nil, fmt.Errorf("maksimal_denda is required when denda_harian is set for tenor %d", input.TenorBulan)
+		}
+
+		tenors = append(
+			tenors, domain.ProductTenor{
+				TenorBulan:               input.TenorBulan,
+				MetodeBunga:              calculator.Metode(),
+				SukuBungaTahunan:         input.SukuBungaTahunan,
+				BiayaPelunasanDipercepat: input.BiayaPelunasanDipercepat,
+				DendaHarian:              input.DendaHarian,
+				MaksimalDenda:            input.MaksimalDenda,
+			},
+		)
+	}
+	return tenors, nil
+}
+
+// validateProductRules memastikan rentang OTR dan biaya admin produk konsisten. Batas bernilai nol berarti
+// tanpa batas sehingga hanya diperiksa jika kedua sisi diisi.
+func validateProductRules(product *domain.Product) error {
+	if product.MaksimalOtr.IsPositive() && product.MinimalOtr.GreaterThan(product.MaksimalOtr) {
+		return fmt.Errorf("minimal_otr (%s) cannot exceed maksimal_otr (%s)", product.MinimalOtr, product.MaksimalOtr)
+	}
+	if product.MaksimalBiayaAdmin.IsPositive() && product.MinimalBiayaAdmin.GreaterThan(product.MaksimalBiayaAdmin) {
+		return fmt.Errorf(
+			"minimal_biaya_admin (%s) cannot exceed maksimal_biaya_admin (%s)",
+			product.MinimalBiayaAdmin,
+			product.MaksimalBiayaAdmin,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupProductUsecase(t *testing.T) (ProductUsecase, sqlmock.Sqlmock, *MockProductRepository) {
+	sqlDB, mockSQL, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	mockProductRepo := new(MockProductRepository)
+	return NewProductUsecase(gormDB, mockProductRepo), mockSQL, mockProductRepo
+}
+
+// newTestProduct membuat produk MOTOR dengan uang muka minimal 20%, pembiayaan maksimal 4 juta,
+// biaya admin tetap 100 ribu, dan tenor 3 serta 6 bulan.
+func newTestProduct() *domain.Product {
+	return &domain.Product{
+		ID:                    1,
+		Kode:                  "MOTOR",
+		Nama:                  "Motor",
+		MinimalUangMukaPersen: 0.2,
+		MaksimalPembiayaan:    domain.NewMoney(4000000),
+		BiayaAdminTetap:       domain.NewMoney(100000),
+		Aktif:                 true,
+		Tenors: []domain.ProductTenor{
+			{TenorBulan: 3, MetodeBunga: MetodeBungaFlat, SukuBungaTahunan: 0.24},
+			{TenorBulan: 6, MetodeBunga: MetodeBungaAnuitas, SukuBungaTahunan: 0.3},
+		},
+	}
+}
+
+func TestCreateProduct_Success(t *testing.T) {
+	// Arrange
+	usecase, _, mockProductRepo := setupProductUsecase(t)
+	input := CreateProductInput{
+		Kode:                  "white goods",
+		Nama:                  "White Goods",
+		MinimalUangMukaPersen: 0.1,
+		Tenors: []ProductTenorInput{
+			{TenorBulan: 3, MetodeBunga: "flat", SukuBungaTahunan: 0.24},
+			{TenorBulan: 6, MetodeBunga: "anuitas", SukuBungaTahunan: 0.26},
+		},
+	}
+
+	mockProductRepo.On("FindByKode", "WHITE_GOODS").Return(nil, gorm.ErrRecordNotFound).Once()
+	mockProductRepo.On("Save", mock.AnythingOfType("*domain.Product")).Return(nil).Once()
+
+	// Act
+	product, err := usecase.CreateProduct(input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "WHITE_GOODS", product.Kode)
+	assert.True(t, product.Aktif)
+	assert.Len(t, product.Tenors, 2)
+	assert.Equal(t, MetodeBungaAnuitas, product.Tenors[1].MetodeBunga)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestCreateProduct_Validation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		tenors  []ProductTenorInput
+		errText string
+	}{
+		{
+			name: "tenor ganda",
+			tenors: []ProductTenorInput{
+				{TenorBulan: 3, MetodeBunga: "FLAT"},
+				{TenorBulan: 3, MetodeBunga: "ANUITAS"},
+			},
+			errText: "tenor 3 months is listed more than once",
+		},
+		{
+			name:    "metode bunga tidak dikenal",
+			tenors:  []ProductTenorInput{{TenorBulan: 3, MetodeBunga: "BALON"}},
+			errText: "unknown interest method: BALON",
+		},
+		{
+			name:    "denda tanpa batas maksimal",
+			tenors:  []ProductTenorInput{{TenorBulan: 3, MetodeBunga: "FLAT", DendaHarian: 0.001}},
+			errText: "maksimal_denda is required when denda_harian is set for tenor 3",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				usecase, _, mockProductRepo := setupProductUsecase(t)
+				mockProductRepo.On("FindByKode", "MOTOR").Return(nil, gorm.ErrRecordNotFound).Once()
+
+				// Act
+				product, err := usecase.CreateProduct(CreateProductInput{Kode: "Motor", Nama: "Motor", Tenors: tc.tenors})
+
+				// Assert
+				assert.Nil(t, product)
+				assert.EqualError(t, err, tc.errText)
+				mockProductRepo.AssertNotCalled(t, "Save", mock.Anything)
+			},
+		)
+	}
+}
+
+func TestCreateProduct_InvalidRuleRange(t *testing.T) {
+	// Arrange
+	usecase, _, mockProductRepo := setupProductUsecase(t)
+	mockProductRepo.On("FindByKode", "MOTOR").Return(nil, gorm.ErrRecordNotFound).Once()
+	input := CreateProductInput{
+		Kode:        "Motor",
+		Nama:        "Motor",
+		MinimalOtr:  domain.NewMoney(80000000),
+		MaksimalOtr: domain.NewMoney(75000000),
+		Tenors:      []ProductTenorInput{{TenorBulan: 3, MetodeBunga: "FLAT"}},
+	}
+
+	// Act
+	product, err := usecase.CreateProduct(input)
+
+	// Assert
+	assert.Nil(t, product)
+	assert.EqualError(t, err, "minimal_otr (80000000.00) cannot exceed maksimal_otr (75000000.00)")
+	mockProductRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCreateProduct_DuplicateCode(t *testing.T) {
+	// Arrange
+	usecase, _, mockProductRepo := setupProductUsecase(t)
+	mockProductRepo.On("FindByKode", "MOTOR").Return(newTestProduct(), nil).Once()
+
+	// Act
+	product, err := usecase.CreateProduct(
+		CreateProductInput{
+			Kode:   "motor",
+			Nama:   "Motor",
+			Tenors: []ProductTenorInput{{TenorBulan: 3, MetodeBunga: "FLAT"}},
+		},
+	)
+
+	// Assert
+	assert.Nil(t, product)
+	assert.EqualError(t, err, "product with code MOTOR already exists")
+	mockProductRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestUpdateProduct_ReplacesTenors(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockProductRepo := setupProductUsecase(t)
+	input := UpdateProductInput{
+		Nama:                  "Motor Baru",
+		MinimalUangMukaPersen: 0.25,
+		Aktif:                 false,
+		Tenors:                []ProductTenorInput{{TenorBulan: 12, MetodeBunga: "EFEKTIF", SukuBungaTahunan: 0.28}},
+	}
+
+	mockSQL.ExpectBegin()
+	mockProductRepo.On("FindByID", uint(1)).Return(newTestProduct(), nil).Once()
+	mockProductRepo.On("Update", mock.AnythingOfType("*domain.Product")).Return(nil).Once()
+	mockProductRepo.On(
+		"ReplaceTenors", uint(1), mock.MatchedBy(
+			func(tenors []domain.ProductTenor) bool {
+				return len(tenors) == 1 && tenors[0].TenorBulan == 12 && tenors[0].MetodeBunga == MetodeBungaEfektif
+			},
+		),
+	).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	product, err := usecase.UpdateProduct(1, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Motor Baru", product.Nama)
+	assert.False(t, product.Aktif)
+	assert.Len(t, product.Tenors, 1)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestProductCatalog_ResolveAndOfferedTenors(t *testing.T) {
+	// Arrange
+	mockProductRepo := new(MockProductRepository)
+	catalog := NewProductCatalog(mockProductRepo, DefaultTenorPricing)
+
+	elektronik := &domain.Product{
+		Kode:   "ELEKTRONIK",
+		Aktif:  true,
+		Tenors: []domain.ProductTenor{{TenorBulan: 1}, {TenorBulan: 12}},
+	}
+	nonaktif := &domain.Product{Kode: "GADGET", Aktif: false}
+
+	mockProductRepo.On("FindByKode", "MOTOR").Return(newTestProduct(), nil)
+	mockProductRepo.On("FindByKode", "GADGET").Return(nonaktif, nil)
+	mockProductRepo.On("FindByKode", "KAPAL").Return(nil, gorm.ErrRecordNotFound)
+	mockProductRepo.On("FindAllActive").Return([]*domain.Product{newTestProduct(), elektronik}, nil)
+
+	// Act & Assert: kebijakan harga diambil dari tenor produk
+	policy, err := catalog.Resolve("motor", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, MetodeBungaAnuitas, policy.MetodeBunga)
+	assert.Equal(t, 0.3, policy.SukuBungaTahunan)
+
+	// Tenor yang tidak ditawarkan produk ditolak
+	_, err = catalog.Resolve("MOTOR", 1)
+	assert.EqualError(t, err, "tenor 1 months is not offered for product MOTOR")
+
+	// Kontrak lama dengan jenis aset yang tidak terdaftar memakai kebijakan bawaan
+	policy, err = catalog.Resolve("KAPAL", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultTenorPricing[3], policy)
+
+	// Pengajuan baru wajib memakai produk yang terdaftar dan aktif
+	_, err = catalog.ResolveProduct("KAPAL")
+	assert.EqualError(t, err, `product "KAPAL" is not available`)
+	_, err = catalog.ResolveProduct("GADGET")
+	assert.EqualError(t, err, `product "GADGET" is not available`)
+
+	tenors, err := catalog.OfferedTenors()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 6, 12}, tenors)
+}
+
+func TestDeleteProduct_NotFound(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockProductRepo := setupProductUsecase(t)
+	mockSQL.ExpectBegin()
+	mockProductRepo.On("FindByID", uint(9)).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	err := usecase.DeleteProduct(9)
+
+	// Assert
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+	mockProductRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kode pelanggaran aturan pembiayaan yang dikembalikan ke klien bersama pesan error.
+const (
+	RuleProductNotAvailable      = "PRODUCT_NOT_AVAILABLE"
+	RuleTenorNotOffered          = "TENOR_NOT_OFFERED"
+	RuleOtrBelowMinimum          = "OTR_BELOW_MINIMUM"
+	RuleOtrAboveMaximum          = "OTR_ABOVE_MAXIMUM"
+	RuleDownPaymentNotBelowOtr   = "DOWN_PAYMENT_NOT_BELOW_OTR"
+	RuleDownPaymentBelowMinimum  = "DOWN_PAYMENT_BELOW_MINIMUM"
+	RuleAdminFeeMismatch         = "ADMIN_FEE_MISMATCH"
+	RuleAdminFeeOutOfRange       = "ADMIN_FEE_OUT_OF_RANGE"
+	RuleLtvAboveMaximum          = "LTV_ABOVE_MAXIMUM"
+	RuleFinancingAboveMaximum    = "FINANCING_ABOVE_MAXIMUM"
+	RuleTenorLimitNotFound       = "TENOR_LIMIT_NOT_FOUND"
+	RuleTenorLimitExceeded       = "TENOR_LIMIT_EXCEEDED"
+	RuleTenorLimitInsufficient   = "TENOR_LIMIT_INSUFFICIENT"
+	RuleOverallLimitInsufficient = "OVERALL_LIMIT_INSUFFICIENT"
+	RuleConsumerFrozen           = "CONSUMER_FROZEN"
+	RuleTenorLimitFrozen         = "TENOR_LIMIT_FROZEN"
+	RuleOverallLimitExpired      = "OVERALL_LIMIT_EXPIRED"
+	RuleTenorLimitExpired        = "TENOR_LIMIT_EXPIRED"
+	RuleKYCNotApproved           = "KYC_NOT_APPROVED"
+	RuleIdempotencyKeyReused     = "IDEMPOTENCY_KEY_REUSED"
+	RulePaymentDateInFuture      = "PAYMENT_DATE_IN_FUTURE"
+	RulePaymentDateBeforeKontrak = "PAYMENT_DATE_BEFORE_CONTRACT"
+)
+
+// ErrCreditFrozen dibungkus oleh pelanggaran CONSUMER_FROZEN dan TENOR_LIMIT_FROZEN agar pemanggil dapat
+// membedakan kredit yang dibekukan dari penolakan aturan lainnya dengan errors.Is.
+var ErrCreditFrozen = errors.New("credit is frozen")
+
+// ErrKYCNotApproved dibungkus oleh pelanggaran KYC_NOT_APPROVED untuk konsumen yang identitasnya belum diverifikasi.
+var ErrKYCNotApproved = errors.New("consumer KYC is not approved")
+
+// RuleViolationError adalah penolakan pengajuan karena melanggar aturan bisnis. Code stabil untuk dipakai
+// klien, Field menunjuk field request yang melanggar (boleh kosong), dan Message adalah penjelasan untuk manusia.
+type RuleViolationError struct {
+	Code    string
+	Field   string
+	Message string
+
+	cause error
+}
+
+func (e *RuleViolationError) Error() string {
+	return e.Message
+}
+
+func (e *RuleViolationError) Unwrap() error {
+	return e.cause
+}
+
+// newRuleViolation membuat RuleViolationError dengan pesan berformat.
+func newRuleViolation(code, field, format string, args ...interface{}) *RuleViolationError {
+	return &RuleViolationError{Code: code, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// AsRuleViolation mengembalikan RuleViolationError di dalam err, atau nil jika err bukan pelanggaran aturan.
+func AsRuleViolation(err error) *RuleViolationError {
+	var violation *RuleViolationError
+	if errors.As(err, &violation) {
+		return violation
+	}
+	return nil
+}
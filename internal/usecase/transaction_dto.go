@@ -1,11 +1,60 @@
 package usecase
 
+import "github.com/adty404/kredit-plus/internal/domain"
+
 type CreateTransactionInput struct {
-	TenorMonths     int     `json:"tenor_months" binding:"required,gt=0"`
-	Otr             float64 `json:"otr" binding:"required,gt=0"`
-	AdminFee        float64 `json:"admin_fee" binding:"gte=0"`
-	UangMuka        float64 `json:"uang_muka" binding:"gte=0"`
-	NamaAsset       string  `json:"nama_asset" binding:"required"`
-	JenisAsset      string  `json:"jenis_asset" binding:"required"`
-	SumberTransaksi string  `json:"sumber_transaksi" binding:"required"` // <-- Field baru ditambahkan
+	TenorMonths     int          `json:"tenor_months" binding:"required,gt=0"`
+	Otr             domain.Money `json:"otr" binding:"required,gt=0"`
+	AdminFee        domain.Money `json:"admin_fee" binding:"gte=0"`
+	UangMuka        domain.Money `json:"uang_muka" binding:"gte=0"`
+	NamaAsset       string       `json:"nama_asset" binding:"required"`
+	JenisAsset      string       `json:"jenis_asset" binding:"required"`
+	SumberTransaksi string       `json:"sumber_transaksi" binding:"required"` // <-- Field baru ditambahkan
+
+	// IdempotencyKey diisi dari header Idempotency-Key, bukan dari body.
+	IdempotencyKey string `json:"-"`
+}
+
+type TransactionScheduleOutput struct {
+	Transaction  *domain.Transaction   `json:"transaction"`
+	Installments []*domain.Installment `json:"installments"`
+}
+
+type SimulateTransactionInput struct {
+	Otr        domain.Money `json:"otr" binding:"required,gt=0"`
+	AdminFee   domain.Money `json:"admin_fee" binding:"gte=0"`
+	UangMuka   domain.Money `json:"uang_muka" binding:"gte=0"`
+	JenisAsset string       `json:"jenis_asset" binding:"required"`
+}
+
+// TransactionQuote adalah penawaran pembiayaan untuk satu tenor. Jika Eligible bernilai false,
+// RejectionReason menjelaskan validasi yang gagal saat transaksi benar-benar dibuat dan RejectionCode
+// berisi kode pelanggaran aturannya.
+type TransactionQuote struct {
+	TenorMonths                int          `json:"tenor_months"`
+	Eligible                   bool         `json:"eligible"`
+	RejectionCode              string       `json:"rejection_code,omitempty"`
+	RejectionReason            string       `json:"rejection_reason,omitempty"`
+	PokokPembiayaan            domain.Money `json:"pokok_pembiayaan"`
+	MetodeBunga                string       `json:"metode_bunga,omitempty"`
+	SukuBungaTahunan           float64      `json:"suku_bunga_tahunan"`
+	NilaiCicilanPerPeriode     domain.Money `json:"nilai_cicilan_per_periode"`
+	TotalBunga                 domain.Money `json:"total_bunga"`
+	TotalKewajibanPembayaran   domain.Money `json:"total_kewajiban_pembayaran"`
+	SisaLimitTenor             domain.Money `json:"sisa_limit_tenor"`
+	SisaPlafonSetelahTransaksi domain.Money `json:"sisa_plafon_setelah_transaksi"`
+}
+
+type TransactionSimulationOutput struct {
+	ConsumerID uint               `json:"consumer_id"`
+	Otr        domain.Money       `json:"otr"`
+	UangMuka   domain.Money       `json:"uang_muka"`
+	AdminFee   domain.Money       `json:"admin_fee"`
+	SisaPlafon domain.Money       `json:"sisa_plafon"`
+	Quotes     []TransactionQuote `json:"quotes"`
+}
+
+// ChangeContractStatusInput adalah alasan perubahan status kontrak, disimpan ke Catatan dan riwayat status.
+type ChangeContractStatusInput struct {
+	Alasan string `json:"alasan" binding:"required,min=5"`
 }
@@ -28,6 +28,14 @@ func (m *MockTransactionRepository) FindByID(id uint) (*domain.Transaction, erro
 	return args.Get(0).(*domain.Transaction), args.Error(1)
 }
 
+func (m *MockTransactionRepository) FindByIDForUpdate(id uint) (*domain.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Transaction), args.Error(1)
+}
+
 func (m *MockTransactionRepository) FindByConsumerID(consumerID uint) ([]*domain.Transaction, error) {
 	args := m.Called(consumerID)
 	if args.Get(0) == nil {
@@ -48,3 +56,11 @@ func (m *MockTransactionRepository) Update(transaction *domain.Transaction) erro
 	args := m.Called(transaction)
 	return args.Error(0)
 }
+
+func (m *MockTransactionRepository) FindByStatuses(statuses []string) ([]*domain.Transaction, error) {
+	args := m.Called(statuses)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Transaction), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"github.com/adty404/kredit-plus/internal/domain"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTransactionStatusHistoryRepository adalah implementasi mock dari domain.TransactionStatusHistoryRepository.
+type MockTransactionStatusHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockTransactionStatusHistoryRepository) WithTx(tx *gorm.DB) domain.TransactionStatusHistoryRepository {
+	return m
+}
+
+func (m *MockTransactionStatusHistoryRepository) Save(history *domain.TransactionStatusHistory) error {
+	args := m.Called(history)
+	return args.Error(0)
+}
+
+func (m *MockTransactionStatusHistoryRepository) FindByTransactionID(transactionID uint) (
+	[]*domain.TransactionStatusHistory,
+	error,
+) {
+	args := m.Called(transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TransactionStatusHistory), args.Error(1)
+}
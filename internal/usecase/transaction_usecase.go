@@ -1,16 +1,25 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"github.com/adty404/kredit-plus/internal/domain"
 	"gorm.io/gorm"
-	"math/rand"
 	"time"
 )
 
 type TransactionUsecase interface {
 	CreateTransaction(consumerID uint, input CreateTransactionInput) (*domain.Transaction, error)
 	GetTransactionsByConsumerID(consumerID uint) ([]*domain.Transaction, error)
+	GetTransactionSchedule(consumerID, transactionID uint) (*TransactionScheduleOutput, error)
+	SimulateTransaction(consumerID uint, input SimulateTransactionInput) (*TransactionSimulationOutput, error)
+	ActivateTransaction(transactionID, changedBy uint, input ChangeContractStatusInput) (*domain.Transaction, error)
+	CancelTransaction(transactionID, changedBy uint, input ChangeContractStatusInput) (*domain.Transaction, error)
+	RestructureTransaction(transactionID, changedBy uint, input ChangeContractStatusInput) (*domain.Transaction, error)
+	WriteOffTransaction(transactionID, changedBy uint, input ChangeContractStatusInput) (*domain.Transaction, error)
+	GetTransactionStatusHistory(consumerID, transactionID uint) ([]*domain.TransactionStatusHistory, error)
+	CreateLimitHold(consumerID uint, input CreateTransactionInput) (*domain.LimitHold, error)
+	ConfirmLimitHold(consumerID, holdID uint) (*domain.Transaction, error)
 }
 
 type transactionUsecase struct {
@@ -18,6 +27,14 @@ type transactionUsecase struct {
 	transactionRepo domain.TransactionRepository
 	consumerRepo    domain.ConsumerRepository
 	creditLimitRepo domain.ConsumerCreditLimitRepository
+	installmentRepo domain.InstallmentRepository
+	historyRepo     domain.TransactionStatusHistoryRepository
+	idempotencyRepo domain.IdempotencyKeyRepository
+	holdRepo        domain.LimitHoldRepository
+	products        ProductResolver
+	contractNumbers ContractNumberGenerator
+	coolingOff      time.Duration
+	holdTTL         time.Duration
 }
 
 func NewTransactionUsecase(
@@ -25,12 +42,28 @@ func NewTransactionUsecase(
 	transactionRepo domain.TransactionRepository,
 	consumerRepo domain.ConsumerRepository,
 	creditLimitRepo domain.ConsumerCreditLimitRepository,
+	installmentRepo domain.InstallmentRepository,
+	historyRepo domain.TransactionStatusHistoryRepository,
+	idempotencyRepo domain.IdempotencyKeyRepository,
+	holdRepo domain.LimitHoldRepository,
+	products ProductResolver,
+	contractNumbers ContractNumberGenerator,
+	coolingOff time.Duration,
+	holdTTL time.Duration,
 ) TransactionUsecase {
 	return &transactionUsecase{
 		db:              db,
 		transactionRepo: transactionRepo,
 		consumerRepo:    consumerRepo,
 		creditLimitRepo: creditLimitRepo,
+		installmentRepo: installmentRepo,
+		historyRepo:     historyRepo,
+		idempotencyRepo: idempotencyRepo,
+		holdRepo:        holdRepo,
+		products:        products,
+		contractNumbers: contractNumbers,
+		coolingOff:      coolingOff,
+		holdTTL:         holdTTL,
 	}
 }
 
@@ -40,13 +73,22 @@ func (uc *transactionUsecase) CreateTransaction(consumerID uint, input CreateTra
 ) {
 	var newTransaction *domain.Transaction
 
+	requestHash, err := idempotencyRequestHash(
+		struct {
+			ConsumerID uint
+			Input      CreateTransactionInput
+		}{consumerID, input},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Membungkus seluruh logika dalam sebuah transaksi database.
 	// Jika ada error di dalam fungsi ini, semua operasi akan di-rollback.
-	err := uc.db.Transaction(
+	err = uc.db.Transaction(
 		func(tx *gorm.DB) error {
 			consumerRepoTx := uc.consumerRepo.WithTx(tx)
-			creditLimitRepoTx := uc.creditLimitRepo.WithTx(tx)
-			transactionRepoTx := uc.transactionRepo.WithTx(tx)
+			idempotencyRepoTx := uc.idempotencyRepo.WithTx(tx)
 
 			// 1. Validasi: Dapatkan data konsumen dan KUNCI barisnya untuk mencegah race condition.
 			consumer, err := consumerRepoTx.FindByIDForUpdate(consumerID)
@@ -54,69 +96,46 @@ func (uc *transactionUsecase) CreateTransaction(consumerID uint, input CreateTra
 				return fmt.Errorf("consumer with id %d not found", consumerID)
 			}
 
-			// Validasi: Dapatkan limit kredit
-			creditLimit, err := creditLimitRepoTx.FindByConsumerAndTenor(consumerID, input.TenorMonths)
+			// Permintaan ulang dengan Idempotency-Key yang sama mendapatkan kontrak yang sudah dibuat.
+			// Pengecekan dilakukan setelah baris konsumen terkunci agar retry paralel tidak lolos bersamaan.
+			var replayed domain.Transaction
+			found, err := replayIdempotentResponse(
+				idempotencyRepoTx,
+				domain.IdempotencyScopeCreateTransaction,
+				input.IdempotencyKey,
+				requestHash,
+				&replayed,
+			)
 			if err != nil {
-				return fmt.Errorf("credit limit for tenor %d not found for this consumer", input.TenorMonths)
+				return err
 			}
-
-			// 2. Kalkulasi Pokok Pembiayaan
-			pokokPembiayaan := input.Otr - input.UangMuka + input.AdminFee
-
-			// 3. Validasi: Cek apakah pokok pembiayaan melebihi limit produk tenor
-			if pokokPembiayaan > creditLimit.CreditLimit {
-				return fmt.Errorf(
-					"loan amount (%.2f) exceeds tenor credit limit (%.2f)",
-					pokokPembiayaan,
-					creditLimit.CreditLimit,
-				)
+			if found {
+				newTransaction = &replayed
+				return nil
 			}
 
-			// 4. Validasi: Cek ketersediaan plafon kredit keseluruhan
-			activeTransactions, err := transactionRepoTx.FindActiveByConsumerID(consumerID)
+			// 2. Validasi limit (termasuk penahanan limit yang aktif) dan kalkulasi bunga, jalur yang sama
+			// dengan simulasi
+			draft, err := uc.prepareLockedTransaction(tx, consumer, input, 0)
 			if err != nil {
 				return err
 			}
-			var totalPinjamanAktif float64
-			for _, trans := range activeTransactions {
-				totalPinjamanAktif += trans.PokokPembiayaanAwal
-			}
-			sisaPlafon := consumer.OverallCreditLimit - totalPinjamanAktif
-			if pokokPembiayaan > sisaPlafon {
-				return fmt.Errorf(
-					"loan amount (%.2f) exceeds available overall credit limit (%.2f)",
-					pokokPembiayaan,
-					sisaPlafon,
-				)
-			}
 
-			// 5. Kalkulasi detail transaksi
-			totalBunga := pokokPembiayaan * 0.10
-			totalKewajiban := pokokPembiayaan + totalBunga
-			nilaiCicilan := totalKewajiban / float64(input.TenorMonths)
-
-			// 6. Buat objek transaksi
-			transactionToSave := &domain.Transaction{
-				ConsumerID:               consumerID,
-				ConsumerCreditLimitID:    creditLimit.ID,
-				NomorKontrak:             fmt.Sprintf("KONTRAK/%d/%d", time.Now().Unix(), rand.Intn(1000)),
-				TanggalKontrak:           time.Now(),
-				Otr:                      input.Otr,
-				UangMuka:                 input.UangMuka,
-				AdminFee:                 input.AdminFee,
-				PokokPembiayaanAwal:      pokokPembiayaan,
-				NilaiCicilanPerPeriode:   nilaiCicilan,
-				TenorBulan:               input.TenorMonths,
-				TotalBunga:               totalBunga,
-				TotalKewajibanPembayaran: totalKewajiban,
-				NamaAsset:                input.NamaAsset,
-				JenisAsset:               input.JenisAsset,
-				StatusKontrak:            "AKTIF",
-				SumberTransaksi:          input.SumberTransaksi,
+			// 3. Simpan transaksi beserta jadwal angsurannya
+			transactionToSave, err := uc.saveContract(tx, draft)
+			if err != nil {
+				return err
 			}
 
-			// 7. Simpan transaksi
-			if err = transactionRepoTx.Save(transactionToSave); err != nil {
+			// 4. Simpan respons untuk Idempotency-Key dalam transaksi database yang sama
+			err = storeIdempotentResponse(
+				idempotencyRepoTx,
+				domain.IdempotencyScopeCreateTransaction,
+				input.IdempotencyKey,
+				requestHash,
+				transactionToSave,
+			)
+			if err != nil {
 				return err
 			}
 			newTransaction = transactionToSave
@@ -133,6 +152,260 @@ func (uc *transactionUsecase) CreateTransaction(consumerID uint, input CreateTra
 	return newTransaction, nil
 }
 
+// SimulateTransaction menghitung penawaran untuk setiap tenor yang limitnya dimiliki konsumen
+// dengan validasi dan kalkulasi yang sama persis dengan CreateTransaction, tanpa menyimpan apa pun.
+func (uc *transactionUsecase) SimulateTransaction(consumerID uint, input SimulateTransactionInput) (
+	*TransactionSimulationOutput,
+	error,
+) {
+	consumer, err := uc.consumerRepo.FindByID(consumerID)
+	if err != nil {
+		return nil, fmt.Errorf("consumer with id %d not found", consumerID)
+	}
+
+	limits, err := uc.creditLimitRepo.FindByConsumerID(consumerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeTransactions, err := uc.transactionRepo.FindActiveByConsumerID(consumerID)
+	if err != nil {
+		return nil, err
+	}
+
+	activeHolds, err := uc.holdRepo.FindActiveByConsumerID(consumerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	availability := computeLimitAvailability(consumer, limits, activeTransactions, activeHolds)
+	output := &TransactionSimulationOutput{
+		ConsumerID: consumerID,
+		Otr:        input.Otr,
+		UangMuka:   input.UangMuka,
+		AdminFee:   input.AdminFee,
+		SisaPlafon: availability.Overall.Remaining,
+		Quotes:     make([]TransactionQuote, 0, len(limits)),
+	}
+
+	for _, limit := range limits {
+		quote := TransactionQuote{
+			TenorMonths:     limit.TenorMonths,
+			PokokPembiayaan: input.Otr.Sub(input.UangMuka).Add(input.AdminFee),
+			SisaLimitTenor:  availability.Tenor(limit.TenorMonths).Remaining,
+		}
+
+		draft, err := uc.prepareTransaction(
+			consumer, limit, activeTransactions, activeHolds, CreateTransactionInput{
+				TenorMonths: limit.TenorMonths,
+				Otr:         input.Otr,
+				AdminFee:    input.AdminFee,
+				UangMuka:    input.UangMuka,
+				JenisAsset:  input.JenisAsset,
+			},
+		)
+		if err != nil {
+			quote.RejectionReason = err.Error()
+			if violation := AsRuleViolation(err); violation != nil {
+				quote.RejectionCode = violation.Code
+			}
+			output.Quotes = append(output.Quotes, quote)
+			continue
+		}
+
+		quote.Eligible = true
+		quote.PokokPembiayaan = draft.transaction.PokokPembiayaanAwal
+		quote.MetodeBunga = draft.transaction.MetodeBunga
+		quote.SukuBungaTahunan = draft.transaction.SukuBungaTahunan
+		quote.NilaiCicilanPerPeriode = draft.transaction.NilaiCicilanPerPeriode
+		quote.TotalBunga = draft.transaction.TotalBunga
+		quote.TotalKewajibanPembayaran = draft.transaction.TotalKewajibanPembayaran
+		quote.SisaPlafonSetelahTransaksi = output.SisaPlafon.Sub(draft.transaction.PokokPembiayaanAwal)
+		output.Quotes = append(output.Quotes, quote)
+	}
+
+	return output, nil
+}
+
+// transactionDraft adalah hasil validasi dan kalkulasi sebuah pengajuan yang belum disimpan.
+type transactionDraft struct {
+	transaction  *domain.Transaction
+	lines        []InterestScheduleLine
+	availability *LimitAvailabilityOutput
+}
+
+// prepareLockedTransaction memuat limit tenor, kontrak aktif, dan penahanan limit aktif milik konsumen yang
+// barisnya sudah dikunci, lalu menjalankan prepareTransaction. Penahanan dengan ID excludeHoldID tidak ikut
+// dihitung karena sedang dikonfirmasi menjadi kontrak.
+func (uc *transactionUsecase) prepareLockedTransaction(
+	tx *gorm.DB,
+	consumer *domain.Consumer,
+	input CreateTransactionInput,
+	excludeHoldID uint,
+) (*transactionDraft, error) {
+	creditLimit, err := uc.creditLimitRepo.WithTx(tx).FindByConsumerAndTenor(consumer.ID, input.TenorMonths)
+	if err != nil {
+		return nil, newRuleViolation(
+			RuleTenorLimitNotFound, "tenor_months",
+			"credit limit for tenor %d not found for this consumer", input.TenorMonths,
+		)
+	}
+
+	activeTransactions, err := uc.transactionRepo.WithTx(tx).FindActiveByConsumerID(consumer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	holds, err := uc.holdRepo.WithTx(tx).FindActiveByConsumerID(consumer.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	activeHolds := make([]*domain.LimitHold, 0, len(holds))
+	for _, hold := range holds {
+		if hold.ID != excludeHoldID {
+			activeHolds = append(activeHolds, hold)
+		}
+	}
+
+	return uc.prepareTransaction(consumer, creditLimit, activeTransactions, activeHolds, input)
+}
+
+// prepareTransaction menjalankan seluruh validasi limit dan kalkulasi bunga untuk sebuah pengajuan
+// tanpa menulis apa pun ke database. Dipakai bersama oleh CreateTransaction, SimulateTransaction,
+// dan penahanan limit.
+func (uc *transactionUsecase) prepareTransaction(
+	consumer *domain.Consumer,
+	creditLimit *domain.ConsumerCreditLimit,
+	activeTransactions []*domain.Transaction,
+	activeHolds []*domain.LimitHold,
+	input CreateTransactionInput,
+) (*transactionDraft, error) {
+	// 1. Validasi: Konsumen yang KYC-nya belum disetujui, serta konsumen dan limit tenor yang dibekukan atau
+	// sudah habis masa berlakunya tidak boleh menarik pinjaman baru
+	if err := checkKYCApproved(consumer); err != nil {
+		return nil, err
+	}
+	if err := checkCreditNotFrozen(consumer, creditLimit); err != nil {
+		return nil, err
+	}
+	if err := checkLimitNotExpired(consumer, creditLimit, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi aturan produk untuk jenis aset: tenor, OTR, uang muka, biaya admin, LTV, dan pembiayaan maksimal
+	product, err := uc.products.ResolveProduct(input.JenisAsset)
+	if err != nil {
+		return nil, err
+	}
+	terms, err := applyFinancingRules(product, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. Validasi: Cek apakah pokok pembiayaan melebihi limit produk tenor
+	pokokPembiayaan := terms.pokokPembiayaan
+	if pokokPembiayaan.GreaterThan(creditLimit.CreditLimit) {
+		return nil, newRuleViolation(
+			RuleTenorLimitExceeded, "",
+			"loan amount (%s) exceeds tenor credit limit (%s)",
+			pokokPembiayaan,
+			creditLimit.CreditLimit,
+		)
+	}
+
+	// 4. Validasi: Cek ketersediaan limit tenor dan plafon keseluruhan berdasarkan sisa pokok kontrak aktif
+	// dan penahanan limit yang masih aktif
+	availability := computeLimitAvailability(
+		consumer,
+		[]*domain.ConsumerCreditLimit{creditLimit},
+		activeTransactions,
+		activeHolds,
+	)
+	sisaLimitTenor := availability.Tenor(creditLimit.TenorMonths).Remaining
+	if pokokPembiayaan.GreaterThan(sisaLimitTenor) {
+		return nil, newRuleViolation(
+			RuleTenorLimitInsufficient, "",
+			"loan amount (%s) exceeds available tenor credit limit (%s)",
+			pokokPembiayaan,
+			sisaLimitTenor,
+		)
+	}
+	sisaPlafon := availability.Overall.Remaining
+	if pokokPembiayaan.GreaterThan(sisaPlafon) {
+		return nil, newRuleViolation(
+			RuleOverallLimitInsufficient, "",
+			"loan amount (%s) exceeds available overall credit limit (%s)",
+			pokokPembiayaan,
+			sisaPlafon,
+		)
+	}
+
+	// 5. Kalkulasi bunga sesuai kebijakan harga untuk tenor/produk ini
+	policy := pricingPolicyFromTenor(terms.tenor)
+	calculator, err := NewInterestCalculator(policy.MetodeBunga)
+	if err != nil {
+		return nil, err
+	}
+	calculation, err := calculator.Calculate(pokokPembiayaan, input.TenorMonths, policy.SukuBungaTahunan)
+	if err != nil {
+		return nil, err
+	}
+	totalBunga := calculation.TotalBunga
+	totalKewajiban := pokokPembiayaan.Add(totalBunga)
+	nilaiCicilan := calculation.Lines[0].Pokok.Add(calculation.Lines[0].Bunga)
+
+	// 6. Buat objek transaksi
+	return &transactionDraft{
+		transaction: &domain.Transaction{
+			ConsumerID:               consumer.ID,
+			ConsumerCreditLimitID:    creditLimit.ID,
+			TanggalKontrak:           time.Now(),
+			Otr:                      input.Otr,
+			UangMuka:                 input.UangMuka,
+			AdminFee:                 terms.adminFee,
+			PokokPembiayaanAwal:      pokokPembiayaan,
+			NilaiCicilanPerPeriode:   nilaiCicilan,
+			TenorBulan:               input.TenorMonths,
+			MetodeBunga:              calculator.Metode(),
+			SukuBungaTahunan:         policy.SukuBungaTahunan,
+			TotalBunga:               totalBunga,
+			TotalKewajibanPembayaran: totalKewajiban,
+			NamaAsset:                input.NamaAsset,
+			JenisAsset:               input.JenisAsset,
+			StatusKontrak:            domain.StatusKontrakPending,
+			SumberTransaksi:          input.SumberTransaksi,
+		},
+		lines:        calculation.Lines,
+		availability: availability,
+	}, nil
+}
+
+// saveContract menerbitkan nomor kontrak, lalu menyimpan kontrak hasil prepareTransaction beserta jadwal
+// angsurannya di dalam transaksi database tx.
+func (uc *transactionUsecase) saveContract(tx *gorm.DB, draft *transactionDraft) (*domain.Transaction, error) {
+	transaction := draft.transaction
+
+	// Nomor kontrak diambil dari nomor urut di dalam transaksi yang sama agar unik dan tidak terpakai
+	// jika transaksi gagal.
+	nomorKontrak, err := uc.contractNumbers.WithTx(tx).Generate(transaction.TanggalKontrak)
+	if err != nil {
+		return nil, err
+	}
+	transaction.NomorKontrak = nomorKontrak
+
+	if err := uc.transactionRepo.WithTx(tx).Save(transaction); err != nil {
+		return nil, err
+	}
+
+	// Bentuk dan simpan jadwal angsuran per periode
+	schedule := buildInstallmentSchedule(transaction, draft.lines)
+	if err := uc.installmentRepo.WithTx(tx).SaveAll(schedule); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
 func (uc *transactionUsecase) GetTransactionsByConsumerID(consumerID uint) ([]*domain.Transaction, error) {
 	// Pastikan konsumen ada
 	if _, err := uc.consumerRepo.FindByID(consumerID); err != nil {
@@ -140,3 +413,167 @@ func (uc *transactionUsecase) GetTransactionsByConsumerID(consumerID uint) ([]*d
 	}
 	return uc.transactionRepo.FindByConsumerID(consumerID)
 }
+
+// GetTransactionSchedule mengambil tabel amortisasi sebuah transaksi milik konsumen tertentu.
+func (uc *transactionUsecase) GetTransactionSchedule(consumerID, transactionID uint) (
+	*TransactionScheduleOutput,
+	error,
+) {
+	transaction, err := uc.transactionRepo.FindByID(transactionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err != nil || transaction.ConsumerID != consumerID {
+		return nil, fmt.Errorf(
+			"transaction with id %d not found for this consumer: %w", transactionID, gorm.ErrRecordNotFound,
+		)
+	}
+
+	installments, err := uc.installmentRepo.FindByTransactionID(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionScheduleOutput{
+		Transaction:  transaction,
+		Installments: installments,
+	}, nil
+}
+
+// CancelTransaction membatalkan kontrak yang masih dalam masa cooling-off dan belum menerima pembayaran.
+// Pembatalan membebaskan plafon yang dipakai kontrak tersebut.
+func (uc *transactionUsecase) CancelTransaction(
+	transactionID, changedBy uint,
+	input ChangeContractStatusInput,
+) (*domain.Transaction, error) {
+	return uc.changeStatus(
+		transactionID, changedBy, domain.StatusKontrakDibatalkan, input.Alasan,
+		func(tx *gorm.DB, trx *domain.Transaction) error {
+			if time.Since(trx.TanggalKontrak) > uc.coolingOff {
+				return fmt.Errorf(
+					"cooling-off period of %d days has passed, contract can no longer be cancelled",
+					int(uc.coolingOff.Hours()/24),
+				)
+			}
+
+			installments, err := uc.installmentRepo.WithTx(tx).FindByTransactionID(trx.ID)
+			if err != nil {
+				return err
+			}
+			for _, installment := range installments {
+				if installment.JumlahDibayar.IsPositive() {
+					return fmt.Errorf("cannot cancel contract that already has recorded payments")
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// ActivateTransaction mengaktifkan kontrak PENDING setelah barang diserahkan kepada konsumen.
+// Tanggal kontrak dipindahkan ke tanggal aktivasi dan jatuh tempo angsuran dihitung ulang darinya.
+func (uc *transactionUsecase) ActivateTransaction(
+	transactionID, changedBy uint,
+	input ChangeContractStatusInput,
+) (*domain.Transaction, error) {
+	return uc.changeStatus(
+		transactionID, changedBy, domain.StatusKontrakAktif, input.Alasan,
+		func(tx *gorm.DB, trx *domain.Transaction) error {
+			return uc.redateContract(tx, trx, time.Now())
+		},
+	)
+}
+
+// redateContract memindahkan tanggal kontrak ke tanggalKontrak dan menyesuaikan jatuh tempo setiap angsuran.
+// Pemanggil bertanggung jawab menyimpan transaksi di dalam transaksi database tx.
+func (uc *transactionUsecase) redateContract(tx *gorm.DB, trx *domain.Transaction, tanggalKontrak time.Time) error {
+	installmentRepoTx := uc.installmentRepo.WithTx(tx)
+
+	installments, err := installmentRepoTx.FindByTransactionID(trx.ID)
+	if err != nil {
+		return err
+	}
+	for _, installment := range installments {
+		installment.TanggalJatuhTempo = addMonthsClamped(tanggalKontrak, installment.AngsuranKe)
+		if err := installmentRepoTx.Update(installment); err != nil {
+			return err
+		}
+	}
+
+	trx.TanggalKontrak = tanggalKontrak
+	return nil
+}
+
+// RestructureTransaction menandai kontrak yang sedang berjalan sebagai kontrak restrukturisasi.
+// Kontrak restrukturisasi tetap menerima pembayaran dan mengikat plafon hingga lunas atau dihapusbukukan.
+func (uc *transactionUsecase) RestructureTransaction(
+	transactionID, changedBy uint,
+	input ChangeContractStatusInput,
+) (*domain.Transaction, error) {
+	return uc.changeStatus(transactionID, changedBy, domain.StatusKontrakRestrukturisasi, input.Alasan, nil)
+}
+
+// WriteOffTransaction menghapusbukukan kontrak yang sedang berjalan.
+func (uc *transactionUsecase) WriteOffTransaction(
+	transactionID, changedBy uint,
+	input ChangeContractStatusInput,
+) (*domain.Transaction, error) {
+	return uc.changeStatus(transactionID, changedBy, domain.StatusKontrakWriteOff, input.Alasan, nil)
+}
+
+// changeStatus mengunci kontrak, menjalankan validasi tambahan (jika ada), lalu memindahkan statusnya
+// beserta riwayat perubahan dalam satu transaksi database.
+func (uc *transactionUsecase) changeStatus(
+	transactionID, changedBy uint,
+	toStatus, alasan string,
+	validate func(tx *gorm.DB, trx *domain.Transaction) error,
+) (*domain.Transaction, error) {
+	var updated *domain.Transaction
+
+	err := uc.db.Transaction(
+		func(tx *gorm.DB) error {
+			transactionRepoTx := uc.transactionRepo.WithTx(tx)
+
+			trx, err := transactionRepoTx.FindByIDForUpdate(transactionID)
+			if err != nil {
+				return fmt.Errorf("transaction with id %d not found", transactionID)
+			}
+			if !trx.CanTransitionTo(toStatus) {
+				return fmt.Errorf("cannot change contract status from %s to %s", trx.StatusKontrak, toStatus)
+			}
+			if validate != nil {
+				if err := validate(tx, trx); err != nil {
+					return err
+				}
+			}
+
+			if err := changeContractStatus(trx, toStatus, alasan, &changedBy, uc.historyRepo.WithTx(tx)); err != nil {
+				return err
+			}
+			if err := transactionRepoTx.Update(trx); err != nil {
+				return err
+			}
+
+			updated = trx
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// GetTransactionStatusHistory mengambil riwayat perubahan status kontrak milik konsumen tertentu.
+func (uc *transactionUsecase) GetTransactionStatusHistory(consumerID, transactionID uint) (
+	[]*domain.TransactionStatusHistory,
+	error,
+) {
+	transaction, err := uc.transactionRepo.FindByID(transactionID)
+	if err != nil || transaction.ConsumerID != consumerID {
+		return nil, fmt.Errorf("transaction with id %d not found for this consumer", transactionID)
+	}
+	return uc.historyRepo.FindByTransactionID(transactionID)
+}
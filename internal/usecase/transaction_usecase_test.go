@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"errors"
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/adty404/kredit-plus/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -8,6 +9,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"testing"
+	"time"
 )
 
 func setupMocksAndDb(t *testing.T) (
@@ -16,6 +18,7 @@ func setupMocksAndDb(t *testing.T) (
 	*MockConsumerRepository,
 	*MockCreditLimitRepository,
 	*MockTransactionRepository,
+	*MockInstallmentRepository,
 ) {
 	sqlDB, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -32,25 +35,59 @@ func setupMocksAndDb(t *testing.T) (
 	mockConsumerRepo := new(MockConsumerRepository)
 	mockLimitRepo := new(MockCreditLimitRepository)
 	mockTransactionRepo := new(MockTransactionRepository)
+	mockInstallmentRepo := new(MockInstallmentRepository)
 
-	return gormDB, mock, mockConsumerRepo, mockLimitRepo, mockTransactionRepo
+	return gormDB, mock, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo
+}
+
+// newTestContractNumbers menyiapkan generator nomor kontrak dengan nomor urut tiruan yang selalu bernilai 1.
+func newTestContractNumbers() ContractNumberGenerator {
+	sequenceRepo := new(MockContractSequenceRepository)
+	sequenceRepo.On("Next", mock.AnythingOfType("string")).Return(int64(1), nil).Maybe()
+	format, _ := ParseContractNumberFormat("")
+	return NewContractNumberGenerator(sequenceRepo, format, DefaultBranchCode)
+}
+
+// newTestLimitHoldRepo menyiapkan repository penahanan limit tiruan tanpa penahanan aktif.
+func newTestLimitHoldRepo() *MockLimitHoldRepository {
+	holdRepo := new(MockLimitHoldRepository)
+	holdRepo.On("FindActiveByConsumerID", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return([]*domain.LimitHold{}, nil).Maybe()
+	return holdRepo
 }
 
 func TestCreateTransaction_Success(t *testing.T) {
 	// Arrange
-	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo := setupMocksAndDb(t)
-	usecase := NewTransactionUsecase(gormDB, mockTransactionRepo, mockConsumerRepo, mockLimitRepo)
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
 
 	consumerID := uint(1)
 	input := CreateTransactionInput{
 		TenorMonths: 6,
-		Otr:         5000000,
-		AdminFee:    100000,
-		UangMuka:    500000,
+		Otr:         domain.NewMoney(5000000),
+		AdminFee:    domain.NewMoney(100000),
+		UangMuka:    domain.NewMoney(500000),
 	}
 
-	consumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 10000000}
-	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: 5000000}
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: domain.NewMoney(5000000)}
 	activeTransactions := []*domain.Transaction{}
 
 	// Tentukan ekspektasi untuk transaksi SQL
@@ -61,6 +98,7 @@ func TestCreateTransaction_Success(t *testing.T) {
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
 	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return(activeTransactions, nil).Once()
 	mockTransactionRepo.On("Save", mock.AnythingOfType("*domain.Transaction")).Return(nil).Once()
+	mockInstallmentRepo.On("SaveAll", mock.AnythingOfType("[]*domain.Installment")).Return(nil).Once()
 
 	// Harapkan Commit setelah semua operasi berhasil
 	mockSQL.ExpectCommit()
@@ -71,26 +109,46 @@ func TestCreateTransaction_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, transaction)
-	assert.Equal(t, float64(4600000), transaction.PokokPembiayaanAwal)
+	assert.Equal(t, domain.NewMoney(4600000), transaction.PokokPembiayaanAwal)
+	assert.Equal(t, domain.StatusKontrakPending, transaction.StatusKontrak)
+	assert.Regexp(t, `^KP/PST/\d{6}/000001-\d$`, transaction.NomorKontrak)
 
 	// Verifikasi semua ekspektasi (termasuk SQL) terpenuhi
 	assert.NoError(t, mockSQL.ExpectationsWereMet())
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 	mockTransactionRepo.AssertExpectations(t)
+	mockInstallmentRepo.AssertExpectations(t)
 }
 
 func TestCreateTransaction_ExceedsOverallLimit(t *testing.T) {
 	// Arrange
-	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo := setupMocksAndDb(t)
-	usecase := NewTransactionUsecase(gormDB, mockTransactionRepo, mockConsumerRepo, mockLimitRepo)
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
 
 	consumerID := uint(1)
-	input := CreateTransactionInput{TenorMonths: 6, Otr: 5000000}
+	input := CreateTransactionInput{TenorMonths: 6, Otr: domain.NewMoney(5000000)}
 
-	consumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 10000000}
-	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: 8000000}
-	activeTransactions := []*domain.Transaction{{PokokPembiayaanAwal: 6000000}}
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: domain.NewMoney(8000000)}
+	activeTransactions := []*domain.Transaction{{PokokPembiayaanAwal: domain.NewMoney(6000000)}}
 
 	// Tentukan ekspektasi SQL (gagal, jadi akan di-rollback)
 	mockSQL.ExpectBegin()
@@ -117,14 +175,31 @@ func TestCreateTransaction_ExceedsOverallLimit(t *testing.T) {
 
 func TestCreateTransaction_ExceedsTenorLimit(t *testing.T) {
 	// Arrange
-	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo := setupMocksAndDb(t)
-	usecase := NewTransactionUsecase(gormDB, mockTransactionRepo, mockConsumerRepo, mockLimitRepo)
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
 
 	consumerID := uint(1)
-	input := CreateTransactionInput{TenorMonths: 3, Otr: 6000000}
+	input := CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(6000000)}
 
-	consumer := &domain.Consumer{ID: consumerID, OverallCreditLimit: 10000000}
-	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: 5000000}
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: domain.NewMoney(5000000)}
 
 	// Tentukan ekspektasi SQL (gagal, jadi akan di-rollback)
 	mockSQL.ExpectBegin()
@@ -133,6 +208,7 @@ func TestCreateTransaction_ExceedsTenorLimit(t *testing.T) {
 	// Tentukan ekspektasi mock repository
 	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
 	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
 
 	// Act
 	transaction, err := usecase.CreateTransaction(consumerID, input)
@@ -146,3 +222,1066 @@ func TestCreateTransaction_ExceedsTenorLimit(t *testing.T) {
 	mockConsumerRepo.AssertExpectations(t)
 	mockLimitRepo.AssertExpectations(t)
 }
+
+func TestCreateTransaction_GeneratesInstallmentSchedule(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(1000000)}
+
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: domain.NewMoney(5000000)}
+
+	var savedSchedule []*domain.Installment
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	mockTransactionRepo.On("Save", mock.AnythingOfType("*domain.Transaction")).Return(nil).Once()
+	mockInstallmentRepo.On("SaveAll", mock.AnythingOfType("[]*domain.Installment")).
+		Run(func(args mock.Arguments) { savedSchedule = args.Get(0).([]*domain.Installment) }).
+		Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, savedSchedule, 3)
+
+	var totalPokok, totalTagihan domain.Money
+	for i, installment := range savedSchedule {
+		assert.Equal(t, i+1, installment.AngsuranKe)
+		assert.Equal(t, domain.InstallmentStatusBelumBayar, installment.Status)
+		assert.Equal(t, addMonthsClamped(transaction.TanggalKontrak, i+1), installment.TanggalJatuhTempo)
+		totalPokok = totalPokok.Add(installment.Pokok)
+		totalTagihan = totalTagihan.Add(installment.JumlahTagihan)
+	}
+	// Pokok dibulatkan ke rupiah utuh dan sisa pembulatan dibebankan ke angsuran terakhir.
+	assert.Equal(t, domain.NewMoney(333333), savedSchedule[0].Pokok)
+	assert.Equal(t, domain.NewMoney(333334), savedSchedule[2].Pokok)
+	assert.Equal(t, transaction.PokokPembiayaanAwal, totalPokok)
+	assert.Equal(t, transaction.TotalKewajibanPembayaran, totalTagihan)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertExpectations(t)
+}
+
+func TestGetTransactionSchedule_Success(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	transaction := &domain.Transaction{ID: 5, ConsumerID: 1, TenorBulan: 2}
+	installments := []*domain.Installment{
+		{TransactionID: 5, AngsuranKe: 1},
+		{TransactionID: 5, AngsuranKe: 2},
+	}
+
+	mockTransactionRepo.On("FindByID", uint(5)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindByTransactionID", uint(5)).Return(installments, nil).Once()
+
+	// Act
+	schedule, err := usecase.GetTransactionSchedule(1, 5)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, transaction, schedule.Transaction)
+	assert.Len(t, schedule.Installments, 2)
+	mockTransactionRepo.AssertExpectations(t)
+	mockInstallmentRepo.AssertExpectations(t)
+}
+
+func TestGetTransactionSchedule_OtherConsumer(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	mockTransactionRepo.On("FindByID", uint(5)).Return(&domain.Transaction{ID: 5, ConsumerID: 2}, nil).Once()
+
+	// Act
+	schedule, err := usecase.GetTransactionSchedule(1, 5)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, schedule)
+	assert.Contains(t, err.Error(), "not found for this consumer")
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	mockInstallmentRepo.AssertNotCalled(t, "FindByTransactionID")
+}
+
+func TestGetTransactionSchedule_RepositoryError(t *testing.T) {
+	// Arrange
+	gormDB, _, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	dbErr := errors.New("connection refused")
+	mockTransactionRepo.On("FindByID", uint(5)).Return(nil, dbErr).Once()
+
+	// Act
+	schedule, err := usecase.GetTransactionSchedule(1, 5)
+
+	// Assert: kegagalan database tidak disamarkan sebagai data tidak ditemukan.
+	assert.Nil(t, schedule)
+	assert.ErrorIs(t, err, dbErr)
+	assert.NotErrorIs(t, err, gorm.ErrRecordNotFound)
+	mockInstallmentRepo.AssertNotCalled(t, "FindByTransactionID")
+}
+
+func TestCreateTransaction_RepaidPrincipalFreesPlafon(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 6, Otr: domain.NewMoney(5000000)}
+
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(8000000)}
+	// Pokok awal 6 juta, 5 juta sudah dibayar kembali: sisa plafon 9 juta, sisa limit tenor 7 juta.
+	activeTransactions := []*domain.Transaction{
+		{ConsumerCreditLimitID: 10, PokokPembiayaanAwal: domain.NewMoney(6000000), PokokTerbayar: domain.NewMoney(5000000)},
+	}
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return(activeTransactions, nil).Once()
+	mockTransactionRepo.On("Save", mock.AnythingOfType("*domain.Transaction")).Return(nil).Once()
+	mockInstallmentRepo.On("SaveAll", mock.AnythingOfType("[]*domain.Installment")).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, transaction)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_ExceedsRemainingTenorLimit(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 6, Otr: domain.NewMoney(5000000)}
+
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(20000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(8000000)}
+	activeTransactions := []*domain.Transaction{
+		{ConsumerCreditLimitID: 10, PokokPembiayaanAwal: domain.NewMoney(4000000)},
+	}
+
+	mockSQL.ExpectBegin()
+	mockSQL.ExpectRollback()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return(activeTransactions, nil).Once()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, transaction)
+	assert.Contains(t, err.Error(), "exceeds available tenor credit limit")
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestSimulateTransaction_QuotesEveryTenor(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := SimulateTransactionInput{Otr: domain.NewMoney(3000000), UangMuka: domain.NewMoney(500000), AdminFee: domain.NewMoney(100000), JenisAsset: "ELEKTRONIK"}
+
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	limits := []*domain.ConsumerCreditLimit{
+		{ID: 10, ConsumerID: consumerID, TenorMonths: 1, CreditLimit: domain.NewMoney(2000000)},
+		{ID: 11, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(8000000)},
+	}
+
+	mockConsumerRepo.On("FindByID", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerID", consumerID).Return(limits, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+
+	// Act
+	simulation, err := usecase.SimulateTransaction(consumerID, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, simulation.Quotes, 2)
+
+	rejected := simulation.Quotes[0]
+	assert.Equal(t, 1, rejected.TenorMonths)
+	assert.False(t, rejected.Eligible)
+	assert.Contains(t, rejected.RejectionReason, "exceeds tenor credit limit")
+	assert.Equal(t, RuleTenorLimitExceeded, rejected.RejectionCode)
+
+	accepted := simulation.Quotes[1]
+	assert.Equal(t, 6, accepted.TenorMonths)
+	assert.True(t, accepted.Eligible)
+	assert.Empty(t, accepted.RejectionCode)
+	assert.Equal(t, domain.NewMoney(2600000), accepted.PokokPembiayaan)
+	assert.Equal(t, domain.NewMoney(312000), accepted.TotalBunga)
+	assert.Equal(t, domain.NewMoney(2912000), accepted.TotalKewajibanPembayaran)
+	assert.Equal(t, domain.NewMoney(7400000), accepted.SisaPlafonSetelahTransaksi)
+
+	// Simulasi tidak boleh membuka transaksi database maupun menyimpan data.
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+	mockInstallmentRepo.AssertNotCalled(t, "SaveAll", mock.Anything)
+}
+
+func newStatusChangeUsecase(t *testing.T) (
+	TransactionUsecase,
+	sqlmock.Sqlmock,
+	*MockTransactionRepository,
+	*MockInstallmentRepository,
+	*MockTransactionStatusHistoryRepository,
+) {
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	mockHistoryRepo := new(MockTransactionStatusHistoryRepository)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		mockHistoryRepo,
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+	return usecase, mockSQL, mockTransactionRepo, mockInstallmentRepo, mockHistoryRepo
+}
+
+func TestCancelTransaction_WithinCoolingOff(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockTransactionRepo, mockInstallmentRepo, mockHistoryRepo := newStatusChangeUsecase(t)
+
+	adminID := uint(99)
+	transaction := &domain.Transaction{
+		ID:             5,
+		StatusKontrak:  domain.StatusKontrakAktif,
+		TanggalKontrak: time.Now().AddDate(0, 0, -3),
+	}
+	input := ChangeContractStatusInput{Alasan: "Konsumen membatalkan pembelian"}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindByTransactionID", uint(5)).Return([]*domain.Installment{{ID: 1}}, nil).Once()
+	mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(h *domain.TransactionStatusHistory) bool {
+				return h.FromStatus == domain.StatusKontrakAktif &&
+					h.ToStatus == domain.StatusKontrakDibatalkan &&
+					*h.ChangedBy == adminID &&
+					h.Alasan == input.Alasan
+			},
+		),
+	).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	cancelled, err := usecase.CancelTransaction(5, adminID, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusKontrakDibatalkan, cancelled.StatusKontrak)
+	assert.Equal(t, input.Alasan, cancelled.Catatan)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockHistoryRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCancelTransaction_CoolingOffPassed(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockTransactionRepo, _, mockHistoryRepo := newStatusChangeUsecase(t)
+
+	transaction := &domain.Transaction{
+		ID:             5,
+		StatusKontrak:  domain.StatusKontrakAktif,
+		TanggalKontrak: time.Now().AddDate(0, 0, -30),
+	}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	cancelled, err := usecase.CancelTransaction(5, 99, ChangeContractStatusInput{Alasan: "Terlambat membatalkan"})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, cancelled)
+	assert.Contains(t, err.Error(), "cooling-off period of 14 days has passed")
+	assert.Equal(t, domain.StatusKontrakAktif, transaction.StatusKontrak)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCancelTransaction_HasPayments(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockTransactionRepo, mockInstallmentRepo, mockHistoryRepo := newStatusChangeUsecase(t)
+
+	transaction := &domain.Transaction{ID: 5, StatusKontrak: domain.StatusKontrakAktif, TanggalKontrak: time.Now()}
+	installments := []*domain.Installment{{ID: 1, JumlahDibayar: domain.NewMoney(100000)}}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindByTransactionID", uint(5)).Return(installments, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	_, err := usecase.CancelTransaction(5, 99, ChangeContractStatusInput{Alasan: "Konsumen berubah pikiran"})
+
+	// Assert
+	assert.EqualError(t, err, "cannot cancel contract that already has recorded payments")
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestWriteOffTransaction_InvalidTransition(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockTransactionRepo, _, mockHistoryRepo := newStatusChangeUsecase(t)
+
+	transaction := &domain.Transaction{ID: 5, StatusKontrak: domain.StatusKontrakLunas}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	_, err := usecase.WriteOffTransaction(5, 99, ChangeContractStatusInput{Alasan: "Piutang macet"})
+
+	// Assert
+	assert.EqualError(t, err, "cannot change contract status from LUNAS to WRITE_OFF")
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+	mockTransactionRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestActivateTransaction_RedatesSchedule(t *testing.T) {
+	// Arrange: kontrak dibuat 31 Januari dan baru diaktifkan hari ini
+	usecase, mockSQL, mockTransactionRepo, mockInstallmentRepo, mockHistoryRepo := newStatusChangeUsecase(t)
+
+	adminID := uint(99)
+	transaction := &domain.Transaction{
+		ID:             5,
+		StatusKontrak:  domain.StatusKontrakPending,
+		TanggalKontrak: time.Date(2026, 1, 31, 9, 0, 0, 0, time.Local),
+	}
+	installments := []*domain.Installment{
+		{ID: 1, AngsuranKe: 1, TanggalJatuhTempo: time.Date(2026, 2, 28, 0, 0, 0, 0, time.Local)},
+		{ID: 2, AngsuranKe: 2, TanggalJatuhTempo: time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)},
+	}
+	input := ChangeContractStatusInput{Alasan: "Barang sudah diterima konsumen"}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+	mockInstallmentRepo.On("FindByTransactionID", uint(5)).Return(installments, nil).Once()
+	mockInstallmentRepo.On("Update", mock.AnythingOfType("*domain.Installment")).Return(nil).Twice()
+	mockHistoryRepo.On(
+		"Save", mock.MatchedBy(
+			func(h *domain.TransactionStatusHistory) bool {
+				return h.FromStatus == domain.StatusKontrakPending &&
+					h.ToStatus == domain.StatusKontrakAktif &&
+					*h.ChangedBy == adminID
+			},
+		),
+	).Return(nil).Once()
+	mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	before := time.Now()
+	activated, err := usecase.ActivateTransaction(5, adminID, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusKontrakAktif, activated.StatusKontrak)
+	assert.WithinDuration(t, before, activated.TanggalKontrak, time.Second)
+	assert.Equal(t, addMonthsClamped(activated.TanggalKontrak, 1), installments[0].TanggalJatuhTempo)
+	assert.Equal(t, addMonthsClamped(activated.TanggalKontrak, 2), installments[1].TanggalJatuhTempo)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertExpectations(t)
+	mockHistoryRepo.AssertExpectations(t)
+}
+
+func TestActivateTransaction_AlreadyActive(t *testing.T) {
+	// Arrange
+	usecase, mockSQL, mockTransactionRepo, mockInstallmentRepo, mockHistoryRepo := newStatusChangeUsecase(t)
+
+	transaction := &domain.Transaction{ID: 5, StatusKontrak: domain.StatusKontrakAktif}
+
+	mockSQL.ExpectBegin()
+	mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	_, err := usecase.ActivateTransaction(5, 99, ChangeContractStatusInput{Alasan: "Aktivasi ulang"})
+
+	// Assert
+	assert.EqualError(t, err, "cannot change contract status from AKTIF to AKTIF")
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockInstallmentRepo.AssertNotCalled(t, "Update", mock.Anything)
+	mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestRestructureTransaction(t *testing.T) {
+	testCases := []struct {
+		name       string
+		fromStatus string
+		errText    string
+	}{
+		{name: "kontrak berjalan", fromStatus: domain.StatusKontrakAktif},
+		{
+			name:       "kontrak belum aktif",
+			fromStatus: domain.StatusKontrakPending,
+			errText:    "cannot change contract status from PENDING to RESTRUKTURISASI",
+		},
+		{
+			name:       "kontrak sudah direstrukturisasi",
+			fromStatus: domain.StatusKontrakRestrukturisasi,
+			errText:    "cannot change contract status from RESTRUKTURISASI to RESTRUKTURISASI",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				usecase, mockSQL, mockTransactionRepo, _, mockHistoryRepo := newStatusChangeUsecase(t)
+
+				transaction := &domain.Transaction{ID: 5, StatusKontrak: tc.fromStatus}
+				input := ChangeContractStatusInput{Alasan: "Konsumen terdampak PHK"}
+
+				mockSQL.ExpectBegin()
+				mockTransactionRepo.On("FindByIDForUpdate", uint(5)).Return(transaction, nil).Once()
+				if tc.errText == "" {
+					mockHistoryRepo.On("Save", mock.AnythingOfType("*domain.TransactionStatusHistory")).
+						Return(nil).Once()
+					mockTransactionRepo.On("Update", transaction).Return(nil).Once()
+					mockSQL.ExpectCommit()
+				} else {
+					mockSQL.ExpectRollback()
+				}
+
+				// Act
+				restructured, err := usecase.RestructureTransaction(5, 99, input)
+
+				// Assert
+				if tc.errText != "" {
+					assert.EqualError(t, err, tc.errText)
+					mockHistoryRepo.AssertNotCalled(t, "Save", mock.Anything)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, domain.StatusKontrakRestrukturisasi, restructured.StatusKontrak)
+					assert.True(t, restructured.AcceptsPayment())
+				}
+				assert.NoError(t, mockSQL.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestCreateTransaction_StoresIdempotencyKey(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	mockIdempotencyRepo := new(MockIdempotencyKeyRepository)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		mockIdempotencyRepo,
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(1000000), IdempotencyKey: "retry-123"}
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, CreditLimit: domain.NewMoney(5000000)}
+
+	var stored *domain.IdempotencyKey
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockIdempotencyRepo.On("FindByScopeAndKey", domain.IdempotencyScopeCreateTransaction, "retry-123").
+		Return(nil, gorm.ErrRecordNotFound).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	mockTransactionRepo.On("Save", mock.AnythingOfType("*domain.Transaction")).Return(nil).Once()
+	mockInstallmentRepo.On("SaveAll", mock.AnythingOfType("[]*domain.Installment")).Return(nil).Once()
+	mockIdempotencyRepo.On("Save", mock.AnythingOfType("*domain.IdempotencyKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(0).(*domain.IdempotencyKey) }).
+		Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "retry-123", stored.Kunci)
+	assert.Equal(t, domain.IdempotencyScopeCreateTransaction, stored.Scope)
+	assert.Contains(t, stored.ResponseBody, transaction.NomorKontrak)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockIdempotencyRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_ReplaysIdempotentRequest(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	mockIdempotencyRepo := new(MockIdempotencyKeyRepository)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		mockIdempotencyRepo,
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(1000000), IdempotencyKey: "retry-123"}
+	requestHash, err := idempotencyRequestHash(
+		struct {
+			ConsumerID uint
+			Input      CreateTransactionInput
+		}{consumerID, input},
+	)
+	assert.NoError(t, err)
+
+	stored := &domain.IdempotencyKey{
+		Scope:        domain.IdempotencyScopeCreateTransaction,
+		Kunci:        "retry-123",
+		RequestHash:  requestHash,
+		ResponseBody: `{"ID":42,"NomorKontrak":"KP/PST/202610/000001-0","PokokPembiayaanAwal":1000000.00}`,
+	}
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(&domain.Consumer{ID: consumerID}, nil).Once()
+	mockIdempotencyRepo.On("FindByScopeAndKey", domain.IdempotencyScopeCreateTransaction, "retry-123").
+		Return(stored, nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert: kontrak lama dikembalikan tanpa membuat kontrak baru
+	assert.NoError(t, err)
+	assert.Equal(t, uint(42), transaction.ID)
+	assert.Equal(t, "KP/PST/202610/000001-0", transaction.NomorKontrak)
+	assert.Equal(t, domain.NewMoney(1000000), transaction.PokokPembiayaanAwal)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "FindByConsumerAndTenor", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_IdempotencyKeyReusedWithDifferentBody(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	mockIdempotencyRepo := new(MockIdempotencyKeyRepository)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		mockIdempotencyRepo,
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(2000000), IdempotencyKey: "retry-123"}
+	stored := &domain.IdempotencyKey{
+		Scope:        domain.IdempotencyScopeCreateTransaction,
+		Kunci:        "retry-123",
+		RequestHash:  "hash-of-another-request",
+		ResponseBody: `{"ID":42}`,
+	}
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(&domain.Consumer{ID: consumerID}, nil).Once()
+	mockIdempotencyRepo.On("FindByScopeAndKey", domain.IdempotencyScopeCreateTransaction, "retry-123").
+		Return(stored, nil).Once()
+	mockSQL.ExpectRollback()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.Nil(t, transaction)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+	mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestCreateTransaction_ProductRules(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   CreateTransactionInput
+		errText string
+		code    string
+	}{
+		{
+			name:    "tenor tidak ditawarkan produk",
+			input:   CreateTransactionInput{TenorMonths: 1, Otr: domain.NewMoney(3000000), UangMuka: domain.NewMoney(600000)},
+			errText: "tenor 1 months is not offered for product MOTOR",
+			code:    RuleTenorNotOffered,
+		},
+		{
+			name:    "uang muka di bawah minimal",
+			input:   CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(3000000), UangMuka: domain.NewMoney(500000)},
+			errText: "down payment (500000.00) is below the minimum for product MOTOR (600000.00)",
+			code:    RuleDownPaymentBelowMinimum,
+		},
+		{
+			name: "biaya admin tidak sesuai produk",
+			input: CreateTransactionInput{
+				TenorMonths: 3,
+				Otr:         domain.NewMoney(3000000),
+				UangMuka:    domain.NewMoney(600000),
+				AdminFee:    domain.NewMoney(50000),
+			},
+			errText: "admin fee (50000.00) does not match the fee for product MOTOR (100000.00)",
+			code:    RuleAdminFeeMismatch,
+		},
+		{
+			name:    "melebihi pembiayaan maksimal produk",
+			input:   CreateTransactionInput{TenorMonths: 3, Otr: domain.NewMoney(6000000), UangMuka: domain.NewMoney(1200000)},
+			errText: "loan amount (4900000.00) exceeds maximum financing for product MOTOR (4000000.00)",
+			code:    RuleFinancingAboveMaximum,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				// Arrange
+				gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+				mockProductRepo := new(MockProductRepository)
+				mockProductRepo.On("FindByKode", "MOTOR").Return(newTestProduct(), nil)
+				usecase := NewTransactionUsecase(
+					gormDB,
+					mockTransactionRepo,
+					mockConsumerRepo,
+					mockLimitRepo,
+					mockInstallmentRepo,
+					new(MockTransactionStatusHistoryRepository),
+					new(MockIdempotencyKeyRepository),
+					newTestLimitHoldRepo(),
+					NewProductCatalog(mockProductRepo, DefaultTenorPricing),
+					newTestContractNumbers(),
+					DefaultCoolingOffPeriod,
+					DefaultLimitHoldTTL,
+				)
+
+				consumerID := uint(1)
+				input := tc.input
+				input.JenisAsset = "Motor"
+				consumer := &domain.Consumer{
+					ID:                 consumerID,
+					OverallCreditLimit: domain.NewMoney(20000000),
+					KYCVerification:    kycApproved,
+				}
+				creditLimit := &domain.ConsumerCreditLimit{
+					ID:          10,
+					ConsumerID:  consumerID,
+					TenorMonths: input.TenorMonths,
+					CreditLimit: domain.NewMoney(10000000),
+				}
+
+				mockSQL.ExpectBegin()
+				mockSQL.ExpectRollback()
+				mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+				mockLimitRepo.On("FindByConsumerAndTenor", consumerID, input.TenorMonths).Return(creditLimit, nil).Once()
+				mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+
+				// Act
+				transaction, err := usecase.CreateTransaction(consumerID, input)
+
+				// Assert
+				assert.Nil(t, transaction)
+				assert.EqualError(t, err, tc.errText)
+				if violation := AsRuleViolation(err); assert.NotNil(t, violation) {
+					assert.Equal(t, tc.code, violation.Code)
+				}
+				mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+				assert.NoError(t, mockSQL.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestCreateTransaction_UsesProductAdminFeeAndPricing(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	mockProductRepo := new(MockProductRepository)
+	mockProductRepo.On("FindByKode", "MOTOR").Return(newTestProduct(), nil)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		NewProductCatalog(mockProductRepo, DefaultTenorPricing),
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{
+		TenorMonths: 6,
+		Otr:         domain.NewMoney(3000000),
+		UangMuka:    domain.NewMoney(600000),
+		JenisAsset:  "MOTOR",
+	}
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(20000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{ID: 10, ConsumerID: consumerID, TenorMonths: 6, CreditLimit: domain.NewMoney(5000000)}
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, 6).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Once()
+	mockTransactionRepo.On("Save", mock.AnythingOfType("*domain.Transaction")).Return(nil).Once()
+	mockInstallmentRepo.On("SaveAll", mock.AnythingOfType("[]*domain.Installment")).Return(nil).Once()
+	mockSQL.ExpectCommit()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert: biaya admin diisi dari produk dan bunga mengikuti tenor 6 bulan produk (ANUITAS 30%)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMoney(100000), transaction.AdminFee)
+	assert.Equal(t, domain.NewMoney(2500000), transaction.PokokPembiayaanAwal)
+	assert.Equal(t, MetodeBungaAnuitas, transaction.MetodeBunga)
+	assert.Equal(t, 0.3, transaction.SukuBungaTahunan)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_RejectsFrozenCredit(t *testing.T) {
+	tests := []struct {
+		name        string
+		consumer    domain.CreditFreeze
+		creditLimit domain.CreditFreeze
+		wantCode    string
+	}{
+		{
+			name:     "consumer frozen",
+			consumer: domain.CreditFreeze{Dibekukan: true, KodePembekuan: domain.KodePembekuanFraud},
+			wantCode: RuleConsumerFrozen,
+		},
+		{
+			name:        "tenor limit frozen",
+			creditLimit: domain.CreditFreeze{Dibekukan: true, KodePembekuan: domain.KodePembekuanKYC},
+			wantCode:    RuleTenorLimitFrozen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				// Arrange
+				gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo :=
+					setupMocksAndDb(t)
+				usecase := NewTransactionUsecase(
+					gormDB,
+					mockTransactionRepo,
+					mockConsumerRepo,
+					mockLimitRepo,
+					mockInstallmentRepo,
+					new(MockTransactionStatusHistoryRepository),
+					new(MockIdempotencyKeyRepository),
+					newTestLimitHoldRepo(),
+					DefaultTenorPricing,
+					newTestContractNumbers(),
+					DefaultCoolingOffPeriod,
+					DefaultLimitHoldTTL,
+				)
+
+				consumerID := uint(1)
+				input := CreateTransactionInput{TenorMonths: 6, Otr: domain.NewMoney(1000000)}
+				consumer := &domain.Consumer{
+					ID:                 consumerID,
+					OverallCreditLimit: domain.NewMoney(10000000),
+					CreditFreeze:       tt.consumer,
+					KYCVerification:    kycApproved,
+				}
+				creditLimit := &domain.ConsumerCreditLimit{
+					ID:           10,
+					ConsumerID:   consumerID,
+					TenorMonths:  6,
+					CreditLimit:  domain.NewMoney(5000000),
+					CreditFreeze: tt.creditLimit,
+				}
+
+				mockSQL.ExpectBegin()
+				mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+				mockLimitRepo.On("FindByConsumerAndTenor", consumerID, 6).Return(creditLimit, nil).Once()
+				mockTransactionRepo.On("FindActiveByConsumerID", consumerID).
+					Return([]*domain.Transaction{}, nil).Maybe()
+				mockSQL.ExpectRollback()
+
+				// Act
+				transaction, err := usecase.CreateTransaction(consumerID, input)
+
+				// Assert
+				assert.Nil(t, transaction)
+				assert.ErrorIs(t, err, ErrCreditFrozen)
+				assert.Equal(t, tt.wantCode, AsRuleViolation(err).Code)
+				mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+				assert.NoError(t, mockSQL.ExpectationsWereMet())
+			},
+		)
+	}
+}
+
+func TestCreateTransaction_RejectsExpiredLimit(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 6, Otr: domain.NewMoney(1000000)}
+	kemarin := time.Now().AddDate(0, 0, -1)
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    kycApproved,
+	}
+	creditLimit := &domain.ConsumerCreditLimit{
+		ID:            10,
+		ConsumerID:    consumerID,
+		TenorMonths:   6,
+		CreditLimit:   domain.NewMoney(5000000),
+		BerlakuSampai: &kemarin,
+	}
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, 6).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Maybe()
+	mockSQL.ExpectRollback()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.Nil(t, transaction)
+	assert.Equal(t, RuleTenorLimitExpired, AsRuleViolation(err).Code)
+	assert.Contains(t, err.Error(), "credit limit for tenor 6 expired on "+kemarin.Format("2006-01-02"))
+	mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
+
+func TestCreateTransaction_RejectsUnapprovedKYC(t *testing.T) {
+	// Arrange
+	gormDB, mockSQL, mockConsumerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo := setupMocksAndDb(t)
+	usecase := NewTransactionUsecase(
+		gormDB,
+		mockTransactionRepo,
+		mockConsumerRepo,
+		mockLimitRepo,
+		mockInstallmentRepo,
+		new(MockTransactionStatusHistoryRepository),
+		new(MockIdempotencyKeyRepository),
+		newTestLimitHoldRepo(),
+		DefaultTenorPricing,
+		newTestContractNumbers(),
+		DefaultCoolingOffPeriod,
+		DefaultLimitHoldTTL,
+	)
+
+	consumerID := uint(1)
+	input := CreateTransactionInput{TenorMonths: 6, Otr: domain.NewMoney(1000000)}
+	consumer := &domain.Consumer{
+		ID:                 consumerID,
+		OverallCreditLimit: domain.NewMoney(10000000),
+		KYCVerification:    domain.KYCVerification{StatusKYC: domain.StatusKYCInReview},
+	}
+	creditLimit := &domain.ConsumerCreditLimit{
+		ID:          10,
+		ConsumerID:  consumerID,
+		TenorMonths: 6,
+		CreditLimit: domain.NewMoney(5000000),
+	}
+
+	mockSQL.ExpectBegin()
+	mockConsumerRepo.On("FindByIDForUpdate", consumerID).Return(consumer, nil).Once()
+	mockLimitRepo.On("FindByConsumerAndTenor", consumerID, 6).Return(creditLimit, nil).Once()
+	mockTransactionRepo.On("FindActiveByConsumerID", consumerID).Return([]*domain.Transaction{}, nil).Maybe()
+	mockSQL.ExpectRollback()
+
+	// Act
+	transaction, err := usecase.CreateTransaction(consumerID, input)
+
+	// Assert
+	assert.Nil(t, transaction)
+	assert.Equal(t, RuleKYCNotApproved, AsRuleViolation(err).Code)
+	assert.ErrorIs(t, err, ErrKYCNotApproved)
+	mockTransactionRepo.AssertNotCalled(t, "Save", mock.Anything)
+	assert.NoError(t, mockSQL.ExpectationsWereMet())
+}
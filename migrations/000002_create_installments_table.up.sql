@@ -0,0 +1,18 @@
+-- Migrations UP
+
+-- Tabel installments (jadwal angsuran per transaksi)
+CREATE TABLE IF NOT EXISTS installments (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    angsuran_ke INT NOT NULL,
+    tanggal_jatuh_tempo DATE NOT NULL,
+    pokok DECIMAL(19,2) NOT NULL,
+    bunga DECIMAL(19,2) NOT NULL,
+    jumlah_tagihan DECIMAL(19,2) NOT NULL,
+    jumlah_dibayar DECIMAL(19,2) NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_installment_transaction FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    CONSTRAINT idx_installment_transaction_periode UNIQUE (transaction_id, angsuran_ke)
+    );
@@ -0,0 +1,10 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS payment_allocations;
+DROP TABLE IF EXISTS payments;
+
+ALTER TABLE installments
+    DROP COLUMN IF EXISTS tanggal_lunas,
+    DROP COLUMN IF EXISTS pokok_dibayar,
+    DROP COLUMN IF EXISTS bunga_dibayar,
+    DROP COLUMN IF EXISTS denda_dibayar,
+    DROP COLUMN IF EXISTS denda;
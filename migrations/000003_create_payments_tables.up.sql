@@ -0,0 +1,45 @@
+-- Migrations UP
+
+-- Rincian pembayaran per komponen pada installments
+ALTER TABLE installments
+    ADD COLUMN IF NOT EXISTS denda DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS denda_dibayar DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS bunga_dibayar DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS pokok_dibayar DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tanggal_lunas TIMESTAMP WITH TIME ZONE;
+
+-- Tabel payments
+CREATE TABLE IF NOT EXISTS payments (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    tanggal_bayar TIMESTAMP WITH TIME ZONE NOT NULL,
+    jumlah DECIMAL(19,2) NOT NULL,
+    dialokasikan_denda DECIMAL(19,2) NOT NULL DEFAULT 0,
+    dialokasikan_bunga DECIMAL(19,2) NOT NULL DEFAULT 0,
+    dialokasikan_pokok DECIMAL(19,2) NOT NULL DEFAULT 0,
+    kelebihan_bayar DECIMAL(19,2) NOT NULL DEFAULT 0,
+    metode_pembayaran VARCHAR(50),
+    referensi VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_payment_transaction FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE RESTRICT
+    );
+
+CREATE INDEX IF NOT EXISTS idx_payments_transaction_id ON payments (transaction_id);
+
+-- Tabel payment_allocations
+CREATE TABLE IF NOT EXISTS payment_allocations (
+    id BIGSERIAL PRIMARY KEY,
+    payment_id BIGINT NOT NULL,
+    installment_id BIGINT NOT NULL,
+    angsuran_ke INT NOT NULL,
+    denda DECIMAL(19,2) NOT NULL DEFAULT 0,
+    bunga DECIMAL(19,2) NOT NULL DEFAULT 0,
+    pokok DECIMAL(19,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_allocation_payment FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_allocation_installment FOREIGN KEY (installment_id) REFERENCES installments(id) ON DELETE RESTRICT
+    );
+
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_payment_id ON payment_allocations (payment_id);
+CREATE INDEX IF NOT EXISTS idx_payment_allocations_installment_id ON payment_allocations (installment_id);
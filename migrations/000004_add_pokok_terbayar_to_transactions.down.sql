@@ -0,0 +1,2 @@
+-- Migrations DOWN
+ALTER TABLE transactions DROP COLUMN IF EXISTS pokok_terbayar;
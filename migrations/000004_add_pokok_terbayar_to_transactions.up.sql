@@ -0,0 +1,14 @@
+-- Migrations UP
+
+-- Pokok yang sudah dibayar kembali, dipakai untuk membebaskan plafon konsumen
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS pokok_terbayar DECIMAL(19,2) NOT NULL DEFAULT 0;
+
+UPDATE transactions t
+SET pokok_terbayar = paid.total
+FROM (
+    SELECT transaction_id, SUM(pokok_dibayar) AS total
+    FROM installments
+    GROUP BY transaction_id
+) paid
+WHERE paid.transaction_id = t.id;
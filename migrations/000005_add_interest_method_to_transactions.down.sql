@@ -0,0 +1,4 @@
+-- Migrations DOWN
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS suku_bunga_tahunan,
+    DROP COLUMN IF EXISTS metode_bunga;
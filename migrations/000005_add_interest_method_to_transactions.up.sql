@@ -0,0 +1,6 @@
+-- Migrations UP
+
+-- Metode dan suku bunga yang dipakai saat kontrak dibuat
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS metode_bunga VARCHAR(20) NOT NULL DEFAULT 'FLAT',
+    ADD COLUMN IF NOT EXISTS suku_bunga_tahunan DECIMAL(7,4) NOT NULL DEFAULT 0;
@@ -0,0 +1,16 @@
+-- Migrations UP
+
+-- Tabel transaction_status_histories
+CREATE TABLE IF NOT EXISTS transaction_status_histories (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL,
+    from_status VARCHAR(30) NOT NULL,
+    to_status VARCHAR(30) NOT NULL,
+    alasan TEXT,
+    changed_by BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_status_history_transaction FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_status_history_user FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_transaction_status_histories_transaction_id ON transaction_status_histories (transaction_id);
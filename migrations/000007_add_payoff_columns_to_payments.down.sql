@@ -0,0 +1,4 @@
+-- Migrations DOWN
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS jenis_pembayaran,
+    DROP COLUMN IF EXISTS biaya_pelunasan;
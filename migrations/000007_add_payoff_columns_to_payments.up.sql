@@ -0,0 +1,6 @@
+-- Migrations UP
+
+-- Pelunasan dipercepat dicatat sebagai pembayaran berjenis PELUNASAN beserta biayanya
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS biaya_pelunasan DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS jenis_pembayaran VARCHAR(20) NOT NULL DEFAULT 'ANGSURAN';
@@ -0,0 +1,8 @@
+-- Migrations DOWN
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS kolektibilitas,
+    DROP COLUMN IF EXISTS hari_keterlambatan;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS kolektibilitas,
+    DROP COLUMN IF EXISTS hari_keterlambatan;
@@ -0,0 +1,10 @@
+-- Migrations UP
+
+-- Hari keterlambatan (DPD) dan kolektibilitas OJK diperbarui oleh job penilaian keterlambatan harian
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS hari_keterlambatan INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS kolektibilitas VARCHAR(20) NOT NULL DEFAULT 'LANCAR';
+
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS hari_keterlambatan INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS kolektibilitas VARCHAR(20) NOT NULL DEFAULT 'LANCAR';
@@ -0,0 +1,8 @@
+-- Migrations UP
+
+-- Nomor urut nomor kontrak per cabang per bulan (kunci: KODE_CABANG/YYYYMM)
+CREATE TABLE IF NOT EXISTS contract_sequences (
+    kunci VARCHAR(50) PRIMARY KEY,
+    nilai_terakhir BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+-- Migrations UP
+
+-- Respons tersimpan untuk permintaan dengan header Idempotency-Key
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id BIGSERIAL PRIMARY KEY,
+    scope VARCHAR(50) NOT NULL,
+    kunci VARCHAR(100) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    response_body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_scope_kunci ON idempotency_keys (scope, kunci);
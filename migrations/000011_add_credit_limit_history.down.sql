@@ -0,0 +1,13 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS credit_limit_history;
+
+DELETE FROM consumer_credit_limits WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_consumer_credit_limits_deleted_at;
+DROP INDEX IF EXISTS idx_consumer_credit_limits_consumer_tenor;
+
+ALTER TABLE consumer_credit_limits
+    ADD CONSTRAINT consumer_credit_limits_consumer_id_tenor_months_key UNIQUE (consumer_id, tenor_months);
+
+ALTER TABLE consumer_credit_limits
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,33 @@
+-- Migrations UP
+
+-- Limit per tenor dihapus secara soft delete agar kontrak lama tetap merujuk ke barisnya,
+-- sehingga keunikan (consumer_id, tenor_months) hanya berlaku untuk limit yang belum dihapus.
+ALTER TABLE consumer_credit_limits
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE consumer_credit_limits
+    DROP CONSTRAINT IF EXISTS consumer_credit_limits_consumer_id_tenor_months_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_consumer_credit_limits_consumer_tenor
+    ON consumer_credit_limits (consumer_id, tenor_months) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_consumer_credit_limits_deleted_at ON consumer_credit_limits (deleted_at);
+
+-- Tabel credit_limit_history
+CREATE TABLE IF NOT EXISTS credit_limit_history (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id BIGINT NOT NULL,
+    consumer_credit_limit_id BIGINT NOT NULL,
+    tenor_months INT NOT NULL,
+    aksi VARCHAR(20) NOT NULL,
+    limit_sebelum DECIMAL(15,2) NOT NULL DEFAULT 0,
+    limit_sesudah DECIMAL(15,2) NOT NULL DEFAULT 0,
+    alasan TEXT,
+    changed_by BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_credit_limit_history_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_credit_limit_history_limit FOREIGN KEY (consumer_credit_limit_id) REFERENCES consumer_credit_limits(id) ON DELETE CASCADE,
+    CONSTRAINT fk_credit_limit_history_user FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE RESTRICT
+    );
+
+CREATE INDEX IF NOT EXISTS idx_credit_limit_history_consumer_id ON credit_limit_history (consumer_id);
@@ -0,0 +1,3 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS product_tenors;
+DROP TABLE IF EXISTS products;
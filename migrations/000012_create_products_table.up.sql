@@ -0,0 +1,29 @@
+-- Migrations UP
+
+-- Tabel products: katalog produk pembiayaan. Kode produk dicocokkan dengan jenis_asset pada transaksi.
+CREATE TABLE IF NOT EXISTS products (
+    id BIGSERIAL PRIMARY KEY,
+    kode VARCHAR(50) NOT NULL UNIQUE,
+    nama VARCHAR(100) NOT NULL,
+    minimal_uang_muka_persen DECIMAL(5,4) NOT NULL DEFAULT 0,
+    maksimal_pembiayaan DECIMAL(19,2) NOT NULL DEFAULT 0,
+    biaya_admin_tetap DECIMAL(19,2) NOT NULL DEFAULT 0,
+    biaya_admin_persen DECIMAL(5,4) NOT NULL DEFAULT 0,
+    aktif BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+    );
+
+-- Tabel product_tenors: tenor yang ditawarkan setiap produk beserta kebijakan bunga dan dendanya.
+CREATE TABLE IF NOT EXISTS product_tenors (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL,
+    tenor_bulan INT NOT NULL,
+    metode_bunga VARCHAR(20) NOT NULL,
+    suku_bunga_tahunan DECIMAL(7,4) NOT NULL DEFAULT 0,
+    biaya_pelunasan_dipercepat DECIMAL(7,4) NOT NULL DEFAULT 0,
+    denda_harian DECIMAL(7,4) NOT NULL DEFAULT 0,
+    maksimal_denda DECIMAL(7,4) NOT NULL DEFAULT 0,
+    CONSTRAINT fk_product_tenors_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT idx_product_tenors_product_tenor UNIQUE (product_id, tenor_bulan)
+    );
@@ -0,0 +1,7 @@
+-- Migrations DOWN
+ALTER TABLE products
+    DROP COLUMN IF EXISTS maksimal_biaya_admin,
+    DROP COLUMN IF EXISTS minimal_biaya_admin,
+    DROP COLUMN IF EXISTS maksimal_otr,
+    DROP COLUMN IF EXISTS minimal_otr,
+    DROP COLUMN IF EXISTS maksimal_ltv;
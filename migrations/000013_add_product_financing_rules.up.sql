@@ -0,0 +1,9 @@
+-- Migrations UP
+
+-- Aturan pembiayaan tambahan per produk: batas LTV, rentang OTR, dan rentang biaya admin (nol berarti tanpa batas).
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS maksimal_ltv DECIMAL(5,4) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS minimal_otr DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS maksimal_otr DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS minimal_biaya_admin DECIMAL(19,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS maksimal_biaya_admin DECIMAL(19,2) NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS limit_holds;
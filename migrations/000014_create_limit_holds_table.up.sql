@@ -0,0 +1,27 @@
+-- Migrations UP
+
+-- Tabel limit_holds: penahanan sementara plafon dan limit tenor selama checkout merchant
+CREATE TABLE IF NOT EXISTS limit_holds (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id BIGINT NOT NULL,
+    consumer_credit_limit_id BIGINT NOT NULL,
+    tenor_bulan INT NOT NULL,
+    jumlah DECIMAL(19,2) NOT NULL,
+    otr DECIMAL(19,2) NOT NULL,
+    uang_muka DECIMAL(19,2) NOT NULL DEFAULT 0,
+    admin_fee DECIMAL(19,2) NOT NULL DEFAULT 0,
+    nama_asset VARCHAR(255),
+    jenis_asset VARCHAR(50),
+    sumber_transaksi VARCHAR(100),
+    status VARCHAR(20) NOT NULL,
+    kedaluwarsa_pada TIMESTAMP WITH TIME ZONE NOT NULL,
+    transaction_id BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_limit_hold_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_limit_hold_consumer_limit FOREIGN KEY (consumer_credit_limit_id) REFERENCES consumer_credit_limits(id) ON DELETE CASCADE,
+    CONSTRAINT fk_limit_hold_transaction FOREIGN KEY (transaction_id) REFERENCES transactions(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_limit_holds_consumer_id ON limit_holds (consumer_id);
+CREATE INDEX IF NOT EXISTS idx_limit_holds_status ON limit_holds (status);
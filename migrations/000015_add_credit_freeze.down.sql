@@ -0,0 +1,16 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS credit_freeze_histories;
+
+ALTER TABLE consumer_credit_limits
+    DROP COLUMN IF EXISTS dibekukan_pada,
+    DROP COLUMN IF EXISTS dibekukan_oleh,
+    DROP COLUMN IF EXISTS catatan_pembekuan,
+    DROP COLUMN IF EXISTS kode_pembekuan,
+    DROP COLUMN IF EXISTS dibekukan;
+
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS dibekukan_pada,
+    DROP COLUMN IF EXISTS dibekukan_oleh,
+    DROP COLUMN IF EXISTS catatan_pembekuan,
+    DROP COLUMN IF EXISTS kode_pembekuan,
+    DROP COLUMN IF EXISTS dibekukan;
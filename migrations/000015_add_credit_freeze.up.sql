@@ -0,0 +1,35 @@
+-- Migrations UP
+
+-- Status pembekuan kredit: konsumen atau limit tenor yang dibekukan tidak dapat menarik pinjaman baru.
+-- dibekukan_oleh bernilai NULL untuk pembekuan otomatis oleh sistem.
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS dibekukan BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS kode_pembekuan VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS catatan_pembekuan TEXT,
+    ADD COLUMN IF NOT EXISTS dibekukan_oleh BIGINT,
+    ADD COLUMN IF NOT EXISTS dibekukan_pada TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE consumer_credit_limits
+    ADD COLUMN IF NOT EXISTS dibekukan BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS kode_pembekuan VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS catatan_pembekuan TEXT,
+    ADD COLUMN IF NOT EXISTS dibekukan_oleh BIGINT,
+    ADD COLUMN IF NOT EXISTS dibekukan_pada TIMESTAMP WITH TIME ZONE;
+
+-- Tabel credit_freeze_histories: riwayat pembekuan dan pencairan kredit konsumen maupun limit tenor
+CREATE TABLE IF NOT EXISTS credit_freeze_histories (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id BIGINT NOT NULL,
+    consumer_credit_limit_id BIGINT,
+    tenor_months INT NOT NULL DEFAULT 0,
+    aksi VARCHAR(20) NOT NULL,
+    kode_pembekuan VARCHAR(30),
+    alasan TEXT,
+    changed_by BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_credit_freeze_history_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_credit_freeze_history_limit FOREIGN KEY (consumer_credit_limit_id) REFERENCES consumer_credit_limits(id) ON DELETE CASCADE,
+    CONSTRAINT fk_credit_freeze_history_user FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_credit_freeze_histories_consumer_id ON credit_freeze_histories (consumer_id);
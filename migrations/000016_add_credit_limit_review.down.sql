@@ -0,0 +1,12 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS credit_limit_review_items;
+DROP TABLE IF EXISTS credit_limit_reviews;
+
+DROP INDEX IF EXISTS idx_consumer_credit_limits_berlaku_sampai;
+DROP INDEX IF EXISTS idx_consumers_plafon_berlaku_sampai;
+
+ALTER TABLE consumer_credit_limits
+    DROP COLUMN IF EXISTS berlaku_sampai;
+
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS plafon_berlaku_sampai;
@@ -0,0 +1,47 @@
+-- Migrations UP
+
+-- Masa berlaku plafon dan limit tenor. NULL berarti belum pernah ditinjau sehingga ikut masuk antrean
+-- peninjauan berikutnya tanpa langsung menolak transaksi.
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS plafon_berlaku_sampai TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE consumer_credit_limits
+    ADD COLUMN IF NOT EXISTS berlaku_sampai TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_consumers_plafon_berlaku_sampai ON consumers (plafon_berlaku_sampai);
+CREATE INDEX IF NOT EXISTS idx_consumer_credit_limits_berlaku_sampai ON consumer_credit_limits (berlaku_sampai);
+
+-- Tabel credit_limit_reviews: usulan plafon hasil peninjauan berkala yang menunggu keputusan admin
+CREATE TABLE IF NOT EXISTS credit_limit_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    versi_aturan VARCHAR(50),
+    eligible BOOLEAN NOT NULL DEFAULT FALSE,
+    berlaku_sampai TIMESTAMP WITH TIME ZONE,
+    plafon_sebelum DECIMAL(19,2) NOT NULL DEFAULT 0,
+    plafon_usulan DECIMAL(19,2) NOT NULL DEFAULT 0,
+    alasan TEXT,
+    diputuskan_oleh BIGINT,
+    diputuskan_pada TIMESTAMP WITH TIME ZONE,
+    catatan_keputusan TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_credit_limit_review_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_credit_limit_review_user FOREIGN KEY (diputuskan_oleh) REFERENCES users(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_credit_limit_reviews_consumer_id ON credit_limit_reviews (consumer_id);
+CREATE INDEX IF NOT EXISTS idx_credit_limit_reviews_status ON credit_limit_reviews (status);
+
+-- Tabel credit_limit_review_items: usulan limit per tenor dari sebuah peninjauan
+CREATE TABLE IF NOT EXISTS credit_limit_review_items (
+    id BIGSERIAL PRIMARY KEY,
+    credit_limit_review_id BIGINT NOT NULL,
+    tenor_months INT NOT NULL,
+    limit_sebelum DECIMAL(15,2) NOT NULL DEFAULT 0,
+    limit_usulan DECIMAL(15,2) NOT NULL DEFAULT 0,
+    CONSTRAINT fk_credit_limit_review_item_review FOREIGN KEY (credit_limit_review_id) REFERENCES credit_limit_reviews(id) ON DELETE CASCADE
+    );
+
+CREATE INDEX IF NOT EXISTS idx_credit_limit_review_items_review_id ON credit_limit_review_items (credit_limit_review_id);
@@ -0,0 +1,11 @@
+-- Migrations DOWN
+DROP TABLE IF EXISTS kyc_histories;
+
+DROP INDEX IF EXISTS idx_consumers_status_kyc;
+
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS kyc_catatan,
+    DROP COLUMN IF EXISTS kyc_diputuskan_pada,
+    DROP COLUMN IF EXISTS kyc_reviewer,
+    DROP COLUMN IF EXISTS kyc_diajukan_pada,
+    DROP COLUMN IF EXISTS status_kyc;
@@ -0,0 +1,29 @@
+-- Migrations UP
+
+-- Status verifikasi KYC konsumen. Konsumen yang sudah ada dianggap telah terverifikasi (APPROVED),
+-- sedangkan konsumen baru dimulai dari DRAFT.
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS status_kyc VARCHAR(20) NOT NULL DEFAULT 'APPROVED',
+    ADD COLUMN IF NOT EXISTS kyc_diajukan_pada TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS kyc_reviewer BIGINT,
+    ADD COLUMN IF NOT EXISTS kyc_diputuskan_pada TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS kyc_catatan TEXT;
+
+ALTER TABLE consumers ALTER COLUMN status_kyc SET DEFAULT 'DRAFT';
+
+CREATE INDEX IF NOT EXISTS idx_consumers_status_kyc ON consumers (status_kyc);
+
+-- Tabel kyc_histories: riwayat pengajuan, peninjauan, dan keputusan KYC konsumen
+CREATE TABLE IF NOT EXISTS kyc_histories (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id BIGINT NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    alasan TEXT,
+    changed_by BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_kyc_history_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_kyc_history_user FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_kyc_histories_consumer_id ON kyc_histories (consumer_id);
@@ -0,0 +1,3 @@
+-- Migrations DOWN
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS jenis_kelamin;
@@ -0,0 +1,10 @@
+-- Migrations UP
+
+-- Jenis kelamin diturunkan dari NIK: tanggal lahir pada digit 7-8 ditambah 40 untuk perempuan.
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS jenis_kelamin VARCHAR(20);
+
+UPDATE consumers
+SET jenis_kelamin = CASE WHEN SUBSTRING(nik FROM 7 FOR 2)::INT > 40 THEN 'PEREMPUAN' ELSE 'LAKI_LAKI' END
+WHERE jenis_kelamin IS NULL
+  AND nik ~ '^[0-9]{16}$';
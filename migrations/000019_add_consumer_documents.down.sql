@@ -0,0 +1,23 @@
+-- Migrations DOWN
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS foto_ktp VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS foto_selfie VARCHAR(255);
+
+-- Hanya dokumen pada backend lokal yang dapat dikembalikan menjadi path di direktori uploads/.
+UPDATE consumers c
+SET foto_ktp = 'uploads/' || d.storage_key
+FROM consumer_documents d
+WHERE d.id = c.foto_ktp_id AND d.storage_backend = 'local';
+
+UPDATE consumers c
+SET foto_selfie = 'uploads/' || d.storage_key
+FROM consumer_documents d
+WHERE d.id = c.foto_selfie_id AND d.storage_backend = 'local';
+
+ALTER TABLE consumers
+    DROP CONSTRAINT IF EXISTS fk_consumer_foto_selfie,
+    DROP CONSTRAINT IF EXISTS fk_consumer_foto_ktp,
+    DROP COLUMN IF EXISTS foto_selfie_id,
+    DROP COLUMN IF EXISTS foto_ktp_id;
+
+DROP TABLE IF EXISTS consumer_documents;
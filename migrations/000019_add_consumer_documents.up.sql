@@ -0,0 +1,56 @@
+-- Migrations UP
+
+-- Tabel consumer_documents: metadata dokumen konsumen (foto KTP dan selfie) yang isinya disimpan di
+-- DOCUMENT_STORAGE (filesystem lokal atau object storage S3-compatible).
+CREATE TABLE IF NOT EXISTS consumer_documents (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_id BIGINT NOT NULL,
+    jenis VARCHAR(20) NOT NULL,
+    storage_backend VARCHAR(20) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    nama_file VARCHAR(255),
+    content_type VARCHAR(100) NOT NULL,
+    ukuran BIGINT NOT NULL DEFAULT 0,
+    sha256 VARCHAR(64),
+    diunggah_oleh BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_consumer_document_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_consumer_document_user FOREIGN KEY (diunggah_oleh) REFERENCES users(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_consumer_documents_consumer_id ON consumer_documents (consumer_id);
+
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS foto_ktp_id BIGINT,
+    ADD COLUMN IF NOT EXISTS foto_selfie_id BIGINT,
+    ADD CONSTRAINT fk_consumer_foto_ktp FOREIGN KEY (foto_ktp_id) REFERENCES consumer_documents(id) ON DELETE SET NULL,
+    ADD CONSTRAINT fk_consumer_foto_selfie FOREIGN KEY (foto_selfie_id)
+        REFERENCES consumer_documents(id) ON DELETE SET NULL;
+
+-- Path file lama di direktori uploads/ dipindahkan menjadi dokumen pada backend lokal. Ukuran dan hash
+-- isinya tidak diketahui dari path sehingga dibiarkan kosong.
+INSERT INTO consumer_documents (consumer_id, jenis, storage_backend, storage_key, nama_file, content_type)
+SELECT id, 'KTP', 'local', regexp_replace(foto_ktp, '^uploads/', ''), foto_ktp,
+       CASE WHEN foto_ktp ILIKE '%.png' THEN 'image/png' ELSE 'image/jpeg' END
+FROM consumers
+WHERE foto_ktp IS NOT NULL AND foto_ktp <> '';
+
+INSERT INTO consumer_documents (consumer_id, jenis, storage_backend, storage_key, nama_file, content_type)
+SELECT id, 'SELFIE', 'local', regexp_replace(foto_selfie, '^uploads/', ''), foto_selfie,
+       CASE WHEN foto_selfie ILIKE '%.png' THEN 'image/png' ELSE 'image/jpeg' END
+FROM consumers
+WHERE foto_selfie IS NOT NULL AND foto_selfie <> '';
+
+UPDATE consumers c
+SET foto_ktp_id = d.id
+FROM consumer_documents d
+WHERE d.consumer_id = c.id AND d.jenis = 'KTP';
+
+UPDATE consumers c
+SET foto_selfie_id = d.id
+FROM consumer_documents d
+WHERE d.consumer_id = c.id AND d.jenis = 'SELFIE';
+
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS foto_ktp,
+    DROP COLUMN IF EXISTS foto_selfie;
@@ -0,0 +1,20 @@
+-- Migrations UP
+
+-- Tabel document_access_logs: audit log setiap unduhan dokumen identitas konsumen dan pembuatan signed URL-nya
+CREATE TABLE IF NOT EXISTS document_access_logs (
+    id BIGSERIAL PRIMARY KEY,
+    consumer_document_id BIGINT NOT NULL,
+    consumer_id BIGINT NOT NULL,
+    aksi VARCHAR(30) NOT NULL,
+    diakses_oleh BIGINT,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_document_access_log_document FOREIGN KEY (consumer_document_id)
+        REFERENCES consumer_documents(id) ON DELETE CASCADE,
+    CONSTRAINT fk_document_access_log_consumer FOREIGN KEY (consumer_id) REFERENCES consumers(id) ON DELETE CASCADE,
+    CONSTRAINT fk_document_access_log_user FOREIGN KEY (diakses_oleh) REFERENCES users(id) ON DELETE SET NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_document_access_logs_consumer_document_id ON document_access_logs (consumer_document_id);
+CREATE INDEX IF NOT EXISTS idx_document_access_logs_consumer_id ON document_access_logs (consumer_id);
@@ -0,0 +1,20 @@
+-- Migrations DOWN
+
+-- Kolom hanya dapat dikembalikan ke tipe semula jika seluruh data pribadi sudah didekripsi; ciphertext tidak
+-- dapat dikonversi ke DATE atau DECIMAL.
+DROP INDEX IF EXISTS idx_consumers_nik_hash;
+
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS nik_hash;
+
+ALTER TABLE consumers
+    ALTER COLUMN nik TYPE VARCHAR(16) USING nik::VARCHAR(16),
+    ALTER COLUMN full_name TYPE VARCHAR(255) USING full_name::VARCHAR(255),
+    ALTER COLUMN legal_name TYPE VARCHAR(255) USING legal_name::VARCHAR(255),
+    ALTER COLUMN tanggal_lahir TYPE DATE USING tanggal_lahir::DATE,
+    ALTER COLUMN gaji TYPE DECIMAL(15,2) USING gaji::DECIMAL(15,2);
+
+ALTER TABLE consumers
+    ADD CONSTRAINT consumers_nik_key UNIQUE (nik);
+
+DROP TABLE IF EXISTS pii_data_keys;
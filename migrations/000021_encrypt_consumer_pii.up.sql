@@ -0,0 +1,31 @@
+-- Migrations UP
+
+-- Tabel pii_data_keys: data encryption key (DEK) untuk data pribadi konsumen, disimpan terbungkus KEK
+CREATE TABLE IF NOT EXISTS pii_data_keys (
+    id BIGSERIAL PRIMARY KEY,
+    kek_id VARCHAR(64) NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    aktif BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX IF NOT EXISTS idx_pii_data_keys_aktif ON pii_data_keys (aktif);
+
+-- Kolom data pribadi menjadi TEXT agar dapat menampung ciphertext. Baris lama tetap plaintext dan terbaca
+-- sampai dienkripsi ulang dengan `./kredit-app --reencrypt-pii`.
+ALTER TABLE consumers
+    ALTER COLUMN nik TYPE TEXT USING nik::TEXT,
+    ALTER COLUMN full_name TYPE TEXT USING full_name::TEXT,
+    ALTER COLUMN legal_name TYPE TEXT USING legal_name::TEXT,
+    ALTER COLUMN tanggal_lahir TYPE TEXT USING tanggal_lahir::TEXT,
+    ALTER COLUMN gaji TYPE TEXT USING gaji::TEXT;
+
+-- Keunikan NIK dijaga oleh blind index (HMAC) karena ciphertext NIK yang sama selalu berbeda.
+ALTER TABLE consumers DROP CONSTRAINT IF EXISTS consumers_nik_key;
+DROP INDEX IF EXISTS idx_consumers_nik;
+
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS nik_hash VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_consumers_nik_hash ON consumers (nik_hash);
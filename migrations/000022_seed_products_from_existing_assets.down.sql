@@ -0,0 +1,5 @@
+-- Migrations DOWN
+
+-- Produk hasil migrasi UP tidak dihapus: produk tersebut dapat sudah diubah admin dan dipakai pengajuan baru,
+-- dan menghapusnya membuat pengajuan untuk jenis aset yang sama kembali ditolak.
+SELECT 1;
@@ -0,0 +1,29 @@
+-- Migrations UP
+
+-- Setiap jenis_asset yang sudah dipakai transaksi atau penahanan limit didaftarkan sebagai produk aktif agar
+-- pengajuan baru untuk jenis aset yang sama tidak ditolak dengan PRODUCT_NOT_AVAILABLE setelah upgrade.
+-- Kode dinormalisasi seperti domain.NormalizeProductCode ("white goods" menjadi "WHITE_GOODS"). Aturan
+-- pembiayaan dibiarkan nol (tanpa batas) dan tenornya mengikuti harga default sebelum katalog produk ada:
+-- 1, 2, 3, dan 6 bulan FLAT 24% per tahun dengan denda harian 0,1% maksimal 50%. Produk yang sudah terdaftar
+-- tidak diubah.
+WITH existing_assets AS (
+    SELECT UPPER(REGEXP_REPLACE(BTRIM(jenis_asset), '\s+', '_', 'g')) AS kode, BTRIM(jenis_asset) AS nama
+    FROM transactions
+    WHERE BTRIM(COALESCE(jenis_asset, '')) <> ''
+    UNION ALL
+    SELECT UPPER(REGEXP_REPLACE(BTRIM(jenis_asset), '\s+', '_', 'g')) AS kode, BTRIM(jenis_asset) AS nama
+    FROM limit_holds
+    WHERE BTRIM(COALESCE(jenis_asset, '')) <> ''
+),
+seeded_products AS (
+    INSERT INTO products (kode, nama, aktif)
+    SELECT kode, MIN(nama), TRUE
+    FROM existing_assets
+    GROUP BY kode
+    ON CONFLICT (kode) DO NOTHING
+    RETURNING id
+)
+INSERT INTO product_tenors (product_id, tenor_bulan, metode_bunga, suku_bunga_tahunan, denda_harian, maksimal_denda)
+SELECT seeded_products.id, tenor.bulan, 'FLAT', 0.24, 0.001, 0.5
+FROM seeded_products
+CROSS JOIN (VALUES (1), (2), (3), (6)) AS tenor (bulan);
@@ -0,0 +1,5 @@
+-- Migrations DOWN
+DROP INDEX IF EXISTS idx_consumers_full_name_tokens;
+
+ALTER TABLE consumers
+    DROP COLUMN IF EXISTS full_name_tokens;
@@ -0,0 +1,9 @@
+-- Migrations UP
+
+-- Blind index per kata nama lengkap konsumen (HMAC, dipisahkan spasi) untuk pencarian nama tanpa mendekripsi
+-- data. Baris lama terisi setelah `./kredit-app --reencrypt-pii` dijalankan.
+ALTER TABLE consumers
+    ADD COLUMN IF NOT EXISTS full_name_tokens TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_consumers_full_name_tokens
+    ON consumers USING GIN (string_to_array(full_name_tokens, ' '));
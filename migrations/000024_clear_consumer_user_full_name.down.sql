@@ -0,0 +1,4 @@
+-- Migrations DOWN
+
+-- Nama yang dihapus tidak dikembalikan karena plaintext-nya hanya tersedia setelah didekripsi aplikasi.
+SELECT 1;
@@ -0,0 +1,8 @@
+-- Migrations UP
+
+-- Nama konsumen sebelumnya disalin sebagai plaintext ke users.full_name saat pendaftaran. Nama tersebut sudah
+-- tersimpan terenkripsi di consumers.full_name, sehingga salinannya dihapus.
+UPDATE users
+SET full_name = ''
+FROM consumers
+WHERE consumers.user_id = users.id;